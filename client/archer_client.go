@@ -28,6 +28,7 @@ import (
 
 	"github.com/sapcc/archer/v2/client/agent"
 	"github.com/sapcc/archer/v2/client/endpoint"
+	"github.com/sapcc/archer/v2/client/irule"
 	"github.com/sapcc/archer/v2/client/quota"
 	"github.com/sapcc/archer/v2/client/rbac"
 	"github.com/sapcc/archer/v2/client/service"
@@ -78,6 +79,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Archer {
 	cli.Transport = transport
 	cli.Agent = agent.New(transport, formats)
 	cli.Endpoint = endpoint.New(transport, formats)
+	cli.Irule = irule.New(transport, formats)
 	cli.Quota = quota.New(transport, formats)
 	cli.Rbac = rbac.New(transport, formats)
 	cli.Service = service.New(transport, formats)
@@ -130,6 +132,8 @@ type Archer struct {
 
 	Endpoint endpoint.ClientService
 
+	Irule irule.ClientService
+
 	Quota quota.ClientService
 
 	Rbac rbac.ClientService
@@ -146,6 +150,7 @@ func (c *Archer) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
 	c.Agent.SetTransport(transport)
 	c.Endpoint.SetTransport(transport)
+	c.Irule.SetTransport(transport)
 	c.Quota.SetTransport(transport)
 	c.Rbac.SetTransport(transport)
 	c.Service.SetTransport(transport)
@@ -437,6 +437,17 @@ swagger:model PutEndpointEndpointIDBody
 */
 type PutEndpointEndpointIDBody struct {
 
+	// Source networks (CIDR notation) allowed to connect to the endpoint. Omit to leave unchanged, set to an empty list to allow all sources.
+	//
+	// Max Items: 64
+	// Unique: true
+	AllowedCidrs []models.CIDR `json:"allowed_cidrs"`
+
+	// Maximum number of concurrent connections of this endpoint, overrides the `connection_limit` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.
+	//
+	// Minimum: -1
+	ConnectionLimit *int32 `json:"connection_limit,omitempty"`
+
 	// Enable BIG-IP connection mirroring for high availability failover.
 	// **Note: This option currently only affects endpoints for services with provider type `tenant`.**
 	//
@@ -452,6 +463,22 @@ type PutEndpointEndpointIDBody struct {
 	// Max Length: 64
 	Name *string `json:"name,omitempty"`
 
+	// Listen ports of the endpoint differing from the service port. Omit to leave unchanged, set to an empty list to listen on the service ports.
+	//
+	// Max Items: 8
+	PortMappings []*models.PortMapping `json:"port_mappings"`
+
+	// Subset of the service ports exposed by the endpoint. Omit to leave unchanged, set to an empty list to expose all ports of the service.
+	//
+	// Max Items: 8
+	// Unique: true
+	Ports []int32 `json:"ports"`
+
+	// Maximum number of new connections per second of this endpoint, overrides the `rate_limit` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.
+	//
+	// Minimum: -1
+	RateLimit *int32 `json:"rate_limit,omitempty"`
+
 	// The list of tags on the resource.
 	Tags []string `json:"tags"`
 }
@@ -460,6 +487,14 @@ type PutEndpointEndpointIDBody struct {
 func (o *PutEndpointEndpointIDBody) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := o.validateAllowedCidrs(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validateConnectionLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := o.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
@@ -468,6 +503,18 @@ func (o *PutEndpointEndpointIDBody) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := o.validatePortMappings(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validatePorts(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validateRateLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := o.validateTags(formats); err != nil {
 		res = append(res, err)
 	}
@@ -478,6 +525,53 @@ func (o *PutEndpointEndpointIDBody) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (o *PutEndpointEndpointIDBody) validateAllowedCidrs(formats strfmt.Registry) error {
+	if swag.IsZero(o.AllowedCidrs) { // not required
+		return nil
+	}
+
+	iAllowedCidrsSize := int64(len(o.AllowedCidrs))
+
+	if err := validate.MaxItems("body"+"."+"allowed_cidrs", "body", iAllowedCidrsSize, 64); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("body"+"."+"allowed_cidrs", "body", o.AllowedCidrs); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(o.AllowedCidrs); i++ {
+
+		if err := o.AllowedCidrs[i].Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("body" + "." + "allowed_cidrs" + "." + strconv.Itoa(i))
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("body" + "." + "allowed_cidrs" + "." + strconv.Itoa(i))
+			}
+
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (o *PutEndpointEndpointIDBody) validateConnectionLimit(formats strfmt.Registry) error {
+	if swag.IsZero(o.ConnectionLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("body"+"."+"connection_limit", "body", int64(*o.ConnectionLimit), -1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (o *PutEndpointEndpointIDBody) validateDescription(formats strfmt.Registry) error {
 	if swag.IsZero(o.Description) { // not required
 		return nil
@@ -502,6 +596,84 @@ func (o *PutEndpointEndpointIDBody) validateName(formats strfmt.Registry) error
 	return nil
 }
 
+func (o *PutEndpointEndpointIDBody) validatePortMappings(formats strfmt.Registry) error {
+	if swag.IsZero(o.PortMappings) { // not required
+		return nil
+	}
+
+	iPortMappingsSize := int64(len(o.PortMappings))
+
+	if err := validate.MaxItems("body"+"."+"port_mappings", "body", iPortMappingsSize, 8); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(o.PortMappings); i++ {
+		if swag.IsZero(o.PortMappings[i]) { // not required
+			continue
+		}
+
+		if o.PortMappings[i] != nil {
+			if err := o.PortMappings[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("body" + "." + "port_mappings" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("body" + "." + "port_mappings" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (o *PutEndpointEndpointIDBody) validatePorts(formats strfmt.Registry) error {
+	if swag.IsZero(o.Ports) { // not required
+		return nil
+	}
+
+	iPortsSize := int64(len(o.Ports))
+
+	if err := validate.MaxItems("body"+"."+"ports", "body", iPortsSize, 8); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("body"+"."+"ports", "body", o.Ports); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(o.Ports); i++ {
+
+		if err := validate.MinimumInt("body"+"."+"ports"+"."+strconv.Itoa(i), "body", int64(o.Ports[i]), 0, false); err != nil {
+			return err
+		}
+
+		if err := validate.MaximumInt("body"+"."+"ports"+"."+strconv.Itoa(i), "body", int64(o.Ports[i]), 65535, false); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (o *PutEndpointEndpointIDBody) validateRateLimit(formats strfmt.Registry) error {
+	if swag.IsZero(o.RateLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("body"+"."+"rate_limit", "body", int64(*o.RateLimit), -1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (o *PutEndpointEndpointIDBody) validateTags(formats strfmt.Registry) error {
 	if swag.IsZero(o.Tags) { // not required
 		return nil
@@ -520,6 +692,44 @@ func (o *PutEndpointEndpointIDBody) validateTags(formats strfmt.Registry) error
 
 // ContextValidate validates this put endpoint endpoint ID body based on context it is used
 func (o *PutEndpointEndpointIDBody) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := o.contextValidatePortMappings(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (o *PutEndpointEndpointIDBody) contextValidatePortMappings(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(o.PortMappings); i++ {
+
+		if o.PortMappings[i] != nil {
+
+			if swag.IsZero(o.PortMappings[i]) { // not required
+				return nil
+			}
+
+			if err := o.PortMappings[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("body" + "." + "port_mappings" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("body" + "." + "port_mappings" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -5,18 +5,89 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 
 	"github.com/jessevdk/go-flags"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/sapcc/archer/v2/internal/agent/f5"
 	"github.com/sapcc/archer/v2/internal/config"
 )
 
+type driftCommand struct {
+	Repair bool `long:"repair" description:"Re-post the AS3 declaration of drifted tenants."`
+}
+
+func (c *driftCommand) Execute(_ []string) error {
+	a := f5.Connect()
+	drifts, err := a.CheckDrift(context.Background(), c.Repair)
+	if errors.Is(err, f5.ErrProcessingInProgress) {
+		return fmt.Errorf("agent is posting tenants, try again later: %w", err)
+	}
+	if drifts == nil && err != nil {
+		return err
+	}
+	// drifts are printed even if their repair failed
+	f5.PrintDrift(os.Stdout, drifts)
+	return err
+}
+
+type renderCommand struct {
+	Host   string `long:"host" description:"Render the declaration of this agent host instead of the local one."`
+	Tenant string `long:"tenant" description:"Render only this tenant, 'Common' or 'net-<network id>'."`
+	Schema string `long:"schema" description:"Validate the declaration against this AS3 JSON schema file."`
+}
+
+func (c *renderCommand) Execute(_ []string) error {
+	a := f5.ConnectOffline()
+	if c.Host != "" {
+		config.Global.Default.Host = c.Host
+	}
+	data, err := a.RenderDeclaration(context.Background(), c.Tenant)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+
+	if c.Schema != "" {
+		if err = f5.ValidateDeclaration(data, c.Schema); err != nil {
+			return fmt.Errorf("declaration does not match AS3 schema: %w", err)
+		}
+		log.Infof("Declaration is valid according to %s", c.Schema)
+	}
+	return nil
+}
+
 func main() {
 	parser := flags.NewParser(&config.Global, flags.Default)
 	parser.ShortDescription = "Archer F5 Agent"
+	parser.SubcommandsOptional = true
+	if _, err := parser.AddCommand("drift", "Print configuration drift",
+		"Compares the AS3 declarations on the active device with the database and prints the differences.",
+		&driftCommand{}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := parser.AddCommand("render", "Print AS3 declaration",
+		"Prints the AS3 declaration the agent would post for a host, generated from the database without contacting any device.",
+		&renderCommand{}); err != nil {
+		log.Fatal(err)
+	}
+
+	// commands run after the config files have been parsed
+	var command flags.Commander
+	parser.CommandHandler = func(c flags.Commander, _ []string) error {
+		command = c
+		return nil
+	}
 
 	if _, err := parser.Parse(); err != nil {
 		code := 1
@@ -30,6 +101,14 @@ func main() {
 	}
 
 	config.ParseConfig(parser)
+
+	if command != nil {
+		if err := command.Execute(nil); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	config.InitSentry()
 
 	a := f5.NewAgent()
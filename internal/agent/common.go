@@ -21,23 +21,81 @@ import (
 
 	"github.com/sapcc/archer/v2/internal/config"
 	"github.com/sapcc/archer/v2/internal/db"
+	"github.com/sapcc/archer/v2/models"
 )
 
-func RegisterAgent(pool db.PgxIface, provider string) {
+// Status is what an agent reports about itself on registration and with every
+// heartbeat, so the scheduler and operators know what it runs and supports.
+type Status struct {
+	// DeviceType of the backend, e.g. "bigip" or "f5os"; empty for agents without device.
+	DeviceType string
+	// FailoverState of the active device, e.g. "active"; empty for agents without device.
+	FailoverState string
+	// Capabilities lists the optional features supported, see internal.Capability*.
+	Capabilities []string
+	// Health details, only reported with heartbeats.
+	Health *models.AgentHealth
+}
+
+// columns maps the reported status onto agents table columns.
+func (s Status) columns() map[string]any {
+	capabilities := s.Capabilities
+	if capabilities == nil {
+		capabilities = []string{}
+	}
+	columns := map[string]any{
+		"version":        config.Version,
+		"device_type":    nilIfEmpty(s.DeviceType),
+		"failover_state": nilIfEmpty(s.FailoverState),
+		"capabilities":   capabilities,
+	}
+	if s.Health != nil {
+		columns["health"] = s.Health
+	}
+	return columns
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func RegisterAgent(pool db.PgxIface, provider string, status Status) {
 	var az *string
 	var physnet *string
 	if config.Global.Default.AvailabilityZone != "" {
 		az = &config.Global.Default.AvailabilityZone
 	}
-	if config.Global.Agent.PhysicalNetwork != "" {
-		physnet = &config.Global.Agent.PhysicalNetwork
+	physnets := make([]string, 0)
+	bridgeMappings := make(map[string]string)
+	for _, m := range config.Global.Agent.GetBridgeMappings() {
+		physnets = append(physnets, m.PhysicalNetwork)
+		bridgeMappings[m.PhysicalNetwork] = m.Interface
+	}
+	if len(physnets) > 0 {
+		// physnet keeps the preferred physical network for older API servers
+		physnet = &physnets[0]
 	}
+	values := status.columns()
+	values["host"] = config.Global.Default.Host
+	values["availability_zone"] = az
+	values["provider"] = provider
+	values["physnet"] = physnet
+	values["physnets"] = physnets
+	values["bridge_mappings"] = bridgeMappings
 	sql, args := db.Insert("agents").
-		Columns("host", "availability_zone", "provider", "physnet").
-		Values(config.Global.Default.Host, az, provider, physnet).
+		SetMap(values).
 		Suffix("ON CONFLICT (host) DO UPDATE SET").
 		SuffixExpr(sq.Expr("availability_zone = ?,", az)).
 		SuffixExpr(sq.Expr("physnet = ?,", physnet)).
+		Suffix("physnets = EXCLUDED.physnets,").
+		Suffix("bridge_mappings = EXCLUDED.bridge_mappings,").
+		Suffix("version = EXCLUDED.version,").
+		Suffix("device_type = EXCLUDED.device_type,").
+		Suffix("failover_state = EXCLUDED.failover_state,").
+		Suffix("capabilities = EXCLUDED.capabilities,").
 		Suffix("updated_at = now(),").
 		Suffix("heartbeat_at = now(),").
 		Suffix("enabled = true").
@@ -48,11 +106,12 @@ func RegisterAgent(pool db.PgxIface, provider string) {
 	}
 }
 
-// UpdateHeartbeat updates the agent's heartbeat timestamp in the database.
-// This should be called periodically to indicate the agent is still alive.
-func UpdateHeartbeat(pool db.PgxIface) {
+// UpdateHeartbeat updates the agent's heartbeat timestamp and reported status in
+// the database. This should be called periodically to indicate the agent is still alive.
+func UpdateHeartbeat(pool db.PgxIface, status Status) {
 	sql, args := db.Update("agents").
 		Set("heartbeat_at", sq.Expr("NOW()")).
+		SetMap(status.columns()).
 		Where("host = ?", config.Global.Default.Host).
 		MustSql()
 
@@ -233,6 +292,9 @@ func processServicesTask(ctx context.Context, scheduler gocron.Scheduler, w Work
 	run = func() error {
 		err := w.ProcessServices(ctx)
 		if err == nil {
+			if hw, ok := w.(interface{ HealthTracker() *HealthTracker }); ok {
+				hw.HealthTracker().ProcessServicesSucceeded()
+			}
 			return nil
 		}
 		// Lost the advisory-lock race: reschedule promptly instead of waiting for the 120s PendingSyncLoop.
@@ -17,6 +17,7 @@ import (
 
 	"github.com/sapcc/archer/v2/internal/config"
 	"github.com/sapcc/archer/v2/internal/db"
+	"github.com/sapcc/archer/v2/models"
 )
 
 // fakeWorker's ProcessServices fails failuresLeft times before succeeding.
@@ -127,6 +128,11 @@ func TestScheduleProcessServicesBusyReschedules(t *testing.T) {
 	}
 }
 
+const registerAgentSQL = "INSERT INTO agents (availability_zone,bridge_mappings,capabilities,device_type,failover_state,host,physnet,physnets,provider,version) " +
+	"VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (host) DO UPDATE SET availability_zone = $11, physnet = $12, " +
+	"physnets = EXCLUDED.physnets, bridge_mappings = EXCLUDED.bridge_mappings, version = EXCLUDED.version, device_type = EXCLUDED.device_type, failover_state = EXCLUDED.failover_state, " +
+	"capabilities = EXCLUDED.capabilities, updated_at = now(), heartbeat_at = now(), enabled = true"
+
 func TestRegisterAgent(t *testing.T) {
 	config.Global.Default.Host = "test-host"
 	config.Global.Default.AvailabilityZone = ""
@@ -140,11 +146,12 @@ func TestRegisterAgent(t *testing.T) {
 
 	var nilString *string
 	dbMock.
-		ExpectExec("INSERT INTO agents (host,availability_zone,provider,physnet) VALUES ($1,$2,$3,$4) ON CONFLICT (host) DO UPDATE SET availability_zone = $5, physnet = $6, updated_at = now(), heartbeat_at = now(), enabled = true").
-		WithArgs(config.Global.Default.Host, nilString, "test", nilString, nilString, nilString).
+		ExpectExec(registerAgentSQL).
+		WithArgs(nilString, map[string]string{}, []string{}, nilString, nilString, config.Global.Default.Host, nilString,
+			[]string{}, "test", config.Version, nilString, nilString).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	RegisterAgent(dbMock, "test")
+	RegisterAgent(dbMock, "test", Status{})
 }
 
 func TestRegisterAgentWithAZ(t *testing.T) {
@@ -160,11 +167,13 @@ func TestRegisterAgentWithAZ(t *testing.T) {
 
 	var nilString *string
 	dbMock.
-		ExpectExec("INSERT INTO agents (host,availability_zone,provider,physnet) VALUES ($1,$2,$3,$4) ON CONFLICT (host) DO UPDATE SET availability_zone = $5, physnet = $6, updated_at = now(), heartbeat_at = now(), enabled = true").
-		WithArgs(config.Global.Default.Host, &config.Global.Default.AvailabilityZone, "test", nilString, &config.Global.Default.AvailabilityZone, nilString).
+		ExpectExec(registerAgentSQL).
+		WithArgs(&config.Global.Default.AvailabilityZone, map[string]string{}, []string{}, nilString, nilString,
+			config.Global.Default.Host, nilString, []string{}, "test", config.Version, &config.Global.Default.AvailabilityZone,
+			nilString).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	RegisterAgent(dbMock, "test")
+	RegisterAgent(dbMock, "test", Status{})
 }
 
 func TestRegisterAgentWith(t *testing.T) {
@@ -180,11 +189,92 @@ func TestRegisterAgentWith(t *testing.T) {
 
 	var nilString *string
 	dbMock.
-		ExpectExec("INSERT INTO agents (host,availability_zone,provider,physnet) VALUES ($1,$2,$3,$4) ON CONFLICT (host) DO UPDATE SET availability_zone = $5, physnet = $6, updated_at = now(), heartbeat_at = now(), enabled = true").
-		WithArgs(config.Global.Default.Host, &config.Global.Default.AvailabilityZone, "test", nilString, &config.Global.Default.AvailabilityZone, nilString).
+		ExpectExec(registerAgentSQL).
+		WithArgs(&config.Global.Default.AvailabilityZone, map[string]string{}, []string{"snat_pool"}, new("bigip"),
+			new("active"), config.Global.Default.Host, nilString, []string{}, "test", config.Version,
+			&config.Global.Default.AvailabilityZone, nilString).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	RegisterAgent(dbMock, "test", Status{DeviceType: "bigip", FailoverState: "active", Capabilities: []string{"snat_pool"}})
+	if err = dbMock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterAgentWithBridgeMappings(t *testing.T) {
+	config.Global.Default.Host = "test-host"
+	config.Global.Default.AvailabilityZone = ""
+	config.Global.Agent.PhysicalInterface = "portchannel1"
+	config.Global.Agent.BridgeMappings = []string{"physnet1:portchannel2", "physnet2"}
+	defer func() {
+		config.Global.Agent.BridgeMappings = nil
+	}()
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dbMock.Close()
+	}()
+
+	var nilString *string
+	physnet := "physnet1"
+	dbMock.
+		ExpectExec(registerAgentSQL).
+		WithArgs(nilString, map[string]string{"physnet1": "portchannel2", "physnet2": "portchannel1"}, []string{},
+			nilString, nilString, config.Global.Default.Host, &physnet, []string{"physnet1", "physnet2"}, "test",
+			config.Version, nilString, &physnet).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	RegisterAgent(dbMock, "test")
+	RegisterAgent(dbMock, "test", Status{})
+	if err = dbMock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateHeartbeat(t *testing.T) {
+	config.Global.Default.Host = "test-host"
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dbMock.Close()
+	}()
+
+	var nilString *string
+	dbMock.
+		ExpectExec("UPDATE agents SET heartbeat_at = NOW(), capabilities = $1, device_type = $2, failover_state = $3, version = $4 WHERE host = $5").
+		WithArgs([]string{"wildcard_port"}, new("f5os"), nilString, config.Version, config.Global.Default.Host).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	UpdateHeartbeat(dbMock, Status{DeviceType: "f5os", Capabilities: []string{"wildcard_port"}})
+	if err = dbMock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateHeartbeatWithHealth(t *testing.T) {
+	config.Global.Default.Host = "test-host"
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dbMock.Close()
+	}()
+
+	var nilString *string
+	health := &models.AgentHealth{QueueDepth: 4, Devices: map[string]bool{"bigip-1": true}}
+	dbMock.
+		ExpectExec("UPDATE agents SET heartbeat_at = NOW(), capabilities = $1, device_type = $2, failover_state = $3, health = $4, version = $5 WHERE host = $6").
+		WithArgs([]string{}, nilString, nilString, health, config.Version, config.Global.Default.Host).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	UpdateHeartbeat(dbMock, Status{Health: health})
+	if err = dbMock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 // TestCoalescer_StateMachine verifies the edge-triggered dedup semantics directly:
@@ -6,9 +6,14 @@ package f5
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/IBM/pgxpoolprometheus"
 	sq "github.com/Masterminds/squirrel"
@@ -21,22 +26,33 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/sapcc/archer/v2/internal"
 	common "github.com/sapcc/archer/v2/internal/agent"
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
 	"github.com/sapcc/archer/v2/internal/config"
 	"github.com/sapcc/archer/v2/internal/db"
 	"github.com/sapcc/archer/v2/internal/neutron"
 	"github.com/sapcc/archer/v2/internal/profiling"
+
 	"github.com/sapcc/archer/v2/models"
 )
 
+// deviceProbeTimeout bounds how long a device may take to respond to the heartbeat probe.
+const deviceProbeTimeout = 10 * time.Second
+
 type Agent struct {
 	scheduler  gocron.Scheduler
 	pool       db.PgxIface // thread safe
 	neutron    *neutron.NeutronClient
 	devices    []F5Device
 	hosts      []F5Device
-	active     F5Device // active target
+	activeMu   sync.RWMutex // guards active
+	active     F5Device     // active target, use activeDevice()
 	psCoalesce common.Coalescer
+	health     common.HealthTracker
+	probing    atomic.Bool // a device probe is running
+	// repostPending is set after a failover until all tenants were re-posted
+	repostPending atomic.Bool
 }
 
 func (a *Agent) GetScheduler() gocron.Scheduler {
@@ -48,11 +64,34 @@ func (a *Agent) ProcessServicesCoalescer() *common.Coalescer {
 	return &a.psCoalesce
 }
 
+// HealthTracker exposes the health details reported with every heartbeat.
+func (a *Agent) HealthTracker() *common.HealthTracker {
+	return &a.health
+}
+
 func (a *Agent) GetPool() db.PgxIface {
 	return a.pool
 }
 
+// NewAgent connects to the database, the devices and Neutron and registers
+// the agent.
 func NewAgent() *Agent {
+	agent := Connect()
+	common.RegisterAgent(agent.pool, "tenant", agent.status())
+	return agent
+}
+
+// Connect connects to the database, the devices and Neutron without
+// registering the agent, for one-shot commands run next to a running agent.
+func Connect() *Agent {
+	agent := ConnectOffline()
+	agent.connectDevices()
+	return agent
+}
+
+// ConnectOffline connects to the database and Neutron only, no device is
+// contacted. Such an agent can only render declarations.
+func ConnectOffline() *Agent {
 	config.ResolveHost()
 
 	agent := new(Agent)
@@ -77,9 +116,27 @@ func NewAgent() *Agent {
 		dbConfig.ConnConfig.Host, dbConfig.MaxConns, dbConfig.HealthCheckPeriod)
 
 	// physical network/interface
-	log.Infof("Physical Interface Mapping: physical_network=%s, interface=%s",
-		config.Global.Agent.PhysicalNetwork, config.Global.Agent.PhysicalInterface)
+	for _, m := range config.Global.Agent.GetBridgeMappings() {
+		log.Infof("Physical Interface Mapping: physical_network=%s, interface=%s", m.PhysicalNetwork, m.Interface)
+	}
+
+	authInfo := clientconfig.AuthInfo(config.Global.ServiceAuth)
+	providerClient, err := clientconfig.AuthenticatedClient(context.Background(), &clientconfig.ClientOpts{
+		AuthInfo: &authInfo})
+	if err != nil {
+		log.WithError(err).Fatal("Error while connecting to Keystone")
+	}
 
+	if agent.neutron, err = neutron.ConnectToNeutron(providerClient); err != nil {
+		log.WithError(err).Fatalf("Error while connecting to Neutron")
+	}
+	log.Infof("Connected to Neutron %s", agent.neutron.Endpoint)
+	agent.neutron.InitCache()
+	return agent
+}
+
+// connectDevices opens sessions to the configured ltm guests and vcmp hosts.
+func (a *Agent) connectDevices() {
 	// ltm guests
 	for _, url := range config.Global.Agent.Devices {
 		f5device, devErr := GetF5DeviceSession(url)
@@ -87,47 +144,44 @@ func NewAgent() *Agent {
 			log.Warningf("Failed to initialize F5 device %s: %v", url, devErr)
 			continue
 		}
-		agent.devices = append(agent.devices, f5device)
-		if f5device.GetFailoverState() == "active" {
-			agent.active = f5device
+		if err := a.addDevice(f5device); err != nil {
+			log.Fatalf("F5 device %s: %v", url, err)
 		}
 	}
 
-	if agent.active == nil {
+	if a.active == nil {
 		log.Fatalf("No active F5 device available")
 	}
+	a.setActive(a.active)
 
-	if len(agent.devices) < len(config.Global.Agent.Devices) {
+	if len(a.devices) < len(config.Global.Agent.Devices) {
 		log.Warningf("Running in degraded mode: %d of %d devices available",
-			len(agent.devices), len(config.Global.Agent.Devices))
+			len(a.devices), len(config.Global.Agent.Devices))
 	}
 
 	// hosts
 	for _, url := range config.Global.Agent.VCMPs {
-		var f5device F5Device
-		f5device, err = GetF5DeviceSession(url)
+		f5device, err := GetF5DeviceSession(url)
 		if err != nil {
 			log.Fatalf("F5 session: %v", err)
 		}
 
-		agent.hosts = append(agent.hosts, f5device)
+		a.hosts = append(a.hosts, f5device)
 	}
+}
 
-	authInfo := clientconfig.AuthInfo(config.Global.ServiceAuth)
-	providerClient, err := clientconfig.AuthenticatedClient(context.Background(), &clientconfig.ClientOpts{
-		AuthInfo: &authInfo})
-	if err != nil {
-		log.WithError(err).Fatal("Error while connecting to Keystone")
+// addDevice adds an ltm guest. L2-only devices are refused, the agent would
+// otherwise accept services it can never provision.
+func (a *Agent) addDevice(device F5Device) error {
+	if device.L2Only() {
+		return fmt.Errorf("%s device %s only supports L2 provisioning, configure it as vcmp host",
+			device.GetDeviceType(), device.GetHostname())
 	}
-
-	if agent.neutron, err = neutron.ConnectToNeutron(providerClient); err != nil {
-		log.WithError(err).Fatalf("Error while connecting to Neutron")
+	a.devices = append(a.devices, device)
+	if device.GetFailoverState() == "active" {
+		a.active = device
 	}
-	log.Infof("Connected to Neutron %s", agent.neutron.Endpoint)
-	agent.neutron.InitCache()
-
-	common.RegisterAgent(agent.pool, "tenant")
-	return agent
+	return nil
 }
 
 func (a *Agent) Run() {
@@ -175,6 +229,28 @@ func (a *Agent) Run() {
 		log.Fatal(err)
 	}
 
+	// drift detection
+	if config.Global.Agent.DriftCheckInterval > 0 {
+		if _, err := a.scheduler.NewJob(
+			gocron.DurationJob(config.Global.Agent.DriftCheckInterval),
+			gocron.NewTask(a.DriftCheckLoop),
+			gocron.WithName("DriftCheckLoop"),
+		); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// failover monitoring
+	if config.Global.Agent.FailoverCheckInterval > 0 {
+		if _, err := a.scheduler.NewJob(
+			gocron.DurationJob(config.Global.Agent.FailoverCheckInterval),
+			gocron.NewTask(a.FailoverCheckLoop),
+			gocron.WithName("FailoverCheckLoop"),
+		); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// heartbeat job
 	if _, err := a.scheduler.NewJob(
 		gocron.DurationJob(config.Global.Agent.HeartbeatInterval),
@@ -260,7 +336,69 @@ func (a *Agent) PendingSyncLoop() error {
 	return nil
 }
 
-// UpdateHeartbeat updates the agent's heartbeat in the database.
+// UpdateHeartbeat updates the agent's heartbeat and health details in the database.
 func (a *Agent) UpdateHeartbeat() {
-	common.UpdateHeartbeat(a.pool)
+	queueDepth, err := common.PendingQueueDepth(context.Background(), a.pool, models.ServiceProviderTenant)
+	if err != nil {
+		log.WithError(err).Warning("Failed to count pending services and endpoints")
+	}
+
+	status := a.status()
+	status.Health = a.health.Snapshot(queueDepth)
+	common.UpdateHeartbeat(a.pool, status)
+
+	// an unreachable device must not delay the heartbeat, the probe results are
+	// reported with the next one
+	go a.probeDevices()
+}
+
+// probeDevices records the reachability of every device, an unreachable device
+// stays unnoticed until it becomes active otherwise. Devices not responding
+// within deviceProbeTimeout count as unreachable.
+func (a *Agent) probeDevices() {
+	if !a.probing.CompareAndSwap(false, true) {
+		return // the previous probe still waits for a device
+	}
+	defer a.probing.Store(false)
+
+	var wg sync.WaitGroup
+	for _, device := range slices.Concat(a.devices, a.hosts) {
+		wg.Go(func() {
+			a.health.DeviceReachable(device.GetHostname(), probeDevice(device, deviceProbeTimeout))
+		})
+	}
+	wg.Wait()
+}
+
+// probeDevice reports whether the device responds within timeout.
+func probeDevice(device F5Device, timeout time.Duration) bool {
+	done := make(chan error, 1)
+	go func() {
+		_, err := device.GetVLANs()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// postAS3 posts the declaration to the active device and records the outcome for the heartbeat.
+func (a *Agent) postAS3(data *as3.AS3, tenant string) error {
+	err := a.activeDevice().PostAS3(data, tenant)
+	a.health.AS3Posted(tenant, err)
+	return err
+}
+
+// status reports the active device and the features this agent supports.
+func (a *Agent) status() common.Status {
+	active := a.activeDevice()
+	return common.Status{
+		DeviceType:    active.GetDeviceType(),
+		FailoverState: active.GetFailoverState(),
+		Capabilities:  []string{internal.CapabilitySnatPool, internal.CapabilityWildcardPort},
+	}
 }
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright 2026 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package as3
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+
+	"github.com/sapcc/archer/v2/models"
+)
+
+//go:embed allowed_cidrs.irule
+var allowedCIDRsTemplate string
+
+var allowedCIDRs = template.Must(template.New("allowed_cidrs").Parse(allowedCIDRsTemplate))
+
+// getAllowedCIDRsIRule renders the iRule rejecting connections from sources
+// outside the allowed networks.
+func getAllowedCIDRsIRule(cidrs []models.CIDR) string {
+	var sb strings.Builder
+	if err := allowedCIDRs.Execute(&sb, cidrs); err != nil {
+		panic(err)
+	}
+	return sb.String()
+}
@@ -7,9 +7,11 @@ package as3
 import (
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/go-openapi/strfmt"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/internal/config"
 	"github.com/sapcc/archer/v2/models"
 )
@@ -30,6 +32,10 @@ func GetEndpointTenantName(networkId strfmt.UUID) string {
 	return fmt.Sprintf("net-%s", networkId)
 }
 
+func GetCatalogIRuleName(name string) string {
+	return fmt.Sprintf("irule-catalog-%s", name)
+}
+
 func getMirroringValue(enabled bool) string {
 	if enabled {
 		return "L4"
@@ -75,31 +81,69 @@ func GetServiceTenants(endpointServices []*ExtendedService) Tenant {
 			SnatAddresses: snatAddresses,
 		}
 
-		var serverAddresses []string
-		for _, ipAddress := range service.IPAddresses {
-			ip, _, err := net.ParseCIDR(string(ipAddress))
-			if err != nil {
-				ip = net.ParseIP(string(ipAddress))
-			}
-			serverAddresses = append(serverAddresses, ip.String())
-		}
-
 		adminState := "enable"
 		if service.Enabled != nil && !*service.Enabled {
 			adminState = "disable"
 		}
 
-		for _, port := range service.Ports {
-			poolMembers := []PoolMember{{
+		// Members sharing their load balancing options are grouped into one pool member
+		// entry; without options all addresses are balanced round robin.
+		members := internal.Members(service.IPAddresses, service.Members)
+		weighted := slices.ContainsFunc(members, func(m *models.ServiceMember) bool { return m.Weight != 1 })
+		prioritized := slices.ContainsFunc(members, func(m *models.ServiceMember) bool {
+			return m.Backup || m.PriorityGroup != 0
+		})
+		var connectionLimit int32
+		if service.Traffic != nil && service.Traffic.MaxConnections != nil {
+			connectionLimit = *service.Traffic.MaxConnections
+		}
+		newMemberGroup := func(ratio, priorityGroup int) PoolMember {
+			return PoolMember{
 				Enable:          true,
 				AdminState:      adminState,
 				RouteDomain:     service.SegmentId,
-				ServicePort:     port,
-				ServerAddresses: serverAddresses,
+				Ratio:           ratio,
+				PriorityGroup:   priorityGroup,
+				ConnectionLimit: connectionLimit,
 				Remark:          GetServiceName(service.ID),
-			}}
+			}
+		}
+		var memberGroups []PoolMember
+		for _, member := range members {
+			ip, _, err := net.ParseCIDR(string(*member.IPAddress))
+			if err != nil {
+				ip = net.ParseIP(string(*member.IPAddress))
+			}
+
+			var ratio, priorityGroup int
+			if weighted {
+				ratio = int(member.Weight)
+			}
+			// BIG-IP prefers higher priority groups, backups form the lowest one
+			if prioritized && !member.Backup {
+				priorityGroup = int(member.PriorityGroup) + 1
+			}
+
+			i := slices.IndexFunc(memberGroups, func(p PoolMember) bool {
+				return p.Ratio == ratio && p.PriorityGroup == priorityGroup
+			})
+			if i < 0 {
+				memberGroups = append(memberGroups, newMemberGroup(ratio, priorityGroup))
+				i = len(memberGroups) - 1
+			}
+			memberGroups[i].ServerAddresses = append(memberGroups[i].ServerAddresses, ip.String())
+		}
+		if len(memberGroups) == 0 {
+			memberGroups = []PoolMember{newMemberGroup(0, 0)}
+		}
 
-			services[GetServicePoolName(service.ID, port)] = Pool{
+		for _, port := range service.Ports {
+			poolMembers := slices.Clone(memberGroups)
+			for i := range poolMembers {
+				poolMembers[i].ServicePort = port
+			}
+
+			pool := Pool{
 				Class:   "Pool",
 				Label:   GetServicePoolName(service.ID, port),
 				Members: poolMembers,
@@ -107,6 +151,10 @@ func GetServiceTenants(endpointServices []*ExtendedService) Tenant {
 					{BigIP: "/Common/cc_gwicmp_monitor"},
 				},
 			}
+			if weighted {
+				pool.LoadBalancingMode = "ratio-member"
+			}
+			services[GetServicePoolName(service.ID, port)] = pool
 		}
 	}
 
@@ -141,8 +189,21 @@ func GetEndpointTenants(endpoints []*ExtendedEndpoint) Tenant {
 			)
 		}
 		iRules := make([]Pointer, 0)
+		if len(endpoint.AllowedCidrs) > 0 {
+			// Reject sources outside the allowed networks before any other iRule runs
+			aclName := fmt.Sprintf("irule-acl-%s", endpoint.ID)
+			services[aclName] = IRule{
+				Label: fmt.Sprint("irule-acl-", endpointName),
+				Class: "iRule",
+				IRule: IRuleBase64{getAllowedCIDRsIRule(endpoint.AllowedCidrs)},
+			}
+			iRules = append(iRules, Pointer{
+				Use: aclName,
+			})
+		}
 		var class string
 		var l4profile *Pointer
+		tcpProfile := &Pointer{BigIP: config.Global.Agent.TCPProfile}
 		if endpoint.ProxyProtocol {
 			// Add iRule for proxy protocol v2
 			class = "Service_TCP"
@@ -159,13 +220,46 @@ func GetEndpointTenants(endpoints []*ExtendedEndpoint) Tenant {
 			l4profile = &Pointer{BigIP: config.Global.Agent.L4Profile}
 		}
 
+		// An idle timeout of the service replaces the agent's profile by one of the endpoint,
+		// derived from the agent's profile so only the idle timeout differs
+		if idleTimeout := endpoint.IdleTimeout(); idleTimeout > 0 {
+			profile := Profile{
+				Label:         fmt.Sprintf("l4-profile-%s", endpoint.ID),
+				Class:         "L4_Profile",
+				ParentProfile: l4profile,
+				IdleTimeout:   idleTimeout,
+			}
+			if class == "Service_TCP" {
+				profile.Label, profile.Class = fmt.Sprintf("tcp-profile-%s", endpoint.ID), "TCP_Profile"
+				profile.ParentProfile = tcpProfile
+				tcpProfile = &Pointer{Use: profile.Label}
+			} else {
+				l4profile = &Pointer{Use: profile.Label}
+			}
+			services[profile.Label] = profile
+		}
+
+		// Catalog iRules are shared by all endpoints of the tenant using them
+		for _, catalogIRule := range endpoint.ServiceIRules {
+			name := GetCatalogIRuleName(catalogIRule.Name)
+			services[name] = IRule{
+				Label: name,
+				Class: "iRule",
+				IRule: IRuleBase64{catalogIRule.Content},
+			}
+			iRules = append(iRules, Pointer{
+				Use: name,
+			})
+		}
+
 		// Wildcard port (0) means "all TCP ports"; disable server port translation
 		// so the backend receives traffic on the original client destination port.
 		translateServerPort := len(endpoint.ServicePorts) != 1 || endpoint.ServicePorts[0] != 0
+		connectionLimit, rateLimit := endpoint.Limits()
 
-		for _, port := range endpoint.ServicePorts {
-			endpointName = fmt.Sprintf("endpoint-%d-%s", port, endpoint.ID)
-			pool := fmt.Sprintf("/Common/Shared/%s", GetServicePoolName(endpoint.ServiceID, port))
+		for _, listener := range endpoint.Listeners() {
+			endpointName = fmt.Sprintf("endpoint-%d-%s", listener.Port, endpoint.ID)
+			pool := fmt.Sprintf("/Common/Shared/%s", GetServicePoolName(endpoint.ServiceID, listener.ServicePort))
 
 			services[endpointName] = Service{
 				Label:               endpointName,
@@ -175,14 +269,16 @@ func GetEndpointTenants(endpoints []*ExtendedEndpoint) Tenant {
 				PersistenceMethods:  []string{},
 				Pool:                Pointer{BigIP: pool},
 				ProfileL4:           l4profile,
-				ProfileTCP:          &Pointer{BigIP: config.Global.Agent.TCPProfile},
+				ProfileTCP:          tcpProfile,
 				Snat:                Pointer{BigIP: snat},
 				TranslateServerPort: translateServerPort,
-				VirtualPort:         port,
+				VirtualPort:         listener.Port,
 				AllowVlans: []string{
 					fmt.Sprintf("/Common/vlan-%d", *endpoint.SegmentId),
 				},
 				VirtualAddresses: virtualAddresses,
+				MaxConnections:   connectionLimit,
+				RateLimit:        rateLimit,
 			}
 		}
 	}
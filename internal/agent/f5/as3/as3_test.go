@@ -111,6 +111,154 @@ func TestGetEndpointTenantsMirroringEnabled(t *testing.T) {
 	assert.Contains(t, string(json), `"mirroring":"L4"`)
 }
 
+func TestGetEndpointTenantsLimits(t *testing.T) {
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:        "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID: strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+				RateLimit: conv.Pointer(int32(50)),
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "1.2.3.4"}},
+			},
+			SegmentId:              conv.Pointer(1),
+			ServicePorts:           []int32{80},
+			ServiceConnectionLimit: conv.Pointer(int32(1000)),
+			ServiceRateLimit:       conv.Pointer(int32(200)),
+		},
+	}
+	tenant := GetEndpointTenants(endpoints)
+	json, err := tenant.MarshalJSON()
+	assert.Nil(t, err)
+	assert.Contains(t, string(json), `"maxConnections":1000`)
+	assert.Contains(t, string(json), `"rateLimit":50`, "endpoint overrides the service rate limit")
+
+	// 0 lifts the service limit for the endpoint
+	endpoints[0].ConnectionLimit = conv.Pointer(int32(0))
+	json, err = GetEndpointTenants(endpoints).MarshalJSON()
+	assert.Nil(t, err)
+	assert.NotContains(t, string(json), "maxConnections")
+
+	// without limits the attributes are left out
+	endpoints[0].ConnectionLimit = nil
+	endpoints[0].RateLimit = nil
+	endpoints[0].ServiceConnectionLimit = nil
+	endpoints[0].ServiceRateLimit = nil
+	json, err = GetEndpointTenants(endpoints).MarshalJSON()
+	assert.Nil(t, err)
+	assert.NotContains(t, string(json), "maxConnections")
+	assert.NotContains(t, string(json), "rateLimit")
+}
+
+func TestGetEndpointTenantsIdleTimeout(t *testing.T) {
+	config.Global.Agent.L4Profile = "test-l4-profile"
+	config.Global.Agent.TCPProfile = "test-tcp-profile"
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:        "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID: strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "1.2.3.4"}},
+			},
+			SegmentId:      conv.Pointer(1),
+			ServicePorts:   []int32{80},
+			ServiceTraffic: &models.ServiceTraffic{MaxConnections: conv.Pointer(int32(100))},
+		},
+	}
+	services := GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+	service := services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Equal(t, &Pointer{BigIP: "test-l4-profile"}, service.ProfileL4, "without idle timeout the agent's profile is used")
+	assert.Len(t, services, 1)
+
+	endpoints[0].ServiceTraffic.IdleTimeout = conv.Pointer(int32(3600))
+	services = GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+	service = services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Equal(t, &Pointer{Use: "l4-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"}, service.ProfileL4)
+	assert.Equal(t, Profile{Label: "l4-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64", Class: "L4_Profile",
+		ParentProfile: &Pointer{BigIP: "test-l4-profile"}, IdleTimeout: 3600},
+		services["l4-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"])
+
+	// proxy protocol endpoints are TCP services
+	endpoints[0].ProxyProtocol = true
+	services = GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+	service = services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Nil(t, service.ProfileL4)
+	assert.Equal(t, &Pointer{Use: "tcp-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"}, service.ProfileTCP)
+	assert.Equal(t, Profile{Label: "tcp-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64", Class: "TCP_Profile",
+		ParentProfile: &Pointer{BigIP: "test-tcp-profile"}, IdleTimeout: 3600},
+		services["tcp-profile-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"])
+}
+
+func TestGetEndpointTenantsIRules(t *testing.T) {
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:        "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID: strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "1.2.3.4"}},
+			},
+			SegmentId:     conv.Pointer(1),
+			ServicePorts:  []int32{80},
+			ProxyProtocol: true,
+			ServiceIRules: []CatalogIRule{
+				{Name: "xff", Content: "when HTTP_REQUEST {}"},
+				{Name: "allow-list", Content: "when CLIENT_ACCEPTED {}"},
+			},
+		},
+	}
+	tenant := GetEndpointTenants(endpoints)
+	services := tenant.Applications["si-endpoints"].Services
+
+	// proxy protocol first, then the catalog iRules in service order
+	service := services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Equal(t, []Pointer{
+		{Use: "irule-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"},
+		{Use: "irule-catalog-xff"},
+		{Use: "irule-catalog-allow-list"},
+	}, service.IRules)
+	assert.Equal(t, IRuleBase64{"when HTTP_REQUEST {}"}, services["irule-catalog-xff"].(IRule).IRule)
+	assert.Equal(t, IRuleBase64{"when CLIENT_ACCEPTED {}"}, services["irule-catalog-allow-list"].(IRule).IRule)
+}
+
+func TestGetEndpointTenantsAllowedCIDRs(t *testing.T) {
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:           "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID:    strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+				AllowedCidrs: []models.CIDR{"10.180.0.0/16", "2001:db8::/32"},
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "1.2.3.4"}},
+			},
+			SegmentId:     conv.Pointer(1),
+			ServicePorts:  []int32{80},
+			ProxyProtocol: true,
+		},
+	}
+	services := GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+
+	// the allow-list runs before the proxy protocol iRule
+	service := services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Equal(t, []Pointer{
+		{Use: "irule-acl-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"},
+		{Use: "irule-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"},
+	}, service.IRules)
+	iRule := services["irule-acl-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(IRule).IRule.(IRuleBase64).Value
+	assert.Contains(t, iRule, "foreach cidr {10.180.0.0/16 2001:db8::/32} {")
+	assert.Contains(t, iRule, "reject")
+
+	// without allowed networks no iRule is added
+	endpoints[0].AllowedCidrs = []models.CIDR{}
+	services = GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+	assert.NotContains(t, services, "irule-acl-3ad9b1f0-4e5a-44c3-ada6-71696925ae64")
+}
+
 func TestGetEndpointTenantsWildcardPort(t *testing.T) {
 	config.Global.Agent.L4Profile = "test-l4-profile"
 	config.Global.Agent.TCPProfile = "test-tcp-profile"
@@ -162,6 +310,57 @@ func TestGetEndpointTenantsNonWildcardPort(t *testing.T) {
 	assert.Contains(t, string(json), `"translateServerPort":true`)
 }
 
+func TestGetEndpointTenantsPortSubset(t *testing.T) {
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:        "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID: strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+				Ports:     []int32{443, 8443},
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "10.0.0.1"}},
+			},
+			SegmentId:    conv.Pointer(1),
+			ServicePorts: []int32{80, 443},
+		},
+	}
+	services := GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+
+	// only the selected ports still exposed by the service get a listener
+	assert.Contains(t, services, "endpoint-443-3ad9b1f0-4e5a-44c3-ada6-71696925ae64")
+	assert.NotContains(t, services, "endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64")
+	assert.NotContains(t, services, "endpoint-8443-3ad9b1f0-4e5a-44c3-ada6-71696925ae64")
+	assert.Equal(t, []int32{80, 443}, endpoints[0].ServicePorts)
+}
+
+func TestGetEndpointTenantsPortMappings(t *testing.T) {
+	endpoints := []*ExtendedEndpoint{
+		{
+			Endpoint: models.Endpoint{
+				ID:        "3ad9b1f0-4e5a-44c3-ada6-71696925ae64",
+				ServiceID: strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3"),
+				PortMappings: []*models.PortMapping{
+					{ListenPort: conv.Pointer(int32(8443)), ServicePort: conv.Pointer(int32(443))},
+				},
+			},
+			Port: &ports.Port{
+				FixedIPs: []ports.IP{{IPAddress: "10.0.0.1"}},
+			},
+			SegmentId:    conv.Pointer(1),
+			ServicePorts: []int32{80, 443},
+		},
+	}
+	services := GetEndpointTenants(endpoints).Applications["si-endpoints"].Services
+
+	// the mapped port listens on the listen port, forwarding to the pool of the service port
+	assert.NotContains(t, services, "endpoint-443-3ad9b1f0-4e5a-44c3-ada6-71696925ae64")
+	service := services["endpoint-8443-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service)
+	assert.Equal(t, int32(8443), service.VirtualPort)
+	assert.Equal(t, "/Common/Shared/"+GetServicePoolName(endpoints[0].ServiceID, 443), service.Pool.BigIP)
+	assert.Equal(t, int32(80), services["endpoint-80-3ad9b1f0-4e5a-44c3-ada6-71696925ae64"].(Service).VirtualPort)
+}
+
 func TestGetServiceName(t *testing.T) {
 	id := strfmt.UUID("4e50bf87-e597-41f2-9ce0-83d3e24dedf3")
 	assert.Equal(t, "service-4e50bf87-e597-41f2-9ce0-83d3e24dedf3",
@@ -254,6 +453,73 @@ func TestGetServiceTenants(t *testing.T) {
 	assert.EqualValues(t, expected, GetServiceTenants(services))
 }
 
+func TestGetServiceTenantsMembers(t *testing.T) {
+	newService := func(members ...*models.ServiceMember) []*ExtendedService {
+		return []*ExtendedService{{
+			Service: models.Service{
+				ID:          "test-service-id",
+				Ports:       []int32{443},
+				IPAddresses: []models.InetAddress{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+				Members:     members,
+			},
+			SegmentId: 54321,
+		}}
+	}
+	pool := func(services []*ExtendedService) Pool {
+		return GetServiceTenants(services).Applications["Shared"].Services["pool-test-service-id-443"].(Pool)
+	}
+
+	// without options all addresses are one round robin member
+	p := pool(newService())
+	assert.Empty(t, p.LoadBalancingMode)
+	if assert.Len(t, p.Members, 1) {
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, p.Members[0].ServerAddresses)
+		assert.Zero(t, p.Members[0].Ratio)
+		assert.Zero(t, p.Members[0].PriorityGroup)
+	}
+
+	p = pool(newService(
+		&models.ServiceMember{IPAddress: new(models.InetAddress("10.0.0.2")), Weight: 3},
+		&models.ServiceMember{IPAddress: new(models.InetAddress("10.0.0.3")), Backup: true},
+	))
+	assert.Equal(t, "ratio-member", p.LoadBalancingMode)
+	if assert.Len(t, p.Members, 3) {
+		assert.Equal(t, []string{"10.0.0.1"}, p.Members[0].ServerAddresses)
+		assert.Equal(t, 1, p.Members[0].Ratio)
+		assert.Equal(t, 1, p.Members[0].PriorityGroup)
+		assert.Equal(t, []string{"10.0.0.2"}, p.Members[1].ServerAddresses)
+		assert.Equal(t, 3, p.Members[1].Ratio)
+		assert.Equal(t, 1, p.Members[1].PriorityGroup)
+		// backups form the lowest priority group
+		assert.Equal(t, []string{"10.0.0.3"}, p.Members[2].ServerAddresses)
+		assert.Equal(t, 0, p.Members[2].PriorityGroup)
+		assert.Equal(t, int32(443), p.Members[2].ServicePort)
+	}
+}
+
+func TestGetServiceTenantsConnectionLimit(t *testing.T) {
+	services := []*ExtendedService{{
+		Service: models.Service{
+			ID:          "test-service-id",
+			Ports:       []int32{443},
+			IPAddresses: []models.InetAddress{"10.0.0.1", "10.0.0.2"},
+			Members:     []*models.ServiceMember{{IPAddress: new(models.InetAddress("10.0.0.2")), Backup: true}},
+			Traffic:     &models.ServiceTraffic{MaxConnections: conv.Pointer(int32(100))},
+		},
+		SegmentId: 54321,
+	}}
+	pool := GetServiceTenants(services).Applications["Shared"].Services["pool-test-service-id-443"].(Pool)
+	if assert.Len(t, pool.Members, 2) {
+		assert.Equal(t, int32(100), pool.Members[0].ConnectionLimit, "applies to every member")
+		assert.Equal(t, int32(100), pool.Members[1].ConnectionLimit, "applies to every member")
+	}
+
+	services[0].Traffic = nil
+	json, err := GetServiceTenants(services).MarshalJSON()
+	assert.Nil(t, err)
+	assert.NotContains(t, string(json), "connectionLimit")
+}
+
 func TestGetServiceTenantsWithoutServices(t *testing.T) {
 	expected := Tenant{Class: "Tenant", Label: "", Remark: "", Applications: map[string]Application{"Shared": {Class: "Application", Label: "", Remark: "", Template: "shared", Services: map[string]any{}}}}
 	assert.EqualValues(t, expected, GetServiceTenants([]*ExtendedService{}))
@@ -117,17 +117,31 @@ type PoolMember struct {
 	RouteDomain     int      `json:"routeDomain"`
 	ServicePort     int32    `json:"servicePort"`
 	ServerAddresses []string `json:"serverAddresses"`
+	Ratio           int      `json:"ratio,omitempty"`
+	PriorityGroup   int      `json:"priorityGroup,omitempty"`
+	ConnectionLimit int32    `json:"connectionLimit,omitempty"`
 	Enable          bool     `json:"enable"`
 	AdminState      string   `json:"adminState,omitempty"`
 	Remark          string   `json:"remark,omitempty"`
 }
 
 type Pool struct {
-	Class    string       `json:"class"`
-	Label    string       `json:"label,omitempty"`
-	Remark   string       `json:"remark,omitempty"`
-	Members  []PoolMember `json:"members"`
-	Monitors []Pointer    `json:"monitors"`
+	Class             string       `json:"class"`
+	Label             string       `json:"label,omitempty"`
+	Remark            string       `json:"remark,omitempty"`
+	LoadBalancingMode string       `json:"loadBalancingMode,omitempty"`
+	Members           []PoolMember `json:"members"`
+	Monitors          []Pointer    `json:"monitors"`
+}
+
+// Application Profiles
+
+type Profile struct {
+	Class         string   `json:"class"`
+	Label         string   `json:"label,omitempty"`
+	Remark        string   `json:"remark,omitempty"`
+	ParentProfile *Pointer `json:"parentProfile,omitempty"`
+	IdleTimeout   int32    `json:"idleTimeout"`
 }
 
 // Application Service
@@ -147,6 +161,8 @@ type Service struct {
 	VirtualAddresses    []string  `json:"virtualAddresses"`
 	TranslateServerPort bool      `json:"translateServerPort"`
 	VirtualPort         int32     `json:"virtualPort"`
+	MaxConnections      int32     `json:"maxConnections,omitempty"`
+	RateLimit           int32     `json:"rateLimit,omitempty"`
 }
 
 // transpared IRule container that emits base64
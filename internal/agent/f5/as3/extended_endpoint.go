@@ -8,6 +8,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/models"
 )
 
@@ -19,7 +20,51 @@ type ExtendedEndpoint struct {
 	ServiceNetworkId    strfmt.UUID
 	ServiceStatus       string
 	SegmentId           *int
+	Physnet             *string // physical network of SegmentId, nil if recorded before bridge mappings
 	ProxyProtocol       bool
 	Owned               bool
 	ConnectionMirroring bool
+	// Limits of the service, used unless the endpoint overrides them
+	ServiceConnectionLimit *int32
+	ServiceRateLimit       *int32
+	// Traffic settings of the service, nil uses the agent's profiles
+	ServiceTraffic *models.ServiceTraffic
+	// iRules of the catalog attached to the service, in order
+	ServiceIRules []CatalogIRule `db:"service_irules"`
+}
+
+// Listeners returns the listeners of the endpoint, see internal.Listeners.
+func (e *ExtendedEndpoint) Listeners() []internal.Listener {
+	return internal.Listeners(e.ServicePorts, e.Ports, e.PortMappings)
+}
+
+// CatalogIRule is an iRule of the admin-managed catalog.
+type CatalogIRule struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Limits returns the connection and rate limit of the endpoint, falling back
+// to the limits of the service. 0 means no limit.
+func (e *ExtendedEndpoint) Limits() (connectionLimit, rateLimit int32) {
+	return limit(e.ConnectionLimit, e.ServiceConnectionLimit), limit(e.RateLimit, e.ServiceRateLimit)
+}
+
+// IdleTimeout returns the idle timeout of the service in seconds, 0 uses the
+// idle timeout of the agent's profiles.
+func (e *ExtendedEndpoint) IdleTimeout() int32 {
+	if e.ServiceTraffic == nil {
+		return 0
+	}
+	return limit(nil, e.ServiceTraffic.IdleTimeout)
+}
+
+func limit(endpoint, service *int32) int32 {
+	if endpoint != nil {
+		return *endpoint
+	}
+	if service != nil {
+		return *service
+	}
+	return 0
 }
@@ -16,5 +16,6 @@ type ExtendedService struct {
 	NeutronPorts map[string]*ports.Port // SelfIPs / SNAT IPs
 	SubnetID     string
 	SegmentId    int
+	Physnet      string // physical network of SegmentId
 	MTU          int
 }
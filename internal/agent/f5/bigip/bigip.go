@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"slices"
@@ -29,6 +30,10 @@ func (b *BigIP) GetDeviceType() string {
 	return "bigip"
 }
 
+func (b *BigIP) L2Only() bool {
+	return false
+}
+
 func (b *BigIP) GetFailoverState() string {
 	// Return device failover state.
 
@@ -138,6 +143,32 @@ func (b *BigIP) PostAS3(as3 *as3.AS3, tenant string) error {
 	})
 }
 
+func (b *BigIP) GetAS3Tenant(tenant string) (json.RawMessage, error) {
+	req := &bigip.APIRequest{
+		Method:      "get",
+		URL:         "mgmt/shared/appsvcs/declare/" + tenant,
+		ContentType: "application/json",
+	}
+	resp, err := (*bigip.BigIP)(b).APICall(req)
+	if err != nil {
+		var reqError bigip.RequestError
+		if json.Unmarshal(resp, &reqError) == nil && reqError.Code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetAS3Tenant: %w", err)
+	}
+	if len(resp) == 0 {
+		// 204 No Content, no declaration stored
+		return nil, nil
+	}
+
+	var declaration map[string]json.RawMessage
+	if err = json.Unmarshal(resp, &declaration); err != nil {
+		return nil, fmt.Errorf("GetAS3Tenant: %w", err)
+	}
+	return declaration[tenant], nil
+}
+
 type VcmpGuests struct {
 	Guests []bigip.VcmpGuest `json:"items,omitempty"`
 }
@@ -425,7 +456,7 @@ func (b *BigIP) EnsureVLAN(segmentId int, mtu int) error {
 	return nil
 }
 
-func (b *BigIP) EnsureInterfaceVlan(segmentId int) error {
+func (b *BigIP) EnsureInterfaceVlan(segmentId int, iface string) error {
 	name := fmt.Sprintf("vlan-%d", segmentId)
 
 	vlanInterfaces, err := (*bigip.BigIP)(b).GetVlanInterfaces(name)
@@ -433,14 +464,14 @@ func (b *BigIP) EnsureInterfaceVlan(segmentId int) error {
 		return err
 	}
 
-	for _, iface := range vlanInterfaces.VlanInterfaces {
-		if iface.Name == config.Global.Agent.PhysicalInterface {
+	for _, vlanIface := range vlanInterfaces.VlanInterfaces {
+		if vlanIface.Name == iface {
 			// found, nothing to do
 			return nil
 		}
 	}
 
-	return (*bigip.BigIP)(b).AddInterfaceToVlan(name, config.Global.Agent.PhysicalInterface, true)
+	return (*bigip.BigIP)(b).AddInterfaceToVlan(name, iface, true)
 }
 
 func (b *BigIP) DeleteVLAN(segmentId int) error {
@@ -125,7 +125,7 @@ func (a *Agent) getUsedSegments(ctx context.Context) (map[int]string, error) {
 			// refresh segmentID from neutron
 			var tmp int
 			if tmp, err = a.neutron.GetNetworkSegment(ctx, epNetworkID.String(),
-				config.Global.Agent.PhysicalNetwork); err != nil {
+				config.Global.Agent.PhysicalNetworks()...); err != nil {
 				return nil, err
 			}
 			if err = segmentID.Scan(int64(tmp)); err != nil {
@@ -136,7 +136,7 @@ func (a *Agent) getUsedSegments(ctx context.Context) (map[int]string, error) {
 			// add endpoint to used segment map
 			usedSegments[int(segmentID.Int32)] = epNetworkID.String()
 		}
-		serviceSegment, err := a.neutron.GetNetworkSegment(ctx, networkID, config.Global.Agent.PhysicalNetwork)
+		serviceSegment, err := a.neutron.GetNetworkSegment(ctx, networkID, config.Global.Agent.PhysicalNetworks()...)
 		if err != nil {
 			return nil, err
 		}
@@ -233,7 +233,7 @@ func (a *Agent) cleanOrphanedNeutronPorts(ctx context.Context, usedSegments map[
 
 	// Fetch all segments for every selfip network
 	for networkID, ports := range selfips {
-		segment, err := a.neutron.GetNetworkSegment(ctx, networkID, config.Global.Agent.PhysicalNetwork)
+		segment, err := a.neutron.GetNetworkSegment(ctx, networkID, config.Global.Agent.PhysicalNetworks()...)
 		if err != nil {
 			log.Errorf("cleanOrphanedNeutronPorts: %s", err.Error())
 			continue
@@ -352,7 +352,7 @@ func (a *Agent) cleanupOrphanedTenants(usedSegments map[int]string) error {
 			data := as3.GetAS3Declaration(map[string]as3.Tenant{
 				partition: as3.GetEndpointTenants([]*as3.ExtendedEndpoint{}),
 			})
-			if err := a.active.PostAS3(&data, partition); err != nil {
+			if err := a.postAS3(&data, partition); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/models"
+)
+
+var (
+	driftDifferences = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "archer_f5_drift_differences",
+		Help: "Number of differences between the database and the AS3 declaration of a tenant on the active device",
+	}, []string{"tenant"})
+	driftRepairs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "archer_f5_drift_repairs",
+		Help: "Counter of AS3 declarations re-posted to repair drift",
+	}, []string{"tenant", "outcome"})
+)
+
+// TenantDrift is an AS3 tenant whose declaration on the active device differs
+// from the one generated from the database.
+type TenantDrift struct {
+	Tenant string
+	// Declaration is the tenant as generated from the database.
+	Declaration as3.Tenant
+	// Differences lists every deviating attribute by path, sorted.
+	Differences []string
+}
+
+// ErrProcessingInProgress is returned by CheckDrift while the agent processes
+// services or endpoints, their tenants are about to be re-posted anyway.
+var ErrProcessingInProgress = errors.New("processing of services or endpoints in progress")
+
+// DriftCheckLoop compares all tenants of this agent with the active device,
+// exports the result and, if enabled, re-posts drifted tenants.
+func (a *Agent) DriftCheckLoop(ctx context.Context) error {
+	drifts, err := a.CheckDrift(ctx, config.Global.Agent.DriftRepair)
+	if errors.Is(err, ErrProcessingInProgress) {
+		log.Debug("DriftCheckLoop: processing in progress, skipping")
+		return nil
+	}
+	for _, drift := range drifts {
+		log.WithFields(log.Fields{"tenant": drift.Tenant, "differences": len(drift.Differences)}).
+			Warning("DriftCheckLoop: AS3 declaration on device differs from database")
+	}
+	return err
+}
+
+// CheckDrift detects the drifted tenants and, with repair, re-posts them. It
+// holds the advisory locks of ProcessServices and ProcessEndpoint throughout,
+// so a repair never re-posts a declaration they superseded in the meantime.
+// Returns ErrProcessingInProgress if either lock is held by another run.
+func (a *Agent) CheckDrift(ctx context.Context, repair bool) ([]*TenantDrift, error) {
+	for _, lockID := range []int64{advisoryLockProcessServices, advisoryLockProcessEndpoints} {
+		lockTx, err := a.tryAdvisoryLock(ctx, lockID)
+		if err != nil {
+			if errors.Is(err, errAdvisoryLockHeld) {
+				return nil, ErrProcessingInProgress
+			}
+			return nil, err
+		}
+		defer func() { _ = lockTx.Rollback(ctx) }()
+	}
+
+	drifts, err := a.detectDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if repair {
+		return drifts, a.repairDrift(drifts)
+	}
+	return drifts, nil
+}
+
+// detectDrift generates the declaration of every tenant this agent manages and
+// compares it with the declaration stored on the active device. Tenants with
+// pending services or endpoints are skipped, as they are about to be re-posted.
+func (a *Agent) detectDrift(ctx context.Context) ([]*TenantDrift, error) {
+	desired, err := a.generateTenants(ctx, tenantSelector{skipPending: true})
+	if err != nil {
+		return nil, err
+	}
+	return a.compareTenants(desired)
+}
+
+// repairDrift re-posts the declaration of every drifted tenant. Callers must
+// hold the processing locks, see CheckDrift.
+func (a *Agent) repairDrift(drifts []*TenantDrift) error {
+	var errs []error
+	for _, drift := range drifts {
+		data := as3.GetAS3Declaration(map[string]as3.Tenant{drift.Tenant: drift.Declaration})
+		if err := a.postAS3(&data, drift.Tenant); err != nil {
+			driftRepairs.WithLabelValues(drift.Tenant, "failed").Inc()
+			errs = append(errs, fmt.Errorf("tenant %s: %w", drift.Tenant, err))
+			continue
+		}
+		log.WithField("tenant", drift.Tenant).Info("repairDrift: re-posted AS3 declaration")
+		driftRepairs.WithLabelValues(drift.Tenant, "success").Inc()
+	}
+	return errors.Join(errs...)
+}
+
+// compareTenants fetches each desired tenant from the active device and returns
+// the drifted ones, sorted by tenant name. The exported differences are only
+// replaced once every tenant was compared.
+func (a *Agent) compareTenants(desired map[string]as3.Tenant) ([]*TenantDrift, error) {
+	var drifts []*TenantDrift
+	counts := make(map[string]int, len(desired))
+	for _, tenant := range slices.Sorted(maps.Keys(desired)) {
+		actual, err := a.activeDevice().GetAS3Tenant(tenant)
+		if err != nil {
+			return nil, err
+		}
+		differences, err := diffTenant(desired[tenant], actual)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", tenant, err)
+		}
+		counts[tenant] = len(differences)
+		if len(differences) > 0 {
+			drifts = append(drifts, &TenantDrift{Tenant: tenant, Declaration: desired[tenant], Differences: differences})
+		}
+	}
+
+	driftDifferences.Reset()
+	for tenant, count := range counts {
+		driftDifferences.WithLabelValues(tenant).Set(float64(count))
+	}
+	return drifts, nil
+}
+
+func isPendingService(status models.ServiceStatus) bool {
+	return status == models.ServiceStatusPENDINGCREATE ||
+		status == models.ServiceStatusPENDINGUPDATE ||
+		status == models.ServiceStatusPENDINGDELETE
+}
+
+func isPendingEndpoint(status models.EndpointStatus) bool {
+	return status == models.EndpointStatusPENDINGCREATE ||
+		status == models.EndpointStatusPENDINGUPDATE ||
+		status == models.EndpointStatusPENDINGDELETE ||
+		status == models.EndpointStatusPENDINGREJECTED
+}
+
+// diffTenant compares the generated tenant with the raw declaration from the
+// device and returns the deviating attributes as "path: detail" lines.
+func diffTenant(desired as3.Tenant, actual json.RawMessage) ([]string, error) {
+	if actual == nil {
+		return []string{"/: missing on device"}, nil
+	}
+
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+	var want, have any
+	if err = json.Unmarshal(data, &want); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(actual, &have); err != nil {
+		return nil, err
+	}
+
+	var differences []string
+	diffValue("", want, have, &differences)
+	slices.Sort(differences)
+	return differences, nil
+}
+
+func diffValue(path string, want, have any, differences *[]string) {
+	wantMap, wantIsMap := want.(map[string]any)
+	haveMap, haveIsMap := have.(map[string]any)
+	if wantIsMap && haveIsMap {
+		for key, value := range wantMap {
+			if other, ok := haveMap[key]; ok {
+				diffValue(path+"/"+key, value, other, differences)
+			} else {
+				*differences = append(*differences, path+"/"+key+": missing on device")
+			}
+		}
+		for key := range haveMap {
+			if _, ok := wantMap[key]; !ok {
+				*differences = append(*differences, path+"/"+key+": unexpected on device")
+			}
+		}
+		return
+	}
+
+	wantSlice, wantIsSlice := want.([]any)
+	haveSlice, haveIsSlice := have.([]any)
+	if wantIsSlice && haveIsSlice && len(wantSlice) == len(haveSlice) {
+		for i := range wantSlice {
+			diffValue(fmt.Sprintf("%s/%d", path, i), wantSlice[i], haveSlice[i], differences)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(want, have) {
+		wantJSON, _ := json.Marshal(want)
+		haveJSON, _ := json.Marshal(have)
+		*differences = append(*differences, fmt.Sprintf("%s: expected %s, found %s", path, wantJSON, haveJSON))
+	}
+}
+
+// PrintDrift writes a human-readable report of drifts to w.
+func PrintDrift(w io.Writer, drifts []*TenantDrift) {
+	if len(drifts) == 0 {
+		_, _ = fmt.Fprintln(w, "No drift detected.")
+		return
+	}
+	for _, drift := range drifts {
+		_, _ = fmt.Fprintf(w, "Tenant %s: %d difference(s)\n  %s\n", drift.Tenant, len(drift.Differences),
+			strings.Join(drift.Differences, "\n  "))
+	}
+}
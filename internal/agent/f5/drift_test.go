@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
+)
+
+func driftTestTenant(port int32) as3.Tenant {
+	return as3.Tenant{
+		Class: "Tenant",
+		Applications: map[string]as3.Application{
+			"Shared": {
+				Class:    "Application",
+				Template: "shared",
+				Services: map[string]any{
+					"pool-1": as3.Pool{
+						Class: "Pool",
+						Label: "pool-1",
+						Members: []as3.PoolMember{{
+							Enable:          true,
+							AdminState:      "enable",
+							ServicePort:     port,
+							ServerAddresses: []string{"1.2.3.4"},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiffTenant(t *testing.T) {
+	desired := driftTestTenant(80)
+	same, err := json.Marshal(desired)
+	require.NoError(t, err)
+
+	differences, err := diffTenant(desired, same)
+	require.NoError(t, err)
+	assert.Empty(t, differences)
+
+	differences, err = diffTenant(desired, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/: missing on device"}, differences)
+
+	// hand-edited port and an additional virtual server on the device
+	var edited map[string]any
+	changed, err := json.Marshal(driftTestTenant(8080))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(changed, &edited))
+	edited["Shared"].(map[string]any)["vs-manual"] = map[string]any{"class": "Service_TCP"}
+	changed, err = json.Marshal(edited)
+	require.NoError(t, err)
+
+	differences, err = diffTenant(desired, changed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/Shared/pool-1/members/0/servicePort: expected 80, found 8080",
+		"/Shared/vs-manual: unexpected on device",
+	}, differences)
+}
+
+func TestCompareTenants(t *testing.T) {
+	inSync, err := json.Marshal(driftTestTenant(443))
+	require.NoError(t, err)
+
+	device := NewMockF5Device(t)
+	device.EXPECT().GetAS3Tenant("Common").Return(inSync, nil)
+	device.EXPECT().GetAS3Tenant("net-123").Return(nil, nil)
+	a := &Agent{active: device}
+
+	drifts, err := a.compareTenants(map[string]as3.Tenant{
+		"Common":  driftTestTenant(443),
+		"net-123": driftTestTenant(80),
+	})
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "net-123", drifts[0].Tenant)
+	assert.Equal(t, driftTestTenant(80), drifts[0].Declaration)
+
+	var out bytes.Buffer
+	PrintDrift(&out, drifts)
+	assert.Equal(t, "Tenant net-123: 1 difference(s)\n  /: missing on device\n", out.String())
+	assert.Equal(t, float64(1), testutil.ToFloat64(driftDifferences.WithLabelValues("net-123")))
+
+	// a failed comparison keeps the previous result
+	device.EXPECT().GetAS3Tenant("net-456").Return(nil, errors.New("connection refused"))
+	_, err = a.compareTenants(map[string]as3.Tenant{"net-456": driftTestTenant(80)})
+	require.Error(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(driftDifferences.WithLabelValues("net-123")))
+}
+
+func TestCheckDriftProcessingInProgress(t *testing.T) {
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer dbMock.Close()
+
+	// the active device is never consulted while endpoints are being processed
+	a := &Agent{pool: dbMock, active: NewMockF5Device(t)}
+	dbMock.ExpectBegin()
+	dbMock.ExpectQuery("SELECT pg_try_advisory_xact_lock($1)").
+		WithArgs(advisoryLockProcessServices).
+		WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	dbMock.ExpectBegin()
+	dbMock.ExpectQuery("SELECT pg_try_advisory_xact_lock($1)").
+		WithArgs(advisoryLockProcessEndpoints).
+		WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	dbMock.ExpectRollback() // held endpoints lock
+	dbMock.ExpectRollback() // services lock
+
+	drifts, err := a.CheckDrift(context.Background(), true)
+	require.ErrorIs(t, err, ErrProcessingInProgress)
+	assert.Nil(t, drifts)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
@@ -82,18 +82,19 @@ func refreshSegments(ctx context.Context, pool db.PgxIface, endpoints []*as3.Ext
 		if endpoint.SegmentId == nil {
 			// Sync endpoint segment to database - we want this because in case of port has been deleted meanwhile,
 			// we loose the segment-id and therefor the ability to delete the l2 configuration
-			var err error
-			var segmentId int
-			segmentId, err = n.GetNetworkSegment(ctx, endpoint.Target.Network.String(), config.Global.Agent.PhysicalNetwork)
+			segmentId, physnet, err := n.FindNetworkSegment(ctx, endpoint.Target.Network.String(),
+				config.Global.Agent.PhysicalNetworks())
 			if err != nil {
 				logger.WithError(err).Warning("ProcessEndpoint: Could not find valid segment")
 				continue
 			}
 			endpoint.SegmentId = &segmentId
+			endpoint.Physnet = &physnet
 
-			log.Infof("ProcessEndpoint: Updating segment_id to %d", segmentId)
+			log.Infof("ProcessEndpoint: Updating segment_id to %d (physnet %s)", segmentId, physnet)
 			sql, args := db.Update("endpoint_port").
 				Set("segment_id", segmentId).
+				Set("physnet", physnet).
 				Where("endpoint_id = ?", endpoint.ID).
 				MustSql()
 			if _, err = pool.Exec(ctx, sql, args...); err != nil {
@@ -115,6 +116,43 @@ func checkAllPendingDelete(endpoints []*as3.ExtendedEndpoint, subnetID string) b
 	return true
 }
 
+// getNetworkEndpoints returns the endpoints of this host's services in networkID,
+// i.e. the content of the network's endpoint tenant.
+func (a *Agent) getNetworkEndpoints(ctx context.Context, networkID strfmt.UUID) ([]*as3.ExtendedEndpoint, error) {
+	var endpoints []*as3.ExtendedEndpoint
+	sql, args := db.Select("endpoint.*",
+		"service.ports AS service_ports",
+		"service.proxy_protocol",
+		"service.network_id AS service_network_id",
+		"service.status AS service_status",
+		"service.connection_limit AS service_connection_limit",
+		"service.rate_limit AS service_rate_limit",
+		"service.traffic AS service_traffic",
+		"(SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) "+
+			"ORDER BY array_position(service.irules, irule.name)), '[]') "+
+			"FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules",
+		"endpoint_port.segment_id",
+		"endpoint_port.physnet",
+		`endpoint_port.port_id AS "target.port"`,
+		`endpoint_port.network AS "target.network"`,
+		`endpoint_port.subnet AS "target.subnet"`,
+		`endpoint_port.owned`).
+		From("endpoint").
+		InnerJoin("service ON endpoint.service_id = service.id").
+		Join("endpoint_port ON endpoint_id = endpoint.id").
+		Where(sq.NotEq{"endpoint.status": []models.EndpointStatus{
+			models.EndpointStatusPENDINGAPPROVAL, // ignore pending approval
+			models.EndpointStatusREJECTED}}).     // ignore rejected, they are considered deleted already
+		Where("network = ?", networkID).
+		Where("service.host = ?", config.Global.Default.Host).
+		Where("service.provider = ?", models.ServiceProviderTenant).
+		MustSql()
+	if err := pgxscan.Select(ctx, a.pool, &endpoints, sql, args...); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
 func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) error {
 	var endpoints []*as3.ExtendedEndpoint
 	var networkID strfmt.UUID
@@ -145,27 +183,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) err
 
 	// Read without FOR UPDATE: all I/O runs with no open write tx, and status
 	// transitions are persisted in a short final tx below.
-	sql, args = db.Select("endpoint.*",
-		"service.ports AS service_ports",
-		"service.proxy_protocol",
-		"service.network_id AS service_network_id",
-		"service.status AS service_status",
-		"endpoint_port.segment_id",
-		`endpoint_port.port_id AS "target.port"`,
-		`endpoint_port.network AS "target.network"`,
-		`endpoint_port.subnet AS "target.subnet"`,
-		`endpoint_port.owned`).
-		From("endpoint").
-		InnerJoin("service ON endpoint.service_id = service.id").
-		Join("endpoint_port ON endpoint_id = endpoint.id").
-		Where(sq.NotEq{"endpoint.status": []models.EndpointStatus{
-			models.EndpointStatusPENDINGAPPROVAL, // ignore pending approval
-			models.EndpointStatusREJECTED}}).     // ignore rejected, they are considered deleted already
-		Where("network = ?", networkID).
-		Where("service.host = ?", config.Global.Default.Host).
-		Where("service.provider = ?", models.ServiceProviderTenant).
-		MustSql()
-	if err := pgxscan.Select(ctx, a.pool, &endpoints, sql, args...); err != nil {
+	if endpoints, err = a.getNetworkEndpoints(ctx, networkID); err != nil {
 		return err
 	}
 
@@ -201,7 +219,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) err
 	if !cleanupL2 {
 		g.Go(func() (err error) {
 			serviceSegmentID, err = a.neutron.GetNetworkSegment(ctx, endpoints[0].ServiceNetworkId.String(),
-				config.Global.Agent.PhysicalNetwork)
+				config.Global.Agent.PhysicalNetworks()...)
 			return
 		})
 		g.Go(func() error {
@@ -223,7 +241,11 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) err
 	   ================================================== */
 	if !cleanupL2 {
 		// VCMP configuration
-		if err := a.EnsureL2(ctx, *endpoints[0].SegmentId, &serviceSegmentID, serviceMTU); err != nil {
+		var physnet string
+		if endpoints[0].Physnet != nil {
+			physnet = *endpoints[0].Physnet
+		}
+		if err := a.EnsureL2(ctx, *endpoints[0].SegmentId, physnet, &serviceSegmentID, serviceMTU); err != nil {
 			return err
 		}
 	}
@@ -261,7 +283,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) err
 		tenantName: as3.GetEndpointTenants(endpoints),
 	})
 
-	if err := a.active.PostAS3(&data, tenantName); err != nil {
+	if err := a.postAS3(&data, tenantName); err != nil {
 		return err
 	}
 
@@ -274,7 +296,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, endpointID strfmt.UUID) err
 	// Get segmentID for subnet before we delete SelfIPs, since they could be the last ports holding the segment
 	var segmentID int
 	if cleanupL2 {
-		segmentID, err = a.neutron.GetSubnetSegment(ctx, subnetID, config.Global.Agent.PhysicalNetwork)
+		segmentID, err = a.neutron.GetSubnetSegment(ctx, subnetID, config.Global.Agent.PhysicalNetworks()...)
 		if err != nil {
 			if !errors.Is(err, aErrors.ErrNoPhysNetFound) {
 				return err
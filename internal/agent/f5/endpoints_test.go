@@ -238,13 +238,13 @@ func TestAgent_ProcessEndpoint(t *testing.T) {
 	dbMock.ExpectQuery("SELECT network, subnet FROM endpoint_port WHERE endpoint_id = $1").
 		WithArgs(endpoint).
 		WillReturnRows(pgxmock.NewRows([]string{"network", "subnet"}).AddRow(network, subnet.String()))
-	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, endpoint_port.segment_id, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
+	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, service.connection_limit AS service_connection_limit, service.rate_limit AS service_rate_limit, service.traffic AS service_traffic, (SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) ORDER BY array_position(service.irules, irule.name)), '[]') FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules, endpoint_port.segment_id, endpoint_port.physnet, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
 		WithArgs(models.EndpointStatusPENDINGAPPROVAL, models.EndpointStatusREJECTED, network, config.Global.Default.Host, models.ServiceProviderTenant).
 		WillReturnRows(pgxmock.
 			NewRows([]string{"id", "service_id", "name", "service_ports", "proxy_protocol", "service_network_id", "service_status", "segment_id", "target.port", "target.network", "target.subnet"}).
 			AddRow(endpoint, service, "test-service", []int32{80}, false, serviceNetwork, string(models.ServiceStatusAVAILABLE), nil, &port, &network, &subnet))
-	dbMock.ExpectExec("UPDATE endpoint_port SET segment_id = $1 WHERE endpoint_id = $2").
-		WithArgs(123, endpoint).
+	dbMock.ExpectExec("UPDATE endpoint_port SET segment_id = $1, physnet = $2 WHERE endpoint_id = $3").
+		WithArgs(123, "physnet1", endpoint).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 	dbMock.ExpectExec("SELECT 1 FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint_port.subnet = $1 AND service.host = $2 AND service.provider = $3 AND endpoint.status NOT IN ($4,$5)").
 		WithArgs(subnet.String(), config.Global.Default.Host, models.ServiceProviderTenant, models.EndpointStatusPENDINGDELETE, models.EndpointStatusPENDINGREJECTED).
@@ -329,14 +329,14 @@ func TestAgent_ProcessEndpointDefersWhenServiceNotReady(t *testing.T) {
 	dbMock.ExpectQuery("SELECT network, subnet FROM endpoint_port WHERE endpoint_id = $1").
 		WithArgs(endpoint).
 		WillReturnRows(pgxmock.NewRows([]string{"network", "subnet"}).AddRow(network, subnet.String()))
-	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, endpoint_port.segment_id, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
+	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, service.connection_limit AS service_connection_limit, service.rate_limit AS service_rate_limit, service.traffic AS service_traffic, (SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) ORDER BY array_position(service.irules, irule.name)), '[]') FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules, endpoint_port.segment_id, endpoint_port.physnet, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
 		WithArgs(models.EndpointStatusPENDINGAPPROVAL, models.EndpointStatusREJECTED, network, config.Global.Default.Host, models.ServiceProviderTenant).
 		WillReturnRows(pgxmock.
 			NewRows([]string{"id", "service_id", "name", "service_ports", "proxy_protocol", "service_network_id", "service_status", "segment_id", "target.port", "target.network", "target.subnet"}).
 			// Service is still PENDING_CREATE: snatpool not yet posted to BigIP.
 			AddRow(endpoint, service, "test-service", []int32{80}, false, serviceNetwork, string(models.ServiceStatusPENDINGCREATE), nil, &port, &network, &subnet))
-	dbMock.ExpectExec("UPDATE endpoint_port SET segment_id = $1 WHERE endpoint_id = $2").
-		WithArgs(123, endpoint).
+	dbMock.ExpectExec("UPDATE endpoint_port SET segment_id = $1, physnet = $2 WHERE endpoint_id = $3").
+		WithArgs(123, "physnet1", endpoint).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 	dbMock.ExpectExec("SELECT 1 FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint_port.subnet = $1 AND service.host = $2 AND service.provider = $3 AND endpoint.status NOT IN ($4,$5)").
 		WithArgs(subnet.String(), config.Global.Default.Host, models.ServiceProviderTenant, models.EndpointStatusPENDINGDELETE, models.EndpointStatusPENDINGREJECTED).
@@ -397,7 +397,7 @@ func TestAgent_DeleteEndpointWithDeletedNetwork(t *testing.T) {
 	dbMock.ExpectQuery("SELECT network, subnet FROM endpoint_port WHERE endpoint_id = $1").
 		WithArgs(endpoint).
 		WillReturnRows(pgxmock.NewRows([]string{"network", "subnet"}).AddRow(network, subnet.String()))
-	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, endpoint_port.segment_id, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
+	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, service.connection_limit AS service_connection_limit, service.rate_limit AS service_rate_limit, service.traffic AS service_traffic, (SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) ORDER BY array_position(service.irules, irule.name)), '[]') FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules, endpoint_port.segment_id, endpoint_port.physnet, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
 		WithArgs(models.EndpointStatusPENDINGAPPROVAL, models.EndpointStatusREJECTED, network, config.Global.Default.Host, models.ServiceProviderTenant).
 		WillReturnRows(pgxmock.
 			NewRows([]string{"id", "service_id", "status", "name", "service_ports", "proxy_protocol", "service_network_id", "service_status", "segment_id", "target.port", "target.network", "target.subnet"}).
@@ -452,7 +452,7 @@ func TestAgent_TestEndpointRequiringApproval(t *testing.T) {
 	dbMock.ExpectQuery("SELECT network, subnet FROM endpoint_port WHERE endpoint_id = $1").
 		WithArgs(endpoint).
 		WillReturnRows(pgxmock.NewRows([]string{"network", "subnet"}).AddRow(network, subnet.String()))
-	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, endpoint_port.segment_id, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
+	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, service.connection_limit AS service_connection_limit, service.rate_limit AS service_rate_limit, service.traffic AS service_traffic, (SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) ORDER BY array_position(service.irules, irule.name)), '[]') FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules, endpoint_port.segment_id, endpoint_port.physnet, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
 		WithArgs(models.EndpointStatusPENDINGAPPROVAL, models.EndpointStatusREJECTED, network, config.Global.Default.Host, models.ServiceProviderTenant).
 		WillReturnRows(pgxmock.
 			NewRows([]string{"id", "service_id", "status", "name", "service_ports", "proxy_protocol", "service_network_id", "service_status", "segment_id", "target.port", "target.network", "target.subnet"}))
@@ -531,7 +531,7 @@ func TestAgent_DeleteEndpointWithMissingNeutronPort(t *testing.T) {
 		WithArgs(endpoint1).
 		WillReturnRows(pgxmock.NewRows([]string{"network", "subnet"}).AddRow(network, subnet.String()))
 	// Return both endpoints: endpoint1 (PENDING_DELETE) and endpoint2 (AVAILABLE)
-	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, endpoint_port.segment_id, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
+	dbMock.ExpectQuery("SELECT endpoint.*, service.ports AS service_ports, service.proxy_protocol, service.network_id AS service_network_id, service.status AS service_status, service.connection_limit AS service_connection_limit, service.rate_limit AS service_rate_limit, service.traffic AS service_traffic, (SELECT COALESCE(json_agg(json_build_object('name', irule.name, 'content', irule.content) ORDER BY array_position(service.irules, irule.name)), '[]') FROM irule WHERE irule.name = ANY(service.irules)) AS service_irules, endpoint_port.segment_id, endpoint_port.physnet, endpoint_port.port_id AS \"target.port\", endpoint_port.network AS \"target.network\", endpoint_port.subnet AS \"target.subnet\", endpoint_port.owned FROM endpoint INNER JOIN service ON endpoint.service_id = service.id JOIN endpoint_port ON endpoint_id = endpoint.id WHERE endpoint.status NOT IN ($1,$2) AND network = $3 AND service.host = $4 AND service.provider = $5").
 		WithArgs(models.EndpointStatusPENDINGAPPROVAL, models.EndpointStatusREJECTED, network, config.Global.Default.Host, models.ServiceProviderTenant).
 		WillReturnRows(pgxmock.
 			NewRows([]string{"id", "service_id", "status", "name", "service_ports", "proxy_protocol", "service_network_id", "service_status", "segment_id", "target.port", "target.network", "target.subnet"}).
@@ -4,6 +4,7 @@
 package f5
 
 import (
+	"encoding/json"
 	"errors"
 	"net/url"
 
@@ -17,6 +18,10 @@ type F5Device interface {
 	// PostAS3 posts AS3 configuration to the device.
 	PostAS3(as3 *as3.AS3, tenant string) error
 
+	// GetAS3Tenant returns the AS3 declaration of the tenant stored on the device,
+	// or nil if AS3 does not manage the tenant.
+	GetAS3Tenant(tenant string) (json.RawMessage, error)
+
 	// GetDeviceType returns the type of the F5 device (e.g., "bigip", "f5os").
 	GetDeviceType() string
 
@@ -27,6 +32,11 @@ type F5Device interface {
 	// It should return a string indicating the state, such as "active", "standby", or "unknown".
 	GetFailoverState() string
 
+	// L2Only reports whether the device only supports VLAN provisioning (F5OS).
+	// All AS3, route domain and self IP operations of such a device fail, so it
+	// can only be used as vcmp host, never as ltm guest.
+	L2Only() bool
+
 	// GetPartitions returns a list of partitions on the F5 device.
 	GetPartitions() ([]string, error)
 
@@ -42,8 +52,8 @@ type F5Device interface {
 	// EnsureVLAN ensures that a VLAN with the given segment ID and MTU exists on the device.
 	EnsureVLAN(segmentId int, mtu int) error
 
-	// EnsureInterfaceVlan ensures that the interface VLAN for the given segment ID exists on the device.
-	EnsureInterfaceVlan(segmentId int) error
+	// EnsureInterfaceVlan ensures that the VLAN for the given segment ID is bound to the interface on the device.
+	EnsureInterfaceVlan(segmentId int, iface string) error
 
 	// EnsureGuestVlan ensures that the guest VLAN for the given segment ID exists on the device.
 	EnsureGuestVlan(segmentId int) error
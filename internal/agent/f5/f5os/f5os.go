@@ -28,6 +28,11 @@ import (
 	"github.com/sapcc/archer/v2/internal/config"
 )
 
+// ErrL2Only is returned by all L3/L4 operations: F5OS only provisions VLANs
+// and trunks, AS3 declarations, route domains and self IPs are configured on
+// the BIG-IP tenants it hosts.
+var ErrL2Only = errors.New("not supported by F5OS (L2 only)")
+
 // token represents a JWT token used for authentication with F5OS.
 type token string
 
@@ -197,8 +202,18 @@ func (f *F5OS) apiCall(req *http.Request, v any) error {
 	})
 }
 
-func (f *F5OS) PostAS3(_ *as3.AS3, _ string) error {
-	panic("not supported for F5OS")
+func (f *F5OS) PostAS3(_ *as3.AS3, tenant string) error {
+	return fmt.Errorf("posting AS3 tenant %s to %s: %w", tenant, f.uri.Host, ErrL2Only)
+}
+
+func (f *F5OS) GetAS3Tenant(tenant string) (json.RawMessage, error) {
+	return nil, fmt.Errorf("fetching AS3 tenant %s from %s: %w", tenant, f.uri.Host, ErrL2Only)
+}
+
+// L2Only reports that F5OS only provisions VLANs, L3/L4 objects live on the
+// BIG-IP tenants it hosts.
+func (f *F5OS) L2Only() bool {
+	return true
 }
 
 func (f *F5OS) GetDeviceType() string {
@@ -214,7 +229,7 @@ func (f *F5OS) GetFailoverState() string {
 }
 
 func (f *F5OS) GetPartitions() ([]string, error) {
-	panic("not supported for F5OS")
+	return nil, fmt.Errorf("fetching partitions from %s: %w", f.uri.Host, ErrL2Only)
 }
 
 func (f *F5OS) GetVLANs() ([]string, error) {
@@ -235,11 +250,11 @@ func (f *F5OS) GetVLANs() ([]string, error) {
 }
 
 func (f *F5OS) GetRouteDomains() ([]string, error) {
-	panic("not supported for F5OS")
+	return nil, fmt.Errorf("fetching route domains from %s: %w", f.uri.Host, ErrL2Only)
 }
 
 func (f *F5OS) GetSelfIPs() ([]string, error) {
-	panic("not supported for F5OS")
+	return nil, fmt.Errorf("fetching self IPs from %s: %w", f.uri.Host, ErrL2Only)
 }
 
 func (f *F5OS) EnsureVLAN(segmentId int, _ int) error {
@@ -263,10 +278,14 @@ func (f *F5OS) EnsureVLAN(segmentId int, _ int) error {
 	return nil
 }
 
-func (f *F5OS) EnsureInterfaceVlan(segmentID int) error {
-	path := "api/data/openconfig-interfaces:interfaces/interface=" +
-		config.Global.Agent.PhysicalInterface +
+// trunkVlansPath returns the API path of the trunk VLANs of an interface.
+func trunkVlansPath(iface string) string {
+	return "api/data/openconfig-interfaces:interfaces/interface=" + iface +
 		"/openconfig-if-aggregate:aggregation/openconfig-vlan:switched-vlan/config/trunk-vlans"
+}
+
+func (f *F5OS) EnsureInterfaceVlan(segmentID int, iface string) error {
+	path := trunkVlansPath(iface)
 
 	var vlans trunkVlans
 	if err := f.apiCall(f.newRequest("GET", path, nil), &vlans); err != nil {
@@ -277,7 +296,7 @@ func (f *F5OS) EnsureInterfaceVlan(segmentID int) error {
 		log.WithFields(log.Fields{
 			"host":      f.GetHostname(),
 			"segmentID": segmentID,
-			"interface": config.Global.Agent.PhysicalInterface,
+			"interface": iface,
 		}).Debug("Trunk VLAN already exists")
 		return nil
 	}
@@ -320,12 +339,12 @@ func (f *F5OS) EnsureGuestVlan(segmentId int) error {
 	return nil
 }
 
-func (f *F5OS) EnsureRouteDomain(_ int, _ *int) error {
-	panic("not supported for F5OS")
+func (f *F5OS) EnsureRouteDomain(segmentId int, _ *int) error {
+	return fmt.Errorf("ensuring route domain %d on %s: %w", segmentId, f.uri.Host, ErrL2Only)
 }
 
-func (f *F5OS) EnsureBigIPSelfIP(_, _ string, _ int) error {
-	panic("not supported for F5OS")
+func (f *F5OS) EnsureBigIPSelfIP(name, _ string, _ int) error {
+	return fmt.Errorf("ensuring self IP %s on %s: %w", name, f.uri.Host, ErrL2Only)
 }
 
 func (f *F5OS) getTenant() (*F5TenantsTenant, error) {
@@ -403,21 +422,30 @@ func (f *F5OS) DeleteVLAN(segmentId int) error {
 	return nil
 }
 
-func (f *F5OS) DeleteSelfIP(_ string) error {
-	panic("not supported for F5OS")
+func (f *F5OS) DeleteSelfIP(name string) error {
+	return fmt.Errorf("deleting self IP %s on %s: %w", name, f.uri.Host, ErrL2Only)
 }
 
+// DeleteInterfaceVlan removes the VLAN of the segment from the trunk VLANs of every
+// interface carrying a physical network of the agent.
 func (f *F5OS) DeleteInterfaceVlan(segmentID int) error {
-	path := "api/data/openconfig-interfaces:interfaces/interface=" +
-		config.Global.Agent.PhysicalInterface +
-		"/openconfig-if-aggregate:aggregation/openconfig-vlan:switched-vlan/config/trunk-vlans"
+	for _, iface := range config.Global.Agent.Interfaces() {
+		if err := f.deleteInterfaceVlan(segmentID, iface); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *F5OS) deleteInterfaceVlan(segmentID int, iface string) error {
+	path := trunkVlansPath(iface)
 
 	var vlans trunkVlans
 	if err := f.apiCall(f.newRequest("GET", path, nil), &vlans); err != nil {
 		return fmt.Errorf("error fetching trunk VLANs: %w", err)
 	}
 
-	b := make([]int, 0, len(vlans.OpenconfigVlanTrunkVlans)-1)
+	b := make([]int, 0, len(vlans.OpenconfigVlanTrunkVlans))
 	for _, v := range vlans.OpenconfigVlanTrunkVlans {
 		if v != segmentID {
 			b = append(b, v)
@@ -463,8 +491,8 @@ func (f *F5OS) DeleteGuestVLAN(segmentId int) error {
 	return nil
 }
 
-func (f *F5OS) DeleteRouteDomain(_ int) error {
-	panic("not supported for F5OS")
+func (f *F5OS) DeleteRouteDomain(segmentId int) error {
+	return fmt.Errorf("deleting route domain %d on %s: %w", segmentId, f.uri.Host, ErrL2Only)
 }
 
 func NewSession(uri *url.URL) (*F5OS, error) {
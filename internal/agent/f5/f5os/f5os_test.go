@@ -9,10 +9,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/config"
 )
 
 // makeJWT builds a minimal unsigned JWT whose payload carries the given exp.
@@ -56,3 +62,53 @@ func TestF5os_apiCall(t *testing.T) {
 	assert.NoError(t, err, "Failed to create HTTP request")
 	assert.NoError(t, f5.apiCall(req, nil), "API call should not fail with unauthorized status")
 }
+
+func TestF5os_L2Only(t *testing.T) {
+	f5 := F5OS{uri: &url.URL{Host: "rseries-1"}}
+	assert.True(t, f5.L2Only())
+
+	assert.ErrorIs(t, f5.PostAS3(nil, "Common"), ErrL2Only)
+	assert.ErrorIs(t, f5.EnsureRouteDomain(100, nil), ErrL2Only)
+	assert.ErrorIs(t, f5.EnsureBigIPSelfIP("selfip-1", "10.0.0.1", 100), ErrL2Only)
+	assert.ErrorIs(t, f5.DeleteSelfIP("selfip-1"), ErrL2Only)
+	assert.ErrorIs(t, f5.DeleteRouteDomain(100), ErrL2Only)
+	_, err := f5.GetRouteDomains()
+	assert.ErrorIs(t, err, ErrL2Only)
+}
+
+func TestF5os_InterfaceVlans(t *testing.T) {
+	config.Global.Agent.BridgeMappings = []string{"physnet1:portchannel1", "physnet2:portchannel2"}
+	defer func() { config.Global.Agent.BridgeMappings = nil }()
+
+	var mu sync.Mutex
+	trunks := map[string][]int{"portchannel1": {}, "portchannel2": {200}}
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		iface := strings.TrimPrefix(req.URL.Path, "/api/data/openconfig-interfaces:interfaces/interface=")
+		iface, _, _ = strings.Cut(iface, "/")
+		switch req.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(res).Encode(trunkVlans{trunks[iface]})
+		case http.MethodPut:
+			var vlans trunkVlans
+			_ = json.NewDecoder(req.Body).Decode(&vlans)
+			trunks[iface] = vlans.OpenconfigVlanTrunkVlans
+		}
+	}))
+	defer testServer.Close()
+
+	uri, err := url.Parse(testServer.URL)
+	require.NoError(t, err)
+	f5 := F5OS{client: testServer.Client(), uri: uri}
+
+	// the VLAN is bound to the interface of the segment's physical network
+	require.NoError(t, f5.EnsureInterfaceVlan(100, "portchannel2"))
+	assert.Empty(t, trunks["portchannel1"])
+	assert.Equal(t, []int{200, 100}, trunks["portchannel2"])
+
+	// and removed from every interface
+	require.NoError(t, f5.DeleteInterfaceVlan(100))
+	assert.Empty(t, trunks["portchannel1"])
+	assert.Equal(t, []int{200}, trunks["portchannel2"])
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
+)
+
+var (
+	failoverEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "archer_f5_failovers",
+		Help: "Counter of switches of the active device AS3 declarations are posted to",
+	}, []string{"from", "to"})
+	deviceActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "archer_f5_device_active",
+		Help: "Whether the device is the active device AS3 declarations are posted to",
+	}, []string{"device"})
+)
+
+// FailoverCheckLoop re-elects the active device and re-posts all tenants to it
+// after a switch, so declarations never end up on a standby unit.
+func (a *Agent) FailoverCheckLoop(ctx context.Context) error {
+	current := a.activeDevice()
+	active := a.electActive()
+	if active == nil {
+		log.WithField("active", current.GetHostname()).
+			Warning("FailoverCheckLoop: no device reports active state, keeping current device")
+		return nil
+	}
+
+	if active != current {
+		previous := current.GetHostname()
+		a.setActive(active)
+		failoverEvents.WithLabelValues(previous, active.GetHostname()).Inc()
+		log.WithFields(log.Fields{"from": previous, "to": active.GetHostname()}).
+			Warning("FailoverCheckLoop: active device changed, re-posting all tenants")
+		a.repostPending.Store(true)
+	}
+
+	if !a.repostPending.Load() {
+		return nil
+	}
+	if err := a.repostTenants(ctx); err != nil {
+		if errors.Is(err, errAdvisoryLockHeld) {
+			log.Debug("FailoverCheckLoop: processing in progress, retrying re-post next cycle")
+			return nil
+		}
+		return err
+	}
+	a.repostPending.Store(false)
+	return nil
+}
+
+// electActive returns the device reporting active failover state, preferring the
+// current one, or nil if there is none.
+func (a *Agent) electActive() F5Device {
+	current := a.activeDevice()
+	if current != nil && current.GetFailoverState() == "active" {
+		return current
+	}
+	for _, device := range a.devices {
+		if device != current && device.GetFailoverState() == "active" {
+			return device
+		}
+	}
+	return nil
+}
+
+// activeDevice returns the device AS3 declarations are posted to.
+func (a *Agent) activeDevice() F5Device {
+	a.activeMu.RLock()
+	defer a.activeMu.RUnlock()
+	return a.active
+}
+
+// setActive switches the device AS3 declarations are posted to.
+func (a *Agent) setActive(device F5Device) {
+	a.activeMu.Lock()
+	a.active = device
+	a.activeMu.Unlock()
+	a.health.ActiveDevice(device.GetHostname())
+	for _, d := range a.devices {
+		value := 0.0
+		if d == device {
+			value = 1
+		}
+		deviceActive.WithLabelValues(d.GetHostname()).Set(value)
+	}
+}
+
+// repostTenants posts the declaration of every tenant without pending services
+// or endpoints to the active device, pending ones are posted by their next run.
+func (a *Agent) repostTenants(ctx context.Context) error {
+	// Don't race ProcessServices/ProcessEndpoint posting the same tenants
+	for _, lockID := range []int64{advisoryLockProcessServices, advisoryLockProcessEndpoints} {
+		lockTx, err := a.tryAdvisoryLock(ctx, lockID)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lockTx.Rollback(ctx) }()
+	}
+
+	tenants, err := a.generateTenants(ctx, tenantSelector{skipPending: true})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, tenant := range slices.Sorted(maps.Keys(tenants)) {
+		data := as3.GetAS3Declaration(map[string]as3.Tenant{tenant: tenants[tenant]})
+		if err = a.postAS3(&data, tenant); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_electActive(t *testing.T) {
+	bigip1 := NewMockF5Device(t)
+	bigip2 := NewMockF5Device(t)
+	a := &Agent{devices: []F5Device{bigip1, bigip2}, active: bigip1}
+
+	bigip1.EXPECT().GetFailoverState().Return("active").Once()
+	assert.Equal(t, bigip1, a.electActive())
+
+	bigip1.EXPECT().GetFailoverState().Return("standby").Once()
+	bigip2.EXPECT().GetFailoverState().Return("active").Once()
+	assert.Equal(t, bigip2, a.electActive())
+
+	bigip1.EXPECT().GetFailoverState().Return("standby").Once()
+	bigip2.EXPECT().GetFailoverState().Return("unknown").Once()
+	assert.Nil(t, a.electActive())
+}
+
+func TestAgent_FailoverCheckLoop(t *testing.T) {
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer dbMock.Close()
+
+	bigip1 := NewMockF5Device(t)
+	bigip1.EXPECT().GetHostname().Return("bigip-1")
+	bigip2 := NewMockF5Device(t)
+	bigip2.EXPECT().GetHostname().Return("bigip-2")
+	a := &Agent{pool: dbMock, devices: []F5Device{bigip1, bigip2}, active: bigip1}
+
+	bigip1.EXPECT().GetFailoverState().Return("standby")
+	bigip2.EXPECT().GetFailoverState().Return("active")
+
+	// another run posts tenants, the re-post is retried next cycle
+	dbMock.ExpectBegin()
+	dbMock.ExpectQuery("SELECT pg_try_advisory_xact_lock($1)").
+		WithArgs(advisoryLockProcessServices).
+		WillReturnRows(dbMock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	dbMock.ExpectRollback()
+
+	before := testutil.ToFloat64(failoverEvents.WithLabelValues("bigip-1", "bigip-2"))
+	require.NoError(t, a.FailoverCheckLoop(context.Background()))
+	assert.Equal(t, bigip2, a.activeDevice())
+	assert.True(t, a.repostPending.Load())
+	assert.Equal(t, before+1, testutil.ToFloat64(failoverEvents.WithLabelValues("bigip-1", "bigip-2")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(deviceActive.WithLabelValues("bigip-1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(deviceActive.WithLabelValues("bigip-2")))
+
+	health := a.health.Snapshot(0)
+	assert.Equal(t, "bigip-2", health.ActiveDevice)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
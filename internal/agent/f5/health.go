@@ -130,7 +130,7 @@ func ComputeServiceHealth(portStatuses []string) string {
 // ScrapeServiceHealth queries the F5 device for all pools of a service and computes aggregate health.
 func (a *Agent) ScrapeServiceHealth(ctx context.Context, service *models.Service) string {
 	// Get the active BigIP device
-	device, ok := a.active.(*bigip.BigIP)
+	device, ok := a.activeDevice().(*bigip.BigIP)
 	if !ok {
 		log.WithField("service_id", service.ID).Debug("Active device is not a BigIP, cannot scrape health")
 		return HealthStatusUnchecked
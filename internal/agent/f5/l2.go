@@ -21,13 +21,16 @@ import (
 // L2 (VLAN, Route Domain, Guest VLAN)
 // --------------------------------------------------------------------------
 
-// EnsureL2 ensures that L2 configuration exists on BIG-IP Guest(s) and Host(s) for the given segmentID.
-func (a *Agent) EnsureL2(ctx context.Context, segmentID int, parentSegmentID *int, mtu int) error {
+// EnsureL2 ensures that L2 configuration exists on BIG-IP Guest(s) and Host(s) for the given segmentID
+// of the physical network physnet.
+func (a *Agent) EnsureL2(ctx context.Context, segmentID int, physnet string, parentSegmentID *int, mtu int) error {
 	printSegmentID := "nil"
 	if parentSegmentID != nil {
 		printSegmentID = fmt.Sprint(*parentSegmentID)
 	}
-	log.WithFields(log.Fields{"segmentID": segmentID, "parentSegmentID": printSegmentID}).Debug("EnsureL2")
+	iface := config.Global.Agent.GetInterface(physnet)
+	log.WithFields(log.Fields{"segmentID": segmentID, "parentSegmentID": printSegmentID, "interface": iface}).
+		Debug("EnsureL2")
 
 	g, _ := errgroup.WithContext(ctx)
 	g.Go(func() error {
@@ -35,7 +38,7 @@ func (a *Agent) EnsureL2(ctx context.Context, segmentID int, parentSegmentID *in
 			if err := host.EnsureVLAN(segmentID, mtu); err != nil {
 				return fmt.Errorf("EnsureVLAN: %s", err.Error())
 			}
-			if err := host.EnsureInterfaceVlan(segmentID); err != nil {
+			if err := host.EnsureInterfaceVlan(segmentID, iface); err != nil {
 				return fmt.Errorf("EnsureInterfaceVlan: %s", err.Error())
 			}
 			if err := host.EnsureGuestVlan(segmentID); err != nil {
@@ -121,7 +124,7 @@ func (a *Agent) EnsureSelfIPs(ctx context.Context, subnetID string, dryRun bool)
 		return err
 	}
 
-	segmentID, err := a.neutron.GetSubnetSegment(ctx, subnetID, config.Global.Agent.PhysicalNetwork)
+	segmentID, err := a.neutron.GetSubnetSegment(ctx, subnetID, config.Global.Agent.PhysicalNetworks()...)
 	if err != nil {
 		return err
 	}
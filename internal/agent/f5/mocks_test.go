@@ -5,6 +5,8 @@
 package f5
 
 import (
+	"encoding/json"
+
 	mock "github.com/stretchr/testify/mock"
 
 	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
@@ -356,16 +358,16 @@ func (_c *MockF5Device_EnsureGuestVlan_Call) RunAndReturn(run func(segmentId int
 }
 
 // EnsureInterfaceVlan provides a mock function for the type MockF5Device
-func (_mock *MockF5Device) EnsureInterfaceVlan(segmentId int) error {
-	ret := _mock.Called(segmentId)
+func (_mock *MockF5Device) EnsureInterfaceVlan(segmentId int, iface string) error {
+	ret := _mock.Called(segmentId, iface)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EnsureInterfaceVlan")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
-		r0 = returnFunc(segmentId)
+	if returnFunc, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = returnFunc(segmentId, iface)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -379,18 +381,24 @@ type MockF5Device_EnsureInterfaceVlan_Call struct {
 
 // EnsureInterfaceVlan is a helper method to define mock.On call
 //   - segmentId int
-func (_e *MockF5Device_Expecter) EnsureInterfaceVlan(segmentId interface{}) *MockF5Device_EnsureInterfaceVlan_Call {
-	return &MockF5Device_EnsureInterfaceVlan_Call{Call: _e.mock.On("EnsureInterfaceVlan", segmentId)}
+//   - iface string
+func (_e *MockF5Device_Expecter) EnsureInterfaceVlan(segmentId interface{}, iface interface{}) *MockF5Device_EnsureInterfaceVlan_Call {
+	return &MockF5Device_EnsureInterfaceVlan_Call{Call: _e.mock.On("EnsureInterfaceVlan", segmentId, iface)}
 }
 
-func (_c *MockF5Device_EnsureInterfaceVlan_Call) Run(run func(segmentId int)) *MockF5Device_EnsureInterfaceVlan_Call {
+func (_c *MockF5Device_EnsureInterfaceVlan_Call) Run(run func(segmentId int, iface string)) *MockF5Device_EnsureInterfaceVlan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -401,7 +409,7 @@ func (_c *MockF5Device_EnsureInterfaceVlan_Call) Return(err error) *MockF5Device
 	return _c
 }
 
-func (_c *MockF5Device_EnsureInterfaceVlan_Call) RunAndReturn(run func(segmentId int) error) *MockF5Device_EnsureInterfaceVlan_Call {
+func (_c *MockF5Device_EnsureInterfaceVlan_Call) RunAndReturn(run func(segmentId int, iface string) error) *MockF5Device_EnsureInterfaceVlan_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -520,6 +528,68 @@ func (_c *MockF5Device_EnsureVLAN_Call) RunAndReturn(run func(segmentId int, mtu
 	return _c
 }
 
+// GetAS3Tenant provides a mock function for the type MockF5Device
+func (_mock *MockF5Device) GetAS3Tenant(tenant string) (json.RawMessage, error) {
+	ret := _mock.Called(tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAS3Tenant")
+	}
+
+	var r0 json.RawMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (json.RawMessage, error)); ok {
+		return returnFunc(tenant)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) json.RawMessage); ok {
+		r0 = returnFunc(tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockF5Device_GetAS3Tenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAS3Tenant'
+type MockF5Device_GetAS3Tenant_Call struct {
+	*mock.Call
+}
+
+// GetAS3Tenant is a helper method to define mock.On call
+//   - tenant string
+func (_e *MockF5Device_Expecter) GetAS3Tenant(tenant interface{}) *MockF5Device_GetAS3Tenant_Call {
+	return &MockF5Device_GetAS3Tenant_Call{Call: _e.mock.On("GetAS3Tenant", tenant)}
+}
+
+func (_c *MockF5Device_GetAS3Tenant_Call) Run(run func(tenant string)) *MockF5Device_GetAS3Tenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockF5Device_GetAS3Tenant_Call) Return(rawMessage json.RawMessage, err error) *MockF5Device_GetAS3Tenant_Call {
+	_c.Call.Return(rawMessage, err)
+	return _c
+}
+
+func (_c *MockF5Device_GetAS3Tenant_Call) RunAndReturn(run func(tenant string) (json.RawMessage, error)) *MockF5Device_GetAS3Tenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDeviceType provides a mock function for the type MockF5Device
 func (_mock *MockF5Device) GetDeviceType() string {
 	ret := _mock.Called()
@@ -872,6 +942,50 @@ func (_c *MockF5Device_GetVLANs_Call) RunAndReturn(run func() ([]string, error))
 	return _c
 }
 
+// L2Only provides a mock function for the type MockF5Device
+func (_mock *MockF5Device) L2Only() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for L2Only")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockF5Device_L2Only_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'L2Only'
+type MockF5Device_L2Only_Call struct {
+	*mock.Call
+}
+
+// L2Only is a helper method to define mock.On call
+func (_e *MockF5Device_Expecter) L2Only() *MockF5Device_L2Only_Call {
+	return &MockF5Device_L2Only_Call{Call: _e.mock.On("L2Only")}
+}
+
+func (_c *MockF5Device_L2Only_Call) Run(run func()) *MockF5Device_L2Only_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockF5Device_L2Only_Call) Return(b bool) *MockF5Device_L2Only_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockF5Device_L2Only_Call) RunAndReturn(run func() bool) *MockF5Device_L2Only_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PostAS3 provides a mock function for the type MockF5Device
 func (_mock *MockF5Device) PostAS3(as31 *as3.AS3, tenant string) error {
 	ret := _mock.Called(as31, tenant)
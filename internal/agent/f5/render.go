@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/internal/db"
+	"github.com/sapcc/archer/v2/models"
+)
+
+// tenantSelector narrows down the tenants generated by generateTenants.
+type tenantSelector struct {
+	// tenant limits generation to "Common" or one "net-<network id>" tenant, empty means all.
+	tenant string
+	// skipPending leaves out tenants with pending services or endpoints.
+	skipPending bool
+}
+
+func (ts tenantSelector) includes(tenant string) bool {
+	return ts.tenant == "" || ts.tenant == tenant
+}
+
+// RenderDeclaration generates the AS3 declaration of this host's tenants from
+// the database, the way ProcessServices and ProcessEndpoint build it, without
+// contacting any device or modifying Neutron ports. tenant limits the output
+// to "Common" or one "net-<network id>" tenant, empty renders all.
+func (a *Agent) RenderDeclaration(ctx context.Context, tenant string) (*as3.AS3, error) {
+	tenants, err := a.generateTenants(ctx, tenantSelector{tenant: tenant})
+	if err != nil {
+		return nil, err
+	}
+	if tenant != "" && len(tenants) == 0 {
+		return nil, fmt.Errorf("tenant %s not found for host %s", tenant, config.Global.Default.Host)
+	}
+	data := as3.GetAS3Declaration(tenants)
+	return &data, nil
+}
+
+// ValidateDeclaration validates the declaration against the AS3 JSON schema
+// stored at schemaPath, as published with the AS3 releases. The draft-07
+// schema is validated as its draft-4 equivalent, see draft4Schema.
+func ValidateDeclaration(data *as3.AS3, schemaPath string) error {
+	raw, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	var draft7 any
+	if err = json.Unmarshal(raw, &draft7); err != nil {
+		return fmt.Errorf("parsing AS3 schema: %w", err)
+	}
+	if raw, err = json.Marshal(draft4Schema(draft7)); err != nil {
+		return err
+	}
+	var schema spec.Schema
+	if err = json.Unmarshal(raw, &schema); err != nil {
+		return fmt.Errorf("parsing AS3 schema: %w", err)
+	}
+
+	// validate the generic JSON representation, as the device would see it
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var declaration any
+	if err = json.Unmarshal(body, &declaration); err != nil {
+		return err
+	}
+	return validate.AgainstSchema(&schema, declaration, strfmt.Default)
+}
+
+// generateTenants builds the Common tenant and the endpoint tenant of every
+// network of this host from the database. Neutron is only queried, SNAT ports
+// are never created and missing endpoint segments are not persisted.
+func (a *Agent) generateTenants(ctx context.Context, sel tenantSelector) (map[string]as3.Tenant, error) {
+	tenants := make(map[string]as3.Tenant)
+
+	if sel.includes("Common") {
+		var dbServices []*models.Service
+		sql, args := db.Select("*").
+			From("service").
+			Where("host = ?", config.Global.Default.Host).
+			Where("provider = ?", models.ServiceProviderTenant).
+			MustSql()
+		if err := pgxscan.Select(ctx, a.pool, &dbServices, sql, args...); err != nil {
+			return nil, err
+		}
+
+		if !sel.skipPending || !slices.ContainsFunc(dbServices, func(s *models.Service) bool {
+			return isPendingService(s.Status)
+		}) {
+			services := make([]*as3.ExtendedService, 0, len(dbServices))
+			for _, s := range dbServices {
+				service, err := a.getExtendedService(ctx, s, true)
+				if err != nil {
+					// ProcessServices leaves such services out as well, the result would be unreliable
+					log.WithError(err).WithField("service", s.ID).Warning("generateTenants: skipping Common tenant")
+					services = nil
+					break
+				}
+				services = append(services, service)
+			}
+			if services != nil {
+				tenants["Common"] = as3.GetServiceTenants(services)
+			}
+		}
+	}
+
+	var networks []strfmt.UUID
+	sql, args := db.Select("DISTINCT endpoint_port.network").
+		From("endpoint_port").
+		Join("endpoint ON endpoint.id = endpoint_port.endpoint_id").
+		Join("service ON service.id = endpoint.service_id").
+		Where("service.host = ?", config.Global.Default.Host).
+		Where("service.provider = ?", models.ServiceProviderTenant).
+		OrderBy("endpoint_port.network").
+		MustSql()
+	if err := pgxscan.Select(ctx, a.pool, &networks, sql, args...); err != nil {
+		return nil, err
+	}
+
+	for _, networkID := range networks {
+		tenant := as3.GetEndpointTenantName(networkID)
+		if !sel.includes(tenant) {
+			continue
+		}
+
+		endpoints, err := a.getNetworkEndpoints(ctx, networkID)
+		if err != nil {
+			return nil, err
+		}
+		if len(endpoints) == 0 || (sel.skipPending && slices.ContainsFunc(endpoints, func(ep *as3.ExtendedEndpoint) bool {
+			return isPendingEndpoint(ep.Status) || ep.ServiceStatus != string(models.ServiceStatusAVAILABLE)
+		})) {
+			continue
+		}
+
+		for _, ep := range endpoints {
+			if ep.SegmentId == nil && !isPendingEndpoint(ep.Status) {
+				segmentID, err := a.neutron.GetNetworkSegment(ctx, networkID.String(),
+					config.Global.Agent.PhysicalNetworks()...)
+				if err != nil {
+					return nil, fmt.Errorf("network %s: %w", networkID, err)
+				}
+				ep.SegmentId = &segmentID
+			}
+		}
+		if err = a.populateEndpointPorts(ctx, endpoints); err != nil {
+			return nil, fmt.Errorf("network %s: %w", networkID, err)
+		}
+		tenants[tenant] = as3.GetEndpointTenants(endpoints)
+	}
+
+	return tenants, nil
+}
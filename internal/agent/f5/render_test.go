@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/agent/f5/as3"
+)
+
+func TestTenantSelector(t *testing.T) {
+	assert.True(t, tenantSelector{}.includes("Common"))
+	assert.True(t, tenantSelector{}.includes("net-123"))
+	assert.True(t, tenantSelector{tenant: "net-123"}.includes("net-123"))
+	assert.False(t, tenantSelector{tenant: "net-123"}.includes("Common"))
+}
+
+func TestValidateDeclaration(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "as3-schema.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{
+		"type": "object",
+		"required": ["class", "declaration"],
+		"properties": {
+			"class": {"enum": ["AS3"]},
+			"declaration": {"type": "object", "required": ["class", "schemaVersion"]}
+		}
+	}`), 0o600))
+
+	data := as3.GetAS3Declaration(map[string]as3.Tenant{"net-123": driftTestTenant(80)})
+	assert.NoError(t, ValidateDeclaration(&data, schemaPath))
+
+	data.Class = "ADC"
+	assert.Error(t, ValidateDeclaration(&data, schemaPath))
+
+	assert.Error(t, ValidateDeclaration(&data, filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestValidateDeclarationDraft07(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "as3-schema.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id": "https://example.com/as3-schema.json",
+		"type": "object",
+		"properties": {
+			"class": {"const": "AS3"},
+			"declaration": {"$ref": "#/definitions/declaration"}
+		},
+		"definitions": {
+			"declaration": {
+				"type": "object",
+				"additionalProperties": {
+					"if": {"type": "object", "properties": {"class": {"const": "Tenant"}}},
+					"then": {"required": ["Shared"]}
+				}
+			}
+		}
+	}`), 0o600))
+
+	data := as3.GetAS3Declaration(map[string]as3.Tenant{"net-123": driftTestTenant(80)})
+	assert.NoError(t, ValidateDeclaration(&data, schemaPath))
+
+	// const
+	data.Class = "ADC"
+	assert.Error(t, ValidateDeclaration(&data, schemaPath))
+
+	// if/then: a tenant lacking the Shared application
+	tenant := driftTestTenant(80)
+	tenant.Applications = map[string]as3.Application{"app": tenant.Applications["Shared"]}
+	data = as3.GetAS3Declaration(map[string]as3.Tenant{"net-123": tenant})
+	assert.Error(t, ValidateDeclaration(&data, schemaPath))
+}
+
+func TestDraft4Schema(t *testing.T) {
+	assert.Equal(t, map[string]any{}, draft4Schema(true))
+	assert.Equal(t, map[string]any{"not": map[string]any{}}, draft4Schema(false))
+	assert.Equal(t, map[string]any{
+		"type":  "integer",
+		"allOf": []any{map[string]any{"minimum": float64(0), "exclusiveMinimum": true}},
+	}, draft4Schema(map[string]any{"type": "integer", "exclusiveMinimum": float64(0)}))
+	assert.Equal(t, map[string]any{
+		"dependencies": map[string]any{"a": []any{"b"}, "c": map[string]any{}},
+	}, draft4Schema(map[string]any{"dependencies": map[string]any{"a": []any{"b"}, "c": true}}))
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package f5
+
+// draft4Schema rewrites a draft-07 JSON schema, as published with the AS3
+// releases, into its draft-4 equivalent. go-openapi only implements draft-4 and
+// silently ignores newer keywords, which would let invalid declarations pass:
+//   - if/then/else becomes anyOf [allOf [if, then], allOf [not if, else]]
+//   - const becomes a single-valued enum
+//   - numeric exclusiveMinimum/exclusiveMaximum become minimum/maximum with the boolean flag
+//   - contains becomes "not all items fail"
+//   - boolean schemas become {} and {"not": {}}
+//
+// $id, $schema and $comment are dropped, $ref is kept as is. propertyNames has
+// no draft-4 equivalent and is not checked.
+func draft4Schema(schema any) any {
+	switch s := schema.(type) {
+	case bool:
+		if s {
+			return map[string]any{}
+		}
+		return map[string]any{"not": map[string]any{}}
+	case map[string]any:
+		return draft4Object(s)
+	default:
+		return schema
+	}
+}
+
+func draft4Object(schema map[string]any) map[string]any {
+	out := make(map[string]any, len(schema))
+	var allOf []any
+	for key, value := range schema {
+		switch key {
+		case "$id", "$schema", "$comment", "propertyNames", "if", "then", "else":
+			// dropped, or handled below
+		case "properties", "patternProperties", "definitions":
+			out[key] = draft4Map(value, false)
+		case "dependencies":
+			out[key] = draft4Map(value, true)
+		case "allOf", "anyOf", "oneOf":
+			out[key] = draft4Slice(value)
+		case "items":
+			if _, ok := value.([]any); ok {
+				out[key] = draft4Slice(value)
+			} else {
+				out[key] = draft4Schema(value)
+			}
+		case "additionalItems", "additionalProperties", "not":
+			out[key] = draft4Schema(value)
+		case "const":
+			allOf = append(allOf, map[string]any{"enum": []any{value}})
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if limit, ok := value.(float64); ok {
+				bound := "minimum"
+				if key == "exclusiveMaximum" {
+					bound = "maximum"
+				}
+				allOf = append(allOf, map[string]any{bound: limit, key: true})
+			} else {
+				out[key] = value
+			}
+		case "contains":
+			allOf = append(allOf, map[string]any{"anyOf": []any{
+				map[string]any{"not": map[string]any{"type": "array"}},
+				map[string]any{"not": map[string]any{"items": map[string]any{"not": draft4Schema(value)}}},
+			}})
+		default:
+			out[key] = value
+		}
+	}
+
+	if condition, ok := schema["if"]; ok {
+		ifSchema := draft4Schema(condition)
+		thenSchema, elseSchema := any(map[string]any{}), any(map[string]any{})
+		if value, ok := schema["then"]; ok {
+			thenSchema = draft4Schema(value)
+		}
+		if value, ok := schema["else"]; ok {
+			elseSchema = draft4Schema(value)
+		}
+		allOf = append(allOf, map[string]any{"anyOf": []any{
+			map[string]any{"allOf": []any{ifSchema, thenSchema}},
+			map[string]any{"allOf": []any{map[string]any{"not": ifSchema}, elseSchema}},
+		}})
+	}
+
+	if len(allOf) > 0 {
+		if existing, ok := out["allOf"].([]any); ok {
+			allOf = append(existing, allOf...)
+		}
+		out["allOf"] = allOf
+	}
+	return out
+}
+
+// draft4Map converts the schemas of a keyword mapping names to schemas. With
+// dependencies, property lists are kept as they are.
+func draft4Map(value any, dependencies bool) any {
+	schemas, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	out := make(map[string]any, len(schemas))
+	for name, schema := range schemas {
+		if _, isList := schema.([]any); dependencies && isList {
+			out[name] = schema
+			continue
+		}
+		out[name] = draft4Schema(schema)
+	}
+	return out
+}
+
+func draft4Slice(value any) any {
+	schemas, ok := value.([]any)
+	if !ok {
+		return value
+	}
+	out := make([]any, 0, len(schemas))
+	for _, schema := range schemas {
+		out = append(out, draft4Schema(schema))
+	}
+	return out
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/sapcc/archer/v2/models"
 )
 
-func (a *Agent) getExtendedService(ctx context.Context, s *models.Service) (*as3.ExtendedService, error) {
+// getExtendedService resolves the Neutron details of a service. With dryRun, SNAT
+// ports are looked up but never created, rebound or deleted.
+func (a *Agent) getExtendedService(ctx context.Context, s *models.Service, dryRun bool) (*as3.ExtendedService, error) {
 	// Fetch SNAT ports from neutron
 	service := &as3.ExtendedService{Service: *s}
 	pendingDelete := service.Status == models.ServiceStatusPENDINGDELETE
@@ -80,7 +82,7 @@ func (a *Agent) getExtendedService(ctx context.Context, s *models.Service) (*as3
 		}
 
 		service.NeutronPorts, err = a.neutron.EnsureServiceSnatPorts(ctx,
-			service.ID, subnetID, int(*service.SnatPoolSize), pendingDelete)
+			service.ID, subnetID, int(*service.SnatPoolSize), pendingDelete || dryRun)
 		if err == nil {
 			service.SubnetID = subnetID
 			break
@@ -105,9 +107,9 @@ func (a *Agent) getExtendedService(ctx context.Context, s *models.Service) (*as3
 	// Fetch segmentID for the network
 	if len(service.NeutronPorts) > 0 {
 		// we only expect a valid segment if we have at least one Service port bound
-		if service.SegmentId, err = a.neutron.GetNetworkSegment(ctx, service.NetworkID.String(),
-			config.Global.Agent.PhysicalNetwork); err != nil {
-			return nil, fmt.Errorf("GetNetworkSegment: %w", err)
+		if service.SegmentId, service.Physnet, err = a.neutron.FindNetworkSegment(ctx, service.NetworkID.String(),
+			config.Global.Agent.PhysicalNetworks()); err != nil {
+			return nil, fmt.Errorf("FindNetworkSegment: %w", err)
 		}
 	}
 
@@ -151,7 +153,7 @@ func (a *Agent) ProcessServices(ctx context.Context) error {
 	// pruning migrated-away services or persisting the others.
 	var deferredErr error
 	for _, service := range dbServices {
-		if extendedService, err := a.getExtendedService(ctx, service); err != nil {
+		if extendedService, err := a.getExtendedService(ctx, service, false); err != nil {
 			l := log.WithFields(log.Fields{"service": service.ID, "network": service.NetworkID})
 			if errors.Is(err, internal.ErrQuotaExceeded) {
 				service.Status = models.ServiceStatusERRORQUOTA
@@ -181,7 +183,7 @@ func (a *Agent) ProcessServices(ctx context.Context) error {
 	   ================================================== */
 	for _, service := range services {
 		if service.Status != models.ServiceStatusPENDINGDELETE {
-			if err := a.EnsureL2(ctx, service.SegmentId, nil, service.MTU); err != nil {
+			if err := a.EnsureL2(ctx, service.SegmentId, service.Physnet, nil, service.MTU); err != nil {
 				return err
 			}
 			// SelfIP on service subnet: BIG-IP L3 presence for SNAT pool and pool members in the service route domain.
@@ -200,7 +202,7 @@ func (a *Agent) ProcessServices(ctx context.Context) error {
 	data := as3.GetAS3Declaration(map[string]as3.Tenant{
 		"Common": as3.GetServiceTenants(services),
 	})
-	if err = a.active.PostAS3(&data, "Common"); err != nil {
+	if err = a.postAS3(&data, "Common"); err != nil {
 		return err
 	}
 
@@ -242,7 +244,7 @@ func (a *Agent) ProcessServices(ctx context.Context) error {
 			// segment
 			var segmentID int
 			if cleanupL2 {
-				segmentID, err = a.neutron.GetSubnetSegment(ctx, service.SubnetID, config.Global.Agent.PhysicalNetwork)
+				segmentID, err = a.neutron.GetSubnetSegment(ctx, service.SubnetID, config.Global.Agent.PhysicalNetworks()...)
 				if errors.Is(err, internal.ErrNoPhysNetFound) {
 					// No segment found, skip L2 cleanup
 					cleanupL2 = false
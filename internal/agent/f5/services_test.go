@@ -298,7 +298,7 @@ func TestGetExtendedServiceAllocatesSnatPortsByDefault(t *testing.T) {
 		SnatPoolSize: conv.Pointer(int32(1)),
 	}
 
-	got, err := a.getExtendedService(context.Background(), svc)
+	got, err := a.getExtendedService(context.Background(), svc, false)
 	if err != nil {
 		t.Fatalf("getExtendedService() error = %v", err)
 	}
@@ -317,4 +317,16 @@ func TestGetExtendedServiceAllocatesSnatPortsByDefault(t *testing.T) {
 	if createCalls != 1 {
 		t.Errorf("expected one Neutron port to be created, got %d", createCalls)
 	}
+
+	// drift detection and render resolve services without touching Neutron ports
+	got, err = a.getExtendedService(context.Background(), svc, true)
+	if err != nil {
+		t.Fatalf("getExtendedService(dryRun) error = %v", err)
+	}
+	if len(got.NeutronPorts) != 0 {
+		t.Errorf("expected no SNAT port in dry run, got %d", len(got.NeutronPorts))
+	}
+	if createCalls != 1 {
+		t.Errorf("expected no Neutron port to be created in dry run, got %d", createCalls-1)
+	}
 }
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/internal/db"
+	"github.com/sapcc/archer/v2/models"
+)
+
+// HealthTracker collects the health details an agent reports with every
+// heartbeat. It is safe for concurrent use, the zero value is ready to use.
+type HealthTracker struct {
+	mu                  sync.Mutex
+	lastProcessServices time.Time
+	lastAS3Post         time.Time
+	lastAS3PostTenant   string
+	lastAS3PostError    string
+	devices             map[string]bool
+	activeDevice        string
+	lastFailover        time.Time
+}
+
+// ProcessServicesSucceeded records a successful ProcessServices run.
+func (h *HealthTracker) ProcessServicesSucceeded() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastProcessServices = time.Now()
+}
+
+// AS3Posted records the outcome of an AS3 declaration post for tenant.
+func (h *HealthTracker) AS3Posted(tenant string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastAS3Post = time.Now()
+	h.lastAS3PostTenant = tenant
+	h.lastAS3PostError = ""
+	if err != nil {
+		h.lastAS3PostError = err.Error()
+	}
+}
+
+// DeviceReachable records whether the device with the given hostname responded.
+func (h *HealthTracker) DeviceReachable(host string, reachable bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.devices == nil {
+		h.devices = make(map[string]bool)
+	}
+	h.devices[host] = reachable
+}
+
+// ActiveDevice records the hostname of the device the agent posts to, a change
+// from a previously recorded device counts as failover.
+func (h *HealthTracker) ActiveDevice(host string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.activeDevice != "" && h.activeDevice != host {
+		h.lastFailover = time.Now()
+	}
+	h.activeDevice = host
+}
+
+// Snapshot returns the collected health details along with queueDepth.
+func (h *HealthTracker) Snapshot(queueDepth int64) *models.AgentHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	health := &models.AgentHealth{
+		QueueDepth:        queueDepth,
+		LastAs3PostTenant: h.lastAS3PostTenant,
+		LastAs3PostError:  h.lastAS3PostError,
+		Devices:           maps.Clone(h.devices),
+		ActiveDevice:      h.activeDevice,
+	}
+	if !h.lastProcessServices.IsZero() {
+		health.LastProcessServicesAt = new(strfmt.DateTime(h.lastProcessServices.UTC()))
+	}
+	if !h.lastAS3Post.IsZero() {
+		health.LastAs3PostAt = new(strfmt.DateTime(h.lastAS3Post.UTC()))
+	}
+	if !h.lastFailover.IsZero() {
+		health.LastFailoverAt = new(strfmt.DateTime(h.lastFailover.UTC()))
+	}
+	return health
+}
+
+// PendingQueueDepth counts the services and endpoints of this agent's host that
+// wait for processing.
+func PendingQueueDepth(ctx context.Context, pool db.PgxIface, provider string) (int64, error) {
+	host := config.Global.Default.Host
+	services := db.Select("COUNT(*)").
+		From("service").
+		Where("provider = ?", provider).
+		Where("host = ?", host).
+		Where(sq.Eq{"status": []models.ServiceStatus{
+			models.ServiceStatusPENDINGCREATE,
+			models.ServiceStatusPENDINGUPDATE,
+			models.ServiceStatusPENDINGDELETE,
+		}})
+	endpoints := sq.Select("COUNT(*)").
+		From("endpoint").
+		Join("service ON service.id = endpoint.service_id").
+		Where("service.provider = ?", provider).
+		Where("service.host = ?", host).
+		Where(sq.Eq{"endpoint.status": []models.EndpointStatus{
+			models.EndpointStatusPENDINGCREATE,
+			models.EndpointStatusPENDINGUPDATE,
+			models.EndpointStatusPENDINGDELETE,
+			models.EndpointStatusPENDINGREJECTED,
+		}})
+	sql, args := services.Column(sq.Alias(endpoints, "endpoints")).MustSql()
+
+	var pendingServices, pendingEndpoints int64
+	if err := pool.QueryRow(ctx, sql, args...).Scan(&pendingServices, &pendingEndpoints); err != nil {
+		return 0, err
+	}
+	return pendingServices + pendingEndpoints, nil
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/models"
+)
+
+func TestHealthTracker(t *testing.T) {
+	var h HealthTracker
+
+	health := h.Snapshot(0)
+	assert.Nil(t, health.LastProcessServicesAt)
+	assert.Nil(t, health.LastAs3PostAt)
+	assert.Empty(t, health.Devices)
+
+	h.ProcessServicesSucceeded()
+	h.AS3Posted("Common", errors.New("422 Unprocessable Entity"))
+	h.DeviceReachable("bigip-1", true)
+	h.DeviceReachable("bigip-2", false)
+
+	health = h.Snapshot(3)
+	assert.NotNil(t, health.LastProcessServicesAt)
+	assert.NotNil(t, health.LastAs3PostAt)
+	assert.Equal(t, "Common", health.LastAs3PostTenant)
+	assert.Equal(t, "422 Unprocessable Entity", health.LastAs3PostError)
+	assert.Equal(t, map[string]bool{"bigip-1": true, "bigip-2": false}, health.Devices)
+	assert.EqualValues(t, 3, health.QueueDepth)
+
+	// a successful post clears the previous error
+	h.AS3Posted("net-123", nil)
+	health = h.Snapshot(0)
+	assert.Equal(t, "net-123", health.LastAs3PostTenant)
+	assert.Empty(t, health.LastAs3PostError)
+
+	// the first active device is no failover
+	h.ActiveDevice("bigip-1")
+	health = h.Snapshot(0)
+	assert.Equal(t, "bigip-1", health.ActiveDevice)
+	assert.Nil(t, health.LastFailoverAt)
+
+	h.ActiveDevice("bigip-2")
+	health = h.Snapshot(0)
+	assert.Equal(t, "bigip-2", health.ActiveDevice)
+	assert.NotNil(t, health.LastFailoverAt)
+}
+
+func TestPendingQueueDepth(t *testing.T) {
+	config.Global.Default.Host = "test-host"
+	dbMock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer dbMock.Close()
+
+	dbMock.ExpectQuery("SELECT COUNT(*), (SELECT COUNT(*) FROM endpoint "+
+		"JOIN service ON service.id = endpoint.service_id "+
+		"WHERE service.provider = $1 AND service.host = $2 AND endpoint.status IN ($3,$4,$5,$6)) AS endpoints "+
+		"FROM service WHERE provider = $7 AND host = $8 AND status IN ($9,$10,$11)").
+		WithArgs("tenant", "test-host",
+			models.EndpointStatusPENDINGCREATE, models.EndpointStatusPENDINGUPDATE,
+			models.EndpointStatusPENDINGDELETE, models.EndpointStatusPENDINGREJECTED,
+			"tenant", "test-host",
+			models.ServiceStatusPENDINGCREATE, models.ServiceStatusPENDINGUPDATE, models.ServiceStatusPENDINGDELETE).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "endpoints"}).AddRow(int64(2), int64(5)))
+
+	depth, err := PendingQueueDepth(context.Background(), dbMock, "tenant")
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, depth)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
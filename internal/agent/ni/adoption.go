@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/netlink"
+	"github.com/sapcc/archer/v2/internal/agent/ni/proxy"
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+// adoptInjections takes over the HAProxy instances left running by a previous agent and
+// reconciles them with the endpoints in the database; anything that no longer belongs is
+// killed. The following full sync starts whatever is missing and reloads changed configs.
+func (a *Agent) adoptInjections(ctx context.Context) error {
+	// socat listeners cannot be supervised after a restart, the sync starts new ones
+	if killed := proxy.KillOrphans(); killed > 0 {
+		log.Infof("Killed %d orphaned proxies", killed)
+	}
+
+	entries, err := os.ReadDir(config.Global.Agent.RunDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strfmt.IsUUID(entry.Name()) {
+			continue
+		}
+
+		networkID := strfmt.UUID(entry.Name())
+		injections, err := networkInjections(ctx, a.pool, networkID)
+		if err != nil {
+			return err
+		}
+
+		if err = a.haproxy.Adopt(networkID.String()); err != nil {
+			log.WithError(err).Debugf("No HAProxy to adopt for network %s", networkID)
+		} else if len(injections) == 0 {
+			log.Infof("Removing HAProxy of network %s without endpoints", networkID)
+			if err = a.haproxy.RemoveInstance(networkID.String()); err != nil {
+				log.WithError(err).Warnf("Failed to remove HAProxy of network %s", networkID)
+			}
+		}
+
+		if len(injections) == 0 {
+			if err = os.RemoveAll(proxy.GetNetworkDir(networkID.String())); err != nil {
+				log.WithError(err).Warnf("Failed to remove run directory of network %s", networkID)
+			}
+		}
+	}
+
+	// HAProxy processes in injection namespaces that were not adopted, e.g. without pidfile
+	networks, err := netlink.ListNetworkNamespaces()
+	if err != nil {
+		return err
+	}
+	for _, networkID := range networks {
+		pids, err := netlink.NetworkNamespacePids(networkID)
+		if err != nil {
+			log.WithError(err).Warnf("Failed to list processes of network %s", networkID)
+			continue
+		}
+		if killed := a.haproxy.KillStrays(networkID, pids); killed > 0 {
+			log.Infof("Killed %d stray HAProxy processes of network %s", killed, networkID)
+		}
+	}
+	return nil
+}
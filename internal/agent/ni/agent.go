@@ -9,6 +9,8 @@ import (
 	"errors"
 	"os"
 	"os/signal"
+	"slices"
+	"sync"
 	"syscall"
 	"time"
 
@@ -39,12 +41,19 @@ type Agent struct {
 	neutron      *neutron.NeutronClient
 	haproxy      haproxy.HAProxy
 	proxyManager *proxy.Manager // manages Unix proxy threads per service
+	health       common.HealthTracker
+	injectionMu  sync.Mutex // serializes changes to the namespaces, proxies and HAProxy instances
 }
 
 func (a *Agent) GetScheduler() gocron.Scheduler {
 	return a.scheduler
 }
 
+// HealthTracker exposes the health details reported with every heartbeat.
+func (a *Agent) HealthTracker() *common.HealthTracker {
+	return &a.health
+}
+
 func (a *Agent) GetPool() db.PgxIface {
 	return a.pool
 }
@@ -94,7 +103,7 @@ func NewAgent() *Agent {
 		log.Fatalf("Failed to chmod run directory %s: %v", config.Global.Agent.RunDir, err)
 	}
 
-	common.RegisterAgent(agent.pool, "cp")
+	common.RegisterAgent(agent.pool, "cp", agent.status())
 
 	// Update services without IP addresses using deprecated config (migration path)
 	if err := agent.migrateServiceIPAddresses(context.Background()); err != nil {
@@ -117,6 +126,11 @@ func (a *Agent) Run() {
 	go common.DBNotificationThread(ctx, a)
 	go a.haproxy.Run(ctx)
 
+	// take over HAProxy instances of a previous agent before syncing
+	if err := a.adoptInjections(ctx); err != nil {
+		log.WithError(err).Warn("Failed to adopt running injections")
+	}
+
 	// sync immediately
 	if err := a.PendingSyncLoop(context.Background(), true); err != nil {
 		log.Fatal(err)
@@ -131,6 +145,28 @@ func (a *Agent) Run() {
 		log.Fatal(err)
 	}
 
+	// garbage collection of namespaces and veths without endpoints
+	if config.Global.Agent.NamespaceGCInterval > 0 {
+		if _, err := a.scheduler.NewJob(
+			gocron.DurationJob(config.Global.Agent.NamespaceGCInterval),
+			gocron.NewTask(a.NamespaceGCLoop),
+			gocron.WithName("NamespaceGCLoop"),
+		); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// subnet gateways and host routes changed in Neutron
+	if config.Global.Agent.RouteSyncInterval > 0 {
+		if _, err := a.scheduler.NewJob(
+			gocron.DurationJob(config.Global.Agent.RouteSyncInterval),
+			gocron.NewTask(a.RouteSyncLoop),
+			gocron.WithName("RouteSyncLoop"),
+		); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// collect metrics
 	if _, err := a.scheduler.NewJob(
 		gocron.DurationJob(1*time.Minute),
@@ -162,9 +198,21 @@ func (a *Agent) Run() {
 	time.Sleep(5 * time.Second)
 }
 
-// UpdateHeartbeat updates the agent's heartbeat in the database.
+// UpdateHeartbeat updates the agent's heartbeat and health details in the database.
 func (a *Agent) UpdateHeartbeat() {
-	common.UpdateHeartbeat(a.pool)
+	queueDepth, err := common.PendingQueueDepth(context.Background(), a.pool, models.ServiceProviderCp)
+	if err != nil {
+		log.WithError(err).Warning("Failed to count pending services and endpoints")
+	}
+
+	status := a.status()
+	status.Health = a.health.Snapshot(queueDepth)
+	common.UpdateHeartbeat(a.pool, status)
+}
+
+// status reports the features this agent supports; it drives no device.
+func (a *Agent) status() common.Status {
+	return common.Status{}
 }
 
 // migrateServiceIPAddresses updates services assigned to this host that have empty IP addresses
@@ -227,6 +275,11 @@ func (a *Agent) ProcessServices(ctx context.Context) error {
 
 	// Update services to AVAILABLE status
 	if len(toUpdate) > 0 {
+		// Apply service changes (ports, protocol, IP addresses...) to the injected endpoints
+		if err := a.syncServiceNetworks(ctx, toUpdate); err != nil {
+			return err
+		}
+
 		// Guard so a concurrent delete (PENDING_DELETE) is not clobbered to AVAILABLE.
 		sql, args = db.Update("service").
 			Set("status", models.ServiceStatusAVAILABLE).
@@ -263,12 +316,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 		var si ni.ServiceInjection
 		var err error
 
-		sql, args := db.Select("e.id", "e.status", "ep.port_id", "ep.network", "host(ep.ip_address) AS ip_address",
-			"s.id AS service_id", "s.protocol AS service_protocol", "s.ports AS service_ports",
-			"host(s.ip_addresses[1]) AS service_ip_address", "s.proxy_protocol").
-			From("endpoint e").
-			Join("endpoint_port ep ON ep.endpoint_id = e.id").
-			Join("service s ON s.id = service_id").
+		sql, args := selectServiceInjections().
 			Where("e.id = ?", id).
 			Suffix("FOR UPDATE OF e").
 			MustSql()
@@ -280,10 +328,15 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 			return err
 		}
 
+		injections, err := networkInjections(ctx, tx, si.Network)
+		if err != nil {
+			return err
+		}
+
 		switch si.Status {
 		case models.EndpointStatusPENDINGREJECTED:
 			log.Infof("ProcessEndpoint: Rejecting endpoint %s", si.ID)
-			if err = a.DisableInjection(&si); err != nil {
+			if err = a.DisableInjection(&si, injections); err != nil {
 				return err
 			}
 			sql, args = db.UpdateStatusGuarded("endpoint", si.ID,
@@ -292,7 +345,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 				return err
 			}
 		case models.EndpointStatusPENDINGDELETE:
-			if err = a.DisableInjection(&si); err != nil {
+			if err = a.DisableInjection(&si, injections); err != nil {
 				return err
 			}
 			sql, args = db.DeleteIfStatus("endpoint", si.ID, models.EndpointStatusPENDINGDELETE)
@@ -300,7 +353,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 				return err
 			}
 		case models.EndpointStatusAVAILABLE:
-			if err = a.EnableInjection(ctx, &si); err != nil {
+			if err = a.EnableInjection(ctx, &si, injections); err != nil {
 				return err
 			}
 		case models.EndpointStatusPENDINGUPDATE:
@@ -314,7 +367,7 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 		case models.EndpointStatusFAILED:
 			fallthrough
 		case models.EndpointStatusPENDINGCREATE:
-			if err = a.EnableInjection(ctx, &si); err != nil {
+			if err = a.EnableInjection(ctx, &si, withInjection(injections, &si)); err != nil {
 				return err
 			}
 			sql, args = db.UpdateStatusGuarded("endpoint", si.ID,
@@ -328,6 +381,81 @@ func (a *Agent) ProcessEndpoint(ctx context.Context, id strfmt.UUID) error {
 	})
 }
 
+// syncServiceNetworks re-applies the injections of all networks with endpoints of the services.
+// A failing network is logged and skipped, it must not hold back the others.
+func (a *Agent) syncServiceNetworks(ctx context.Context, serviceIDs []strfmt.UUID) error {
+	sql, args := db.Select("DISTINCT ep.network").
+		From("endpoint e").
+		Join("endpoint_port ep ON ep.endpoint_id = e.id").
+		Where(sq.Eq{"e.service_id": serviceIDs}).
+		Where(sq.Eq{"e.status": injectedEndpointStatus}).
+		MustSql()
+
+	var networks []strfmt.UUID
+	if err := pgxscan.Select(ctx, a.pool, &networks, sql, args...); err != nil {
+		return err
+	}
+
+	for _, networkID := range networks {
+		injections, err := networkInjections(ctx, a.pool, networkID)
+		if err != nil {
+			log.WithError(err).Errorf("Failed fetching injections of network %s", networkID)
+			continue
+		}
+		log.Infof("Updating injections of network %s", networkID)
+		if err = a.UpdateInjections(networkID, injections); err != nil {
+			log.WithError(err).Errorf("Failed updating injections of network %s", networkID)
+		}
+	}
+	return nil
+}
+
+// injectedEndpointStatus are the states of approved endpoints injected into their network.
+var injectedEndpointStatus = []models.EndpointStatus{
+	models.EndpointStatusAVAILABLE,
+	models.EndpointStatusPENDINGUPDATE,
+}
+
+// withInjection returns the injections of the network including the endpoint being enabled.
+func withInjection(injections []*ni.ServiceInjection, si *ni.ServiceInjection) []*ni.ServiceInjection {
+	if slices.ContainsFunc(injections, func(i *ni.ServiceInjection) bool { return i.ID == si.ID }) {
+		return injections
+	}
+	return append(injections, si)
+}
+
+// networkInjections returns the injections of all endpoints in a network served by this host,
+// the HAProxy config of the network is generated from them.
+func networkInjections(ctx context.Context, q pgxscan.Querier, networkID strfmt.UUID) ([]*ni.ServiceInjection, error) {
+	sql, args := selectServiceInjections().
+		Where("ep.network = ?", networkID).
+		Where("s.host = ?", config.Global.Default.Host).
+		Where("s.provider = 'cp'").
+		Where(sq.Eq{"e.status": injectedEndpointStatus}).
+		OrderBy("e.created_at", "e.id").
+		MustSql()
+
+	var injections []*ni.ServiceInjection
+	if err := pgxscan.Select(ctx, q, &injections, sql, args...); err != nil {
+		return nil, err
+	}
+	return injections, nil
+}
+
+// selectServiceInjections selects the injection data of endpoints together with their service.
+func selectServiceInjections() sq.SelectBuilder {
+	return db.Select("e.id", "e.status", "ep.port_id", "ep.network", "host(ep.ip_address) AS ip_address",
+		"s.id AS service_id", "s.protocol AS service_protocol",
+		"s.ports AS service_ports", "e.ports", "e.port_mappings",
+		"s.ip_addresses AS service_ip_addresses", "s.members AS service_members", "s.traffic AS service_traffic",
+		"s.proxy_protocol",
+		"COALESCE(e.connection_limit, s.connection_limit) AS max_conn",
+		"COALESCE(e.rate_limit, s.rate_limit) AS max_session_rate", "e.allowed_cidrs").
+		From("endpoint e").
+		Join("endpoint_port ep ON ep.endpoint_id = e.id").
+		Join("service s ON s.id = service_id")
+}
+
 func (a *Agent) PendingSyncLoop(ctx context.Context, syncAll bool) error {
 	log.Debugf("PendingSyncLoop(syncAll=%t)", syncAll)
 
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/netlink"
+	"github.com/sapcc/archer/v2/internal/agent/ni/proxy"
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/internal/db"
+)
+
+var (
+	orphans = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "archer_ni_orphans",
+		Help: "Number of injection namespaces and tap veths without endpoint port found by the last garbage collection",
+	}, []string{"type"})
+	orphansDeleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "archer_ni_orphans_deleted",
+		Help: "Counter of injection namespaces and tap veths deleted by the garbage collection",
+	}, []string{"type", "outcome"})
+)
+
+// endpointPort is a port plugged into an injection namespace.
+type endpointPort struct {
+	Network strfmt.UUID
+	PortID  strfmt.UUID
+}
+
+// NamespaceGCLoop reports the injection namespaces of networks and the tap veths of ports that
+// no longer have an endpoint port of a service on this host, and removes them if enabled.
+// DisableInjection leaves both in place so re-enabling an endpoint is cheap; this job reclaims
+// them eventually.
+func (a *Agent) NamespaceGCLoop(ctx context.Context) error {
+	// Namespaces are only created for committed endpoint ports, holding the lock while
+	// reading them ensures nothing plugged concurrently is mistaken for an orphan.
+	a.injectionMu.Lock()
+	defer a.injectionMu.Unlock()
+
+	sql, args := db.Select("ep.network", "ep.port_id").
+		From("endpoint_port ep").
+		Join("endpoint e ON e.id = ep.endpoint_id").
+		Join("service s ON s.id = e.service_id").
+		Where("s.host = ?", config.Global.Default.Host).
+		Where("s.provider = 'cp'").
+		MustSql()
+
+	var ports []endpointPort
+	if err := pgxscan.Select(ctx, a.pool, &ports, sql, args...); err != nil {
+		return err
+	}
+
+	namespaces, err := netlink.ListNetworkNamespaces()
+	if err != nil {
+		return err
+	}
+	orphanNamespaces := orphanNetworks(ports, namespaces)
+	orphans.WithLabelValues("namespace").Set(float64(len(orphanNamespaces)))
+	for _, networkID := range orphanNamespaces {
+		if !config.Global.Agent.NamespaceGCDelete {
+			log.Infof("NamespaceGCLoop: would delete namespace of network %s without endpoint ports", networkID)
+			continue
+		}
+		log.Infof("NamespaceGCLoop: deleting namespace of network %s without endpoint ports", networkID)
+		if err = a.deleteNetwork(networkID); err != nil {
+			log.WithError(err).Errorf("NamespaceGCLoop: failed deleting namespace of network %s", networkID)
+			orphansDeleted.WithLabelValues("namespace", "error").Inc()
+			continue
+		}
+		orphansDeleted.WithLabelValues("namespace", "success").Inc()
+	}
+
+	// ports of deleted endpoints in namespaces still in use, deleting a namespace removes its veths
+	var orphanTaps int
+	for _, networkID := range namespaces {
+		if slices.Contains(orphanNamespaces, networkID) {
+			continue
+		}
+		taps, err := netlink.ListTapPorts(networkID)
+		if err != nil {
+			log.WithError(err).Warnf("NamespaceGCLoop: failed listing veths of network %s", networkID)
+			continue
+		}
+		for _, prefix := range orphanPorts(ports, taps) {
+			orphanTaps++
+			if !config.Global.Agent.NamespaceGCDelete {
+				log.Infof("NamespaceGCLoop: would delete veth tap%s of network %s without endpoint port", prefix, networkID)
+				continue
+			}
+			log.Infof("NamespaceGCLoop: deleting veth tap%s of network %s without endpoint port", prefix, networkID)
+			if err = netlink.DeleteTapPort(prefix); err != nil {
+				log.WithError(err).Errorf("NamespaceGCLoop: failed deleting veth tap%s", prefix)
+				orphansDeleted.WithLabelValues("veth", "error").Inc()
+				continue
+			}
+			orphansDeleted.WithLabelValues("veth", "success").Inc()
+		}
+	}
+	orphans.WithLabelValues("veth").Set(float64(orphanTaps))
+	return nil
+}
+
+// deleteNetwork stops what is left running for a network and deletes its injection namespace.
+func (a *Agent) deleteNetwork(networkID string) error {
+	if a.haproxy.IsRunning(networkID) {
+		if err := a.haproxy.RemoveInstance(networkID); err != nil {
+			return err
+		}
+	}
+	for _, serviceID := range a.proxyManager.Services(strfmt.UUID(networkID)) {
+		a.proxyManager.StopProxy(strfmt.UUID(networkID), serviceID)
+	}
+	if err := os.RemoveAll(proxy.GetNetworkDir(networkID)); err != nil {
+		log.WithError(err).Warnf("Failed to remove run directory of network %s", networkID)
+	}
+
+	ns := netlink.NewNetworkNamespace()
+	defer func() { _ = ns.Close() }()
+	if err := ns.OpenNetworkNamespace(networkID); err != nil {
+		return err
+	}
+	return ns.DeleteNetworkNamespace()
+}
+
+// orphanNetworks returns the namespace network IDs without any of the endpoint ports.
+func orphanNetworks(ports []endpointPort, networks []string) []string {
+	used := make(map[string]struct{}, len(ports))
+	for _, port := range ports {
+		used[port.Network.String()] = struct{}{}
+	}
+
+	var orphaned []string
+	for _, networkID := range networks {
+		if _, ok := used[networkID]; !ok {
+			orphaned = append(orphaned, networkID)
+		}
+	}
+	return orphaned
+}
+
+// orphanPorts returns the tap port ID prefixes not matching any of the endpoint ports.
+func orphanPorts(ports []endpointPort, prefixes []string) []string {
+	used := make(map[string]struct{}, len(ports))
+	for _, port := range ports {
+		if id := port.PortID.String(); len(id) >= 11 {
+			used[id[:11]] = struct{}{}
+		}
+	}
+
+	var orphaned []string
+	for _, prefix := range prefixes {
+		if _, ok := used[prefix]; !ok {
+			orphaned = append(orphaned, prefix)
+		}
+	}
+	return orphaned
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphanNetworks(t *testing.T) {
+	ports := []endpointPort{
+		{Network: "11111111-1111-1111-1111-111111111111", PortID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"},
+		{Network: "11111111-1111-1111-1111-111111111111", PortID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"},
+	}
+
+	assert.Equal(t, []string{"22222222-2222-2222-2222-222222222222"}, orphanNetworks(ports, []string{
+		"11111111-1111-1111-1111-111111111111",
+		"22222222-2222-2222-2222-222222222222",
+	}))
+	assert.Equal(t, []string{"11111111-1111-1111-1111-111111111111"},
+		orphanNetworks(nil, []string{"11111111-1111-1111-1111-111111111111"}), "without ports every namespace is orphaned")
+	assert.Empty(t, orphanNetworks(ports, nil))
+}
+
+func TestOrphanPorts(t *testing.T) {
+	ports := []endpointPort{
+		{Network: "11111111-1111-1111-1111-111111111111", PortID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"},
+	}
+
+	// tap names carry the first 11 characters of the port ID
+	assert.Equal(t, []string{"cccccccc-cc"}, orphanPorts(ports, []string{"aaaaaaaa-aa", "cccccccc-cc"}))
+	assert.Empty(t, orphanPorts(ports, []string{"aaaaaaaa-aa"}))
+}
@@ -15,6 +15,8 @@ import (
 type FakeHaproxy struct {
 	Running                bool
 	AddInstanceReturnError error
+	AdoptReturnError       error
+	Injections             []*models.ServiceInjection // injections of the last AddInstance call
 }
 
 func NewFakeHaproxy() *FakeHaproxy {
@@ -30,8 +32,9 @@ func (h *FakeHaproxy) IsRunning(network string) bool {
 	return h.Running
 }
 
-func (h *FakeHaproxy) AddInstance(injection *models.ServiceInjection) error {
-	log.Debugf("adding instance %s", injection.Name)
+func (h *FakeHaproxy) AddInstance(networkID string, injections []*models.ServiceInjection) error {
+	log.Debugf("adding instance %s with %d endpoints", networkID, len(injections))
+	h.Injections = injections
 	return h.AddInstanceReturnError
 }
 
@@ -40,6 +43,16 @@ func (h *FakeHaproxy) RemoveInstance(networkID string) error {
 	return nil
 }
 
+func (h *FakeHaproxy) Adopt(networkID string) error {
+	log.Debugf("adopting instance %s", networkID)
+	return h.AdoptReturnError
+}
+
+func (h *FakeHaproxy) KillStrays(networkID string, pids []int) int {
+	log.Debugf("killing strays of instance %s among %v", networkID, pids)
+	return 0
+}
+
 func (h *FakeHaproxy) Run(ctx context.Context) {
 	log.Debug("running haproxy (fake)")
 	<-ctx.Done()
@@ -9,11 +9,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"text/template"
 
@@ -22,9 +25,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/internal/agent/ni/models"
+	"github.com/sapcc/archer/v2/internal/agent/ni/procfs"
 	"github.com/sapcc/archer/v2/internal/agent/ni/proxy"
 	"github.com/sapcc/archer/v2/internal/config"
+	archermodels "github.com/sapcc/archer/v2/models"
 )
 
 var configTemplate = `
@@ -32,58 +38,177 @@ global
     log         stdout format raw local0 {{.LogLevel}}
     stats       socket "{{getStatsSocketPath .Network}}" mode 600 level admin
     stats       timeout 2m
-    maxconn     1024
+    maxconn     {{.MaxConn}}
     pidfile     "{{getPidFilePath .Network}}"
     chroot      "{{.ChrootDir}}"
     user        {{.RunUser}}
     group       {{.RunGroup}}
+    master-worker
     daemon
 
 defaults
     log global
     option dontlognull
     retries                 3
+    option redispatch
     timeout connect         30s
     timeout client          32s
     timeout server          32s
     timeout tunnel          1h
 
-{{- $protocol := .Protocol }}
-{{- $upstream := .UpstreamHost }}
-{{- $proxyProtocol := .ProxyProtocol }}
-{{- $endpointID := .EndpointID }}
+{{- range .Endpoints }}
+{{- $endpoint := . }}
+{{- range .Listeners }}
 
-{{ range .UpstreamPorts }}
-frontend fronted_{{ . }}
-    bind :::{{ . }} v4v6
-    mode {{ lower $protocol }}
-{{- if eq $protocol "HTTP" }}
+frontend frontend_{{ $endpoint.ID }}_{{ .Port }}
+    bind {{ bindAddress $endpoint.IPAddress .Port }}
+    mode {{ lower $endpoint.Protocol }}
+{{- if $endpoint.MaxConn }}
+    maxconn {{ $endpoint.MaxConn }}
+{{- end }}
+{{- if $endpoint.MaxSessionRate }}
+    rate-limit sessions {{ $endpoint.MaxSessionRate }}
+{{- end }}
+{{- if $endpoint.AllowedSources }}
+    tcp-request connection reject if !{ src {{ $endpoint.AllowedSources }} }
+{{- end }}
+{{- if $endpoint.IdleTimeout }}
+    timeout client          {{ $endpoint.IdleTimeout }}s
+{{- end }}
+{{- if eq $endpoint.Protocol "HTTP" }}
     option httplog
     option forwardfor
 {{- end }}
-    default_backend backend_{{ . }}
+    default_backend backend_{{ $endpoint.ID }}_{{ .Port }}
 
-backend backend_{{ . }}
-    mode {{ lower $protocol }}
-{{- if eq $protocol "HTTP" }}
-    option http-server-close
+backend backend_{{ $endpoint.ID }}_{{ .Port }}
+    mode {{ lower $endpoint.Protocol }}
+{{- if $endpoint.AllBackups }}
+    option allbackups
+{{- end }}
+{{- if $endpoint.ConnectTimeout }}
+    timeout connect         {{ $endpoint.ConnectTimeout }}s
+{{- end }}
+{{- if $endpoint.IdleTimeout }}
+    timeout server          {{ $endpoint.IdleTimeout }}s
+{{- end }}
+{{- if $endpoint.TunnelTimeout }}
+    timeout tunnel          {{ $endpoint.TunnelTimeout }}s
+{{- end }}
+{{- if eq $endpoint.Protocol "HTTP" }}
+    option {{ keepAliveOption $endpoint.HTTPKeepAlive }}
     timeout http-request    30s
     timeout http-keep-alive 30s
-    http-request replace-header Host .* {{ formatHost $upstream }}
+    http-request replace-header Host .* {{ formatHost $endpoint.UpstreamHost }}
+{{- end }}
+{{- $servicePort := .ServicePort }}
+{{- range $endpoint.Upstreams }}
+    server upstream-{{ .Index }} {{ getChrootSocketPath $endpoint.ServiceID .Index $servicePort }} weight {{ .Weight }}{{- if $endpoint.ServerMaxConn }} maxconn {{ $endpoint.ServerMaxConn }}{{- end }}{{- if .Backup }} backup{{- end }}{{- if $.HealthCheckInterval }} track health_{{ $endpoint.ServiceID }}_{{ $servicePort }}/upstream-{{ .Index }}{{- end }}{{- if $endpoint.ProxyProtocol }} send-proxy-v2 set-proxy-v2-tlv-fmt(0xEC) %[str({{ $endpoint.ID }})]{{- end }}
+{{- end }}
+{{- end }}
 {{- end }}
-    server upstream {{ getChrootSocketPath . }}{{- if $proxyProtocol }} send-proxy-v2 set-proxy-v2-tlv-fmt(0xEC) %[str({{ $endpointID }})]{{- end }}
 
-{{ end }}
+{{- range .HealthChecks }}
+
+backend health_{{ .ServiceID }}_{{ .Port }}
+    mode tcp
+    option tcp-check
+    tcp-check expect string UP
+{{- $check := . }}
+{{- range .Upstreams }}
+    server upstream-{{ . }} {{ getChrootHealthSocketPath $check.ServiceID . $check.Port }} check inter {{ $.HealthCheckInterval }}
+{{- end }}
+{{- end }}
 `
 
+var configTmpl = template.Must(template.New("haproxy").Funcs(template.FuncMap{
+	"lower":           strings.ToLower,
+	"formatHost":      formatHost,
+	"bindAddress":     bindAddress,
+	"keepAliveOption": keepAliveOption,
+	// Backend socket path inside HAProxy's chroot (rooted at the network dir).
+	"getChrootSocketPath": func(serviceID string, upstream int, port int32) string {
+		return "/" + proxy.GetSocketName(serviceID, upstream, port)
+	},
+	// Health socket path of a backend upstream inside HAProxy's chroot.
+	"getChrootHealthSocketPath": func(serviceID string, upstream int, port int32) string {
+		return "/" + proxy.GetHealthSocketName(serviceID, upstream, port)
+	},
+	"getStatsSocketPath": GetStatsSocketPath,
+	"getPidFilePath":     GetPidFilePath,
+}).Parse(configTemplate))
+
+// endpointConfig is the template data of an endpoint served by the HAProxy instance of its network.
+type endpointConfig struct {
+	ID             string
+	ServiceID      string
+	IPAddress      string
+	Protocol       string
+	UpstreamHost   string
+	Upstreams      []upstream
+	AllBackups     bool
+	ProxyProtocol  bool
+	MaxConn        int32
+	MaxSessionRate int32
+	AllowedSources string
+	Listeners      []internal.Listener
+
+	// Traffic settings of the service, 0 or empty uses the defaults section
+	ServerMaxConn  int32
+	ConnectTimeout int32
+	IdleTimeout    int32
+	TunnelTimeout  int32
+	HTTPKeepAlive  string
+}
+
+// healthCheck is the template data of the backend checking the upstreams of a service port
+// through their health sockets. The servers of the endpoint backends track its servers.
+type healthCheck struct {
+	ServiceID string
+	Port      int32
+	Upstreams []int
+}
+
+// healthChecks returns the health check backends of the service ports of the endpoints, one per
+// service port shared by all endpoints of the service.
+func healthChecks(endpoints []endpointConfig) []healthCheck {
+	var checks []healthCheck
+	for _, ep := range endpoints {
+		for _, listener := range ep.Listeners {
+			if slices.ContainsFunc(checks, func(c healthCheck) bool {
+				return c.ServiceID == ep.ServiceID && c.Port == listener.ServicePort
+			}) {
+				continue
+			}
+			check := healthCheck{ServiceID: ep.ServiceID, Port: listener.ServicePort}
+			for _, u := range ep.Upstreams {
+				check.Upstreams = append(check.Upstreams, u.Index)
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// healthCheckInterval returns the interval of the health checks in HAProxy's time format, empty
+// if health checks are disabled.
+func healthCheckInterval() string {
+	if config.Global.Agent.HealthCheckInterval <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dms", config.Global.Agent.HealthCheckInterval.Milliseconds())
+}
+
 type haProxyInstance struct {
-	cmd    *exec.Cmd
-	config *os.File
-	client *haproxy.HAProxyClient
-	pid    int
+	cmd     *exec.Cmd // nil for adopted instances
+	config  string
+	client  *haproxy.HAProxyClient
+	pid     int
+	proxies map[string]proxyLabels // endpoint ports served by the frontends and backends
 }
 
 type HAProxyController struct {
+	mu        sync.Mutex // guards instances, stats are collected concurrently to injections
 	instances map[string]*haProxyInstance
 }
 
@@ -100,6 +225,10 @@ var (
 		Name: "haproxy_scraped",
 		Help: "Counter of haproxy metric scrapes",
 	}, []string{"network"})
+	reloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "haproxy_reloads",
+		Help: "Counter of haproxy configuration reloads",
+	}, []string{"network"})
 )
 
 // formatHost returns the IP in HTTP Host header format.
@@ -111,6 +240,16 @@ func formatHost(ip string) string {
 	return ip
 }
 
+// bindAddress returns the frontend bind line arguments for the IP address of an endpoint port.
+// transparent allows binding before the address is usable (e.g. IPv6 duplicate address detection),
+// without an address the frontend listens dual-stack on all addresses.
+func bindAddress(ip string, port int32) string {
+	if ip == "" {
+		return fmt.Sprintf(":::%d v4v6", port)
+	}
+	return fmt.Sprintf("%s:%d transparent", ip, port)
+}
+
 // haproxyLogLevel maps the agent's verbosity to HAProxy's max syslog level (--debug -> debug, else info).
 func haproxyLogLevel() string {
 	if config.IsDebug() {
@@ -119,25 +258,187 @@ func haproxyLogLevel() string {
 	return "info"
 }
 
+// deref returns the limit or 0 (no limit) if unset.
+func deref(limit *int32) int32 {
+	if limit == nil {
+		return 0
+	}
+	return *limit
+}
+
+// allowedSources returns the allowed source networks as HAProxy src ACL
+// patterns, empty if all sources are allowed.
+func allowedSources(si *models.ServiceInjection) string {
+	sources := make([]string, 0, len(si.AllowedCidrs))
+	for _, cidr := range si.AllowedCidrs {
+		sources = append(sources, string(cidr))
+	}
+	return strings.Join(sources, " ")
+}
+
+// upstream is a server of the HAProxy backends, reached through the proxy
+// socket of the service IP address at Index.
+type upstream struct {
+	Index  int
+	Weight int32
+	Backup bool
+}
+
+// upstreams returns the servers of the HAProxy backends. HAProxy only knows
+// active and backup servers: non-backup members of the highest priority group
+// are active, all other members are backups. Backups are only activated once
+// the health checks mark the active servers down, without health checks all
+// members are active.
+func upstreams(si *models.ServiceInjection, healthChecked bool) []upstream {
+	members := si.Members()
+	activeGroup := int32(-1)
+	for _, member := range members {
+		if !member.Backup && member.PriorityGroup > activeGroup {
+			activeGroup = member.PriorityGroup
+		}
+	}
+
+	servers := make([]upstream, 0, len(members))
+	for i, member := range members {
+		servers = append(servers, upstream{
+			Index:  i,
+			Weight: member.Weight,
+			Backup: healthChecked && (member.Backup || member.PriorityGroup != activeGroup),
+		})
+	}
+	return servers
+}
+
+// upstreamHost returns the first IP address of the service, used as HTTP Host header.
+func upstreamHost(si *models.ServiceInjection) string {
+	if len(si.ServiceIPAddresses) == 0 {
+		return ""
+	}
+	return string(si.ServiceIPAddresses[0])
+}
+
+// keepAliveOption returns the HAProxy option implementing the HTTP keep-alive mode of a service,
+// by default client connections are kept alive while server connections are closed.
+func keepAliveOption(mode string) string {
+	switch mode {
+	case archermodels.ServiceTrafficHTTPKeepAliveBoth:
+		return "http-keep-alive"
+	case archermodels.ServiceTrafficHTTPKeepAliveNone:
+		return "httpclose"
+	default:
+		return "http-server-close"
+	}
+}
+
+// defaultMaxConn is the process wide connection limit of an HAProxy instance without
+// endpoint limits exceeding it.
+const defaultMaxConn = 1024
+
+// globalMaxConn returns the process wide connection limit of an HAProxy instance, raised to
+// allow every frontend to reach its own limit or the limits of its servers.
+func globalMaxConn(endpoints []endpointConfig) int {
+	total := 0
+	for _, ep := range endpoints {
+		limit := max(int(ep.MaxConn), int(ep.ServerMaxConn)*len(ep.Upstreams))
+		total += limit * len(ep.Listeners)
+	}
+	return max(defaultMaxConn, total)
+}
+
+// newEndpointConfig returns the template data of an injected endpoint.
+func newEndpointConfig(si *models.ServiceInjection, healthChecked bool) endpointConfig {
+	servers := upstreams(si, healthChecked)
+	traffic := si.ServiceTraffic
+	if traffic == nil {
+		traffic = &archermodels.ServiceTraffic{}
+	}
+	var keepAlive string
+	if traffic.HTTPKeepAlive != nil {
+		keepAlive = *traffic.HTTPKeepAlive
+	}
+	return endpointConfig{
+		ID:             si.ID.String(),
+		ServiceID:      si.ServiceID.String(),
+		IPAddress:      si.IPAddress,
+		Protocol:       si.ServiceProtocol,
+		UpstreamHost:   upstreamHost(si),
+		Upstreams:      servers,
+		AllBackups:     slices.ContainsFunc(servers, func(u upstream) bool { return u.Backup }),
+		ProxyProtocol:  si.ProxyProtocol,
+		MaxConn:        deref(si.MaxConn),
+		MaxSessionRate: deref(si.MaxSessionRate),
+		AllowedSources: allowedSources(si),
+		Listeners:      si.Listeners(),
+		ServerMaxConn:  deref(traffic.MaxConnections),
+		ConnectTimeout: deref(traffic.ConnectTimeout),
+		IdleTimeout:    deref(traffic.IdleTimeout),
+		TunnelTimeout:  deref(traffic.TunnelTimeout),
+		HTTPKeepAlive:  keepAlive,
+	}
+}
+
+// renderConfig renders the HAProxy configuration serving all injected endpoints of a network.
+func renderConfig(w io.Writer, networkID string, injections []*models.ServiceInjection) error {
+	interval := healthCheckInterval()
+	endpoints := make([]endpointConfig, 0, len(injections))
+	for _, si := range injections {
+		endpoints = append(endpoints, newEndpointConfig(si, interval != ""))
+	}
+
+	var checks []healthCheck
+	if interval != "" {
+		checks = healthChecks(endpoints)
+	}
+
+	return configTmpl.Execute(w, map[string]any{
+		"Endpoints":           endpoints,
+		"HealthChecks":        checks,
+		"HealthCheckInterval": interval,
+		"MaxConn":             globalMaxConn(endpoints),
+		"Network":             networkID,
+		"ChrootDir":           proxy.GetNetworkDir(networkID),
+		"LogLevel":            haproxyLogLevel(),
+		"RunUser":             config.Global.Agent.RunUser,
+		"RunGroup":            config.Global.Agent.RunGroup,
+	})
+}
+
 func NewHAProxyController() *HAProxyController {
 	return &HAProxyController{
-		make(map[string]*haProxyInstance),
+		instances: make(map[string]*haProxyInstance),
 	}
 }
 
 func (h *HAProxyController) CollectStats() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	for networkID, instance := range h.instances {
 		info, err := instance.client.Info()
 		if err != nil {
 			log.Debugf("Failed fetching stats for instance '%s'", networkID)
+			continue
 		}
 		totalBytesOut.WithLabelValues(networkID).Set(float64(info.TotalBytesOut))
 		currConns.WithLabelValues(networkID).Set(float64(info.CurrConns))
 		metricScrape.WithLabelValues(networkID).Inc()
+
+		stats, err := instance.client.Stats()
+		if err != nil {
+			log.Debugf("Failed fetching endpoint stats for instance '%s'", networkID)
+			continue
+		}
+		endpointStats.update(networkID, instance.proxies, stats)
 	}
 }
 
 func (h *HAProxyController) IsRunning(networkID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.isRunning(networkID)
+}
+
+func (h *HAProxyController) isRunning(networkID string) bool {
 	_, ok := h.instances[networkID]
 	if !ok {
 		return false
@@ -157,45 +458,37 @@ func (h *HAProxyController) IsRunning(networkID string) bool {
 	return process.Signal(syscall.Signal(0)) == nil
 }
 
-func (h *HAProxyController) AddInstance(si *models.ServiceInjection) error {
-	// create config
-	filename := GetConfigFilePath(si.Network.String())
-	configFile, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-	if err != nil {
+// AddInstance runs the HAProxy instance of a network serving the given injections. The
+// configuration is regenerated on every call, a running instance is reloaded if it changed.
+func (h *HAProxyController) AddInstance(networkID string, injections []*models.ServiceInjection) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var buf bytes.Buffer
+	if err := renderConfig(&buf, networkID, injections); err != nil {
 		return err
 	}
 
-	defer func() { _ = configFile.Close() }()
-	log.Debugf("Created HAProxy config file '%s'", configFile.Name())
-
-	funcMap := template.FuncMap{
-		"lower":      strings.ToLower,
-		"formatHost": formatHost,
-		// Backend socket path inside HAProxy's chroot (rooted at the network dir).
-		"getChrootSocketPath": func(port int) string { return fmt.Sprintf("/%d.sock", port) },
-		"getStatsSocketPath":  GetStatsSocketPath,
-		"getPidFilePath":      GetPidFilePath,
+	filename := GetConfigFilePath(networkID)
+	if h.isRunning(networkID) {
+		h.instances[networkID].proxies = endpointProxies(injections)
+		if current, err := os.ReadFile(filename); err == nil && bytes.Equal(current, buf.Bytes()) {
+			log.Debugf("HAProxy config of network %s unchanged", networkID)
+			return nil
+		}
+		return h.reload(networkID, buf.Bytes())
 	}
 
-	// template config
-	t, err := template.New("haproxy").Funcs(funcMap).Parse(configTemplate)
+	// create config
+	configFile, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
 	if err != nil {
 		return err
 	}
 
-	data := map[string]any{
-		"UpstreamHost":  si.ServiceIPAddress,
-		"UpstreamPorts": si.ServicePorts,
-		"Network":       si.Network.String(),
-		"Protocol":      si.ServiceProtocol,
-		"ProxyProtocol": si.ProxyProtocol,
-		"EndpointID":    si.ID.String(),
-		"ChrootDir":     proxy.GetNetworkDir(si.Network.String()),
-		"LogLevel":      haproxyLogLevel(),
-		"RunUser":       config.Global.Agent.RunUser,
-		"RunGroup":      config.Global.Agent.RunGroup,
-	}
-	if err = t.Execute(configFile, data); err != nil {
+	defer func() { _ = configFile.Close() }()
+	log.Debugf("Created HAProxy config file '%s'", configFile.Name())
+
+	if _, err = configFile.Write(buf.Bytes()); err != nil {
 		return err
 	}
 
@@ -213,33 +506,141 @@ func (h *HAProxyController) AddInstance(si *models.ServiceInjection) error {
 	}
 
 	// read pid
-	pid, err := readPidFile(GetPidFilePath(si.Network.String()))
+	pid, err := readPidFile(GetPidFilePath(networkID))
 	if err != nil {
 		return err
 	}
 
 	// init haproxy stats client
 	haProxyClient := haproxy.HAProxyClient{
-		Addr: fmt.Sprintf("unix://%s", GetStatsSocketPath(si.Network.String())),
+		Addr: fmt.Sprintf("unix://%s", GetStatsSocketPath(networkID)),
 	}
 	info, err := haProxyClient.Info()
 	if err != nil {
 		return err
 	}
-	log.Printf("Running %s version %s PID %d for %s", info.Name, info.Version, pid, si.Network)
+	log.Printf("Running %s version %s PID %d for %s serving %d endpoints",
+		info.Name, info.Version, pid, networkID, len(injections))
 
 	instance := haProxyInstance{
-		cmd:    cmd,
-		config: configFile,
+		cmd:     cmd,
+		config:  configFile.Name(),
+		client:  &haProxyClient,
+		pid:     pid,
+		proxies: endpointProxies(injections),
+	}
+
+	h.instances[networkID] = &instance
+	return nil
+}
+
+// reload applies a changed configuration to the running instance of a network without
+// downtime: the validated configuration replaces the config file and the master process
+// is signalled to start new workers, which take over the listeners while the old workers
+// finish their connections.
+func (h *HAProxyController) reload(networkID string, cfg []byte) error {
+	instance := h.instances[networkID]
+	filename := GetConfigFilePath(networkID)
+
+	// validate the new configuration before replacing the current one
+	newFilename := filename + ".new"
+	if err := os.WriteFile(newFilename, cfg, 0o600); err != nil {
+		return err
+	}
+
+	haproxyPath, err := exec.LookPath("haproxy")
+	if err != nil {
+		TryRemoveFile(newFilename)
+		return fmt.Errorf("haproxy binary not found in PATH: %w", err)
+	}
+	cmd := exec.Command(haproxyPath, "-c", "-q", "-f", newFilename)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		Dump(newFilename)
+		TryRemoveFile(newFilename)
+		return fmt.Errorf("invalid haproxy config: %w", err)
+	}
+	if err = os.Rename(newFilename, filename); err != nil {
+		return err
+	}
+
+	log.Infof("HAProxy config of network %s changed, reloading PID %d", networkID, instance.pid)
+	if err = syscall.Kill(instance.pid, syscall.SIGUSR2); err != nil {
+		return err
+	}
+	reloads.WithLabelValues(networkID).Inc()
+	return nil
+}
+
+// Adopt takes over the HAProxy instance of a network left running by a previous agent,
+// identified by its pidfile and reachable through its stats socket. A live HAProxy that
+// cannot be adopted is killed, so that it is not started twice.
+func (h *HAProxyController) Adopt(networkID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pid, err := readPidFile(GetPidFilePath(networkID))
+	if err != nil {
+		return err
+	}
+	if procfs.Comm(pid) != "haproxy" {
+		TryRemoveFile(GetPidFilePath(networkID))
+		return fmt.Errorf("process %d of pidfile is not haproxy", pid)
+	}
+
+	haProxyClient := haproxy.HAProxyClient{
+		Addr: fmt.Sprintf("unix://%s", GetStatsSocketPath(networkID)),
+	}
+	info, err := haProxyClient.Info()
+	if err != nil {
+		log.WithError(err).Warnf("Killing HAProxy PID %d of network %s, stats socket unreachable", pid, networkID)
+		if killErr := syscall.Kill(pid, syscall.SIGTERM); killErr != nil {
+			log.WithError(killErr).Errorf("Failed to kill HAProxy PID %d", pid)
+		}
+		TryRemoveFile(GetPidFilePath(networkID))
+		return fmt.Errorf("failed to connect to stats socket: %w", err)
+	}
+
+	log.Infof("Adopted %s version %s PID %d for %s", info.Name, info.Version, pid, networkID)
+	h.instances[networkID] = &haProxyInstance{
+		config: GetConfigFilePath(networkID),
 		client: &haProxyClient,
 		pid:    pid,
 	}
-
-	h.instances[si.Network.String()] = &instance
 	return nil
 }
 
+// KillStrays kills the HAProxy processes among the given processes of a network namespace
+// that are neither the instance of the network nor its workers. It returns the number of
+// processes killed.
+func (h *HAProxyController) KillStrays(networkID string, pids []int) int {
+	master := -1
+	h.mu.Lock()
+	if instance, ok := h.instances[networkID]; ok {
+		master = instance.pid
+	}
+	h.mu.Unlock()
+
+	var killed int
+	for _, pid := range pids {
+		if procfs.Comm(pid) != "haproxy" || pid == master || procfs.Parent(pid) == master {
+			continue
+		}
+		log.Warnf("Killing stray HAProxy PID %d in namespace of network %s", pid, networkID)
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+			log.WithError(err).Errorf("Failed to kill HAProxy PID %d", pid)
+			continue
+		}
+		killed++
+	}
+	return killed
+}
+
 func (h *HAProxyController) RemoveInstance(networkID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	instance, ok := h.instances[networkID]
 	if !ok {
 		return fmt.Errorf("instance '%s' not found", networkID)
@@ -251,21 +652,19 @@ func (h *HAProxyController) RemoveInstance(networkID string) error {
 	}
 
 	// Remove config and pidfile
-	TryRemoveFile(instance.config.Name())
+	TryRemoveFile(instance.config)
 	TryRemoveFile(GetPidFilePath(networkID))
 
+	endpointStats.remove(networkID)
 	delete(h.instances, networkID)
 	return nil
 }
 
+// Run blocks until ctx is done. The HAProxy instances are left running on shutdown, the
+// next agent start adopts them instead of dropping the connections of every endpoint.
 func (h *HAProxyController) Run(ctx context.Context) {
 	<-ctx.Done()
-	log.Debug("Shutting down HAProxy instances...")
-	for networkID := range h.instances {
-		if err := h.RemoveInstance(networkID); err != nil {
-			log.Errorf("Failed to remove instance '%s': %s", networkID, err)
-		}
-	}
+	log.Info("Leaving HAProxy instances running for adoption")
 }
 
 func Dump(file string) {
@@ -4,20 +4,40 @@
 package haproxy
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"testing"
-	"text/template"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sapcc/archer/v2/internal/agent/ni/models"
+	"github.com/sapcc/archer/v2/internal/agent/ni/procfs"
 	"github.com/sapcc/archer/v2/internal/agent/ni/proxy"
 	"github.com/sapcc/archer/v2/internal/config"
+	archermodels "github.com/sapcc/archer/v2/models"
 )
 
+const testNetworkID = "660e8400-e29b-41d4-a716-446655440000"
+
+// renderTestConfig renders the HAProxy config of the test network serving the injections.
+func renderTestConfig(t *testing.T, injections ...*models.ServiceInjection) string {
+	t.Helper()
+	var buf strings.Builder
+	require.NoError(t, renderConfig(&buf, testNetworkID, injections))
+	return buf.String()
+}
+
 func setupHaproxyTempDir(t *testing.T) func() {
 	t.Helper()
 	tmpDir, err := os.MkdirTemp("", "haproxy-test-*")
@@ -31,9 +51,6 @@ func setupHaproxyTempDir(t *testing.T) func() {
 }
 
 func TestConfigTemplate_IPv6BracketRendering(t *testing.T) {
-	cleanup := setupHaproxyTempDir(t)
-	defer cleanup()
-
 	tests := []struct {
 		name     string
 		ip       string
@@ -49,54 +66,16 @@ func TestConfigTemplate_IPv6BracketRendering(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			si := &models.ServiceInjection{
-				ServiceIPAddress: tt.ip,
-				ServicePorts:     []int{80},
-				ServiceProtocol:  tt.protocol,
-				ServiceID:        strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
-				Network:          strfmt.UUID("660e8400-e29b-41d4-a716-446655440000"),
-			}
-
-			configPath := GetConfigFilePath(si.Network.String())
-			require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(si.Network.String()), 0o777))
-			configFile, err := os.Create(configPath)
-			require.NoError(t, err)
-
-			funcMap := template.FuncMap{
-				"lower":               strings.ToLower,
-				"formatHost":          formatHost,
-				"getSocketPath":       func(serviceID string, port int) string { return "/tmp/test.sock" },
-				"getChrootSocketPath": func(port int) string { return "/test.sock" },
-				"getStatsSocketPath":  GetStatsSocketPath,
-				"getPidFilePath":      GetPidFilePath,
-			}
-
-			tmpl, err := template.New("haproxy").Funcs(funcMap).Parse(configTemplate)
-			require.NoError(t, err)
-
-			data := map[string]any{
-				"UpstreamHost":  si.ServiceIPAddress,
-				"UpstreamPorts": si.ServicePorts,
-				"Network":       si.Network.String(),
-				"Protocol":      si.ServiceProtocol,
-				"ServiceID":     si.ServiceID.String(),
-				"ProxyProtocol": false,
-				"EndpointID":    "test-endpoint-id",
-				"ChrootDir":     config.Global.Agent.RunDir,
-				"LogLevel":      "info",
-				"RunUser":       "nobody",
-				"RunGroup":      "nogroup",
-			}
-			err = tmpl.Execute(configFile, data)
-			require.NoError(t, err)
-			_ = configFile.Close()
-
-			content, err := os.ReadFile(configPath)
-			require.NoError(t, err)
-			configStr := string(content)
+			configStr := renderTestConfig(t, &models.ServiceInjection{
+				ServiceIPAddresses: []archermodels.InetAddress{archermodels.InetAddress(tt.ip)},
+				ServicePorts:       []int32{80},
+				ServiceProtocol:    tt.protocol,
+				ServiceID:          strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+				Network:            strfmt.UUID(testNetworkID),
+			})
 
 			assert.Contains(t, configStr, "bind :::80 v4v6",
-				"should bind dual-stack (IPv4+IPv6)")
+				"should bind dual-stack (IPv4+IPv6) without endpoint address")
 
 			if tt.wantHost != "" {
 				assert.Contains(t, configStr, tt.wantHost)
@@ -108,56 +87,17 @@ func TestConfigTemplate_IPv6BracketRendering(t *testing.T) {
 }
 
 func TestConfigTemplate_ProxyProtocolEnabled(t *testing.T) {
-	cleanup := setupHaproxyTempDir(t)
-	defer cleanup()
-
 	endpointID := "3ad9b1f0-4e5a-44c3-ada6-71696925ae64"
 	si := &models.ServiceInjection{
-		ServiceIPAddress: "10.0.0.1",
-		ServicePorts:     []int{80, 443},
-		ServiceProtocol:  "TCP",
-		ServiceID:        strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
-		Network:          strfmt.UUID("660e8400-e29b-41d4-a716-446655440000"),
-		ProxyProtocol:    true,
-	}
-
-	configPath := GetConfigFilePath(si.Network.String())
-	require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(si.Network.String()), 0o777))
-	configFile, err := os.Create(configPath)
-	require.NoError(t, err)
-
-	funcMap := template.FuncMap{
-		"lower":               strings.ToLower,
-		"formatHost":          formatHost,
-		"getSocketPath":       func(serviceID string, port int) string { return "/tmp/test.sock" },
-		"getChrootSocketPath": func(port int) string { return "/test.sock" },
-		"getStatsSocketPath":  GetStatsSocketPath,
-		"getPidFilePath":      GetPidFilePath,
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+		ServicePorts:       []int32{80, 443},
+		ServiceProtocol:    "TCP",
+		ServiceID:          strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+		Network:            strfmt.UUID(testNetworkID),
+		ProxyProtocol:      true,
 	}
-
-	tmpl, err := template.New("haproxy").Funcs(funcMap).Parse(configTemplate)
-	require.NoError(t, err)
-
-	data := map[string]any{
-		"UpstreamHost":  si.ServiceIPAddress,
-		"UpstreamPorts": si.ServicePorts,
-		"Network":       si.Network.String(),
-		"Protocol":      si.ServiceProtocol,
-		"ServiceID":     si.ServiceID.String(),
-		"ProxyProtocol": si.ProxyProtocol,
-		"EndpointID":    endpointID,
-		"ChrootDir":     config.Global.Agent.RunDir,
-		"LogLevel":      "info",
-		"RunUser":       "nobody",
-		"RunGroup":      "nogroup",
-	}
-	err = tmpl.Execute(configFile, data)
-	require.NoError(t, err)
-	_ = configFile.Close()
-
-	content, err := os.ReadFile(configPath)
-	require.NoError(t, err)
-	configStr := string(content)
+	si.ID = strfmt.UUID(endpointID)
+	configStr := renderTestConfig(t, si)
 
 	assert.Contains(t, configStr, "send-proxy-v2")
 	assert.Contains(t, configStr, "set-proxy-v2-tlv-fmt(0xEC) %[str("+endpointID+")]")
@@ -166,55 +106,14 @@ func TestConfigTemplate_ProxyProtocolEnabled(t *testing.T) {
 }
 
 func TestConfigTemplate_ProxyProtocolDisabled(t *testing.T) {
-	cleanup := setupHaproxyTempDir(t)
-	defer cleanup()
-
-	si := &models.ServiceInjection{
-		ServiceIPAddress: "10.0.0.1",
-		ServicePorts:     []int{80},
-		ServiceProtocol:  "TCP",
-		ServiceID:        strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
-		Network:          strfmt.UUID("660e8400-e29b-41d4-a716-446655440000"),
-		ProxyProtocol:    false,
-	}
-
-	configPath := GetConfigFilePath(si.Network.String())
-	require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(si.Network.String()), 0o777))
-	configFile, err := os.Create(configPath)
-	require.NoError(t, err)
-
-	funcMap := template.FuncMap{
-		"lower":               strings.ToLower,
-		"formatHost":          formatHost,
-		"getSocketPath":       func(serviceID string, port int) string { return "/tmp/test.sock" },
-		"getChrootSocketPath": func(port int) string { return "/test.sock" },
-		"getStatsSocketPath":  GetStatsSocketPath,
-		"getPidFilePath":      GetPidFilePath,
-	}
-
-	tmpl, err := template.New("haproxy").Funcs(funcMap).Parse(configTemplate)
-	require.NoError(t, err)
-
-	data := map[string]any{
-		"UpstreamHost":  si.ServiceIPAddress,
-		"UpstreamPorts": si.ServicePorts,
-		"Network":       si.Network.String(),
-		"Protocol":      si.ServiceProtocol,
-		"ServiceID":     si.ServiceID.String(),
-		"ProxyProtocol": si.ProxyProtocol,
-		"EndpointID":    "unused-when-disabled",
-		"ChrootDir":     config.Global.Agent.RunDir,
-		"LogLevel":      "info",
-		"RunUser":       "nobody",
-		"RunGroup":      "nogroup",
-	}
-	err = tmpl.Execute(configFile, data)
-	require.NoError(t, err)
-	_ = configFile.Close()
-
-	content, err := os.ReadFile(configPath)
-	require.NoError(t, err)
-	configStr := string(content)
+	configStr := renderTestConfig(t, &models.ServiceInjection{
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "TCP",
+		ServiceID:          strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+		Network:            strfmt.UUID(testNetworkID),
+		ProxyProtocol:      false,
+	})
 
 	assert.NotContains(t, configStr, "send-proxy-v2")
 	assert.NotContains(t, configStr, "set-proxy-v2-tlv-fmt")
@@ -224,52 +123,24 @@ func TestConfigTemplate_Hardening(t *testing.T) {
 	cleanup := setupHaproxyTempDir(t)
 	defer cleanup()
 
-	si := &models.ServiceInjection{
-		ServiceIPAddress: "10.0.0.1",
-		ServicePorts:     []int{80},
-		ServiceProtocol:  "TCP",
-		ServiceID:        strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
-		Network:          strfmt.UUID("660e8400-e29b-41d4-a716-446655440000"),
-	}
-
-	funcMap := template.FuncMap{
-		"lower":               strings.ToLower,
-		"formatHost":          formatHost,
-		"getSocketPath":       proxy.GetSocketPath,
-		"getChrootSocketPath": func(port int) string { return "/80.sock" },
-		"getStatsSocketPath":  GetStatsSocketPath,
-		"getPidFilePath":      GetPidFilePath,
-	}
-
-	tmpl, err := template.New("haproxy").Funcs(funcMap).Parse(configTemplate)
-	require.NoError(t, err)
-
-	var buf strings.Builder
-	err = tmpl.Execute(&buf, map[string]any{
-		"UpstreamHost":  si.ServiceIPAddress,
-		"UpstreamPorts": si.ServicePorts,
-		"Network":       si.Network.String(),
-		"Protocol":      si.ServiceProtocol,
-		"ServiceID":     si.ServiceID.String(),
-		"ProxyProtocol": false,
-		"EndpointID":    si.ID.String(),
-		"ChrootDir":     proxy.GetNetworkDir(si.Network.String()),
-		"LogLevel":      "info",
-		"RunUser":       "nobody",
-		"RunGroup":      "nogroup",
+	serviceID := "550e8400-e29b-41d4-a716-446655440000"
+	configStr := renderTestConfig(t, &models.ServiceInjection{
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "TCP",
+		ServiceID:          strfmt.UUID(serviceID),
+		Network:            strfmt.UUID(testNetworkID),
 	})
-	require.NoError(t, err)
-	configStr := buf.String()
 
 	assert.Contains(t, configStr, "user        nobody", "should drop privileges to nobody user")
 	assert.Contains(t, configStr, "group       nogroup", "should drop privileges to nogroup group")
-	assert.Contains(t, configStr, `chroot      "`+proxy.GetNetworkDir(si.Network.String())+`"`,
+	assert.Contains(t, configStr, `chroot      "`+proxy.GetNetworkDir(testNetworkID)+`"`,
 		"should chroot into the per-network dir")
 
 	// The backend server line references the socket at the chroot root (HAProxy
-	// chroots into the network dir), so it is simply /<port>.sock.
-	assert.Contains(t, configStr, "server upstream /80.sock")
-	assert.NotContains(t, configStr, "server upstream "+config.Global.Agent.RunDir,
+	// chroots into the network dir), so it is simply /<service>-<port>-<upstream>.sock.
+	assert.Contains(t, configStr, "server upstream-0 /"+serviceID+"-80-0.sock weight 1")
+	assert.NotContains(t, configStr, "server upstream-0 "+config.Global.Agent.RunDir,
 		"backend server line must not use the host-absolute path")
 }
 
@@ -293,6 +164,65 @@ func TestAddInstanceConfigFilePermissions(t *testing.T) {
 		"config file must be created with 0600 permissions")
 }
 
+func TestAddInstance_Reload(t *testing.T) {
+	cleanup := setupHaproxyTempDir(t)
+	defer cleanup()
+
+	// A fake haproxy binary, validating the config succeeds unless FAKE_HAPROXY_INVALID is set
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "haproxy"),
+		[]byte("#!/bin/sh\ntest -z \"$FAKE_HAPROXY_INVALID\"\n"), 0o700))
+	t.Setenv("PATH", binDir)
+
+	// The test process poses as the running HAProxy master
+	require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(testNetworkID), 0o777))
+	require.NoError(t, os.WriteFile(GetPidFilePath(testNetworkID), []byte(strconv.Itoa(os.Getpid())), 0o600))
+	reloaded := make(chan os.Signal, 1)
+	signal.Notify(reloaded, syscall.SIGUSR2)
+	defer signal.Stop(reloaded)
+
+	si := &models.ServiceInjection{
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "TCP",
+		ServiceID:          strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+		Network:            strfmt.UUID(testNetworkID),
+	}
+	injections := []*models.ServiceInjection{si}
+	current := renderTestConfig(t, injections...)
+	require.NoError(t, os.WriteFile(GetConfigFilePath(testNetworkID), []byte(current), 0o600))
+
+	h := NewHAProxyController()
+	h.instances[testNetworkID] = &haProxyInstance{pid: os.Getpid()}
+
+	// Unchanged config: nothing to do
+	require.NoError(t, h.AddInstance(testNetworkID, injections))
+	assert.Empty(t, reloaded)
+
+	// Invalid config: the running config is kept and the master is not signalled
+	si.ServicePorts = []int32{80, 443}
+	t.Setenv("FAKE_HAPROXY_INVALID", "true")
+	assert.ErrorContains(t, h.AddInstance(testNetworkID, injections), "invalid haproxy config")
+	assert.Empty(t, reloaded)
+	content, err := os.ReadFile(GetConfigFilePath(testNetworkID))
+	require.NoError(t, err)
+	assert.Equal(t, current, string(content))
+	assert.NoFileExists(t, GetConfigFilePath(testNetworkID)+".new")
+
+	// Changed config: replaced and the master is signalled to reload
+	t.Setenv("FAKE_HAPROXY_INVALID", "")
+	require.NoError(t, h.AddInstance(testNetworkID, injections))
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("HAProxy master was not signalled to reload")
+	}
+	content, err = os.ReadFile(GetConfigFilePath(testNetworkID))
+	require.NoError(t, err)
+	assert.Equal(t, renderTestConfig(t, injections...), string(content))
+	assert.Contains(t, string(content), "frontend frontend__443\n")
+}
+
 func TestHaproxyLogLevel(t *testing.T) {
 	orig := config.Global.Default.Debug
 	defer func() { config.Global.Default.Debug = orig }()
@@ -303,3 +233,255 @@ func TestHaproxyLogLevel(t *testing.T) {
 	config.Global.Default.Debug = true
 	assert.Equal(t, "debug", haproxyLogLevel(), "agent --debug maps to debug")
 }
+
+func TestConfigTemplate_Limits(t *testing.T) {
+	render := func(maxConn, maxSessionRate *int32) string {
+		return renderTestConfig(t, &models.ServiceInjection{
+			ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+			ServicePorts:       []int32{80, 443},
+			ServiceProtocol:    "TCP",
+			MaxConn:            maxConn,
+			MaxSessionRate:     maxSessionRate,
+		})
+	}
+
+	configStr := render(nil, nil)
+	assert.Equal(t, 1, strings.Count(configStr, "maxconn"), "should only have the global maxconn")
+	assert.NotContains(t, configStr, "rate-limit sessions")
+
+	configStr = render(new(int32(100)), new(int32(20)))
+	assert.Equal(t, 2, strings.Count(configStr, "    maxconn 100\n"), "should limit every frontend")
+	assert.Equal(t, 2, strings.Count(configStr, "    rate-limit sessions 20\n"), "should limit every frontend")
+}
+
+func TestConfigTemplate_AllowedSources(t *testing.T) {
+	si := &models.ServiceInjection{ServicePorts: []int32{80, 443}, ServiceProtocol: "TCP"}
+	assert.NotContains(t, renderTestConfig(t, si), "tcp-request connection reject")
+
+	si.AllowedCidrs = append(si.AllowedCidrs, "10.180.0.0/16", "2001:db8::/32")
+	assert.Equal(t, 2, strings.Count(renderTestConfig(t, si),
+		"    tcp-request connection reject if !{ src 10.180.0.0/16 2001:db8::/32 }\n"), "should restrict every frontend")
+}
+
+func TestConfigTemplate_PortMappings(t *testing.T) {
+	si := &models.ServiceInjection{
+		ServicePorts:       []int32{80, 443},
+		ServiceProtocol:    "TCP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440000",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+	}
+	si.ID = "3ad9b1f0-4e5a-44c3-ada6-71696925ae64"
+	si.PortMappings = []*archermodels.PortMapping{{ListenPort: new(int32(8443)), ServicePort: new(int32(443))}}
+	configStr := renderTestConfig(t, si)
+
+	assert.Contains(t, configStr, "frontend frontend_"+si.ID.String()+"_80\n    bind :::80 v4v6\n")
+	assert.Contains(t, configStr, "frontend frontend_"+si.ID.String()+"_8443\n    bind :::8443 v4v6\n")
+	assert.NotContains(t, configStr, "bind :::443 ")
+	assert.Contains(t, configStr, "backend backend_"+si.ID.String()+"_8443\n")
+	assert.Contains(t, configStr, "    server upstream-0 /"+si.ServiceID.String()+"-443-0.sock weight 1\n")
+}
+
+func TestConfigTemplate_Members(t *testing.T) {
+	origInterval := config.Global.Agent.HealthCheckInterval
+	defer func() { config.Global.Agent.HealthCheckInterval = origInterval }()
+
+	si := &models.ServiceInjection{
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "TCP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440000",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"},
+		ServiceMembers: []*archermodels.ServiceMember{
+			{IPAddress: new(archermodels.InetAddress("10.0.0.1")), Weight: 3, PriorityGroup: 1},
+			{IPAddress: new(archermodels.InetAddress("10.0.0.2")), PriorityGroup: 1},
+			{IPAddress: new(archermodels.InetAddress("10.0.0.4")), PriorityGroup: 1, Backup: true},
+		},
+	}
+	config.Global.Agent.HealthCheckInterval = 5 * time.Second
+	configStr := renderTestConfig(t, si)
+
+	// members of the highest priority group are active, the lower group and backups are backups
+	assert.Contains(t, configStr, "    option allbackups\n")
+	assert.Contains(t, configStr, "    server upstream-0 /550e8400-e29b-41d4-a716-446655440000-80-0.sock weight 3 track")
+	assert.Contains(t, configStr, "    server upstream-1 /550e8400-e29b-41d4-a716-446655440000-80-1.sock weight 1 track")
+	assert.Contains(t, configStr, "    server upstream-2 /550e8400-e29b-41d4-a716-446655440000-80-2.sock weight 1 backup track")
+	assert.Contains(t, configStr, "    server upstream-3 /550e8400-e29b-41d4-a716-446655440000-80-3.sock weight 1 backup track")
+
+	// without health checks backups would never be activated
+	config.Global.Agent.HealthCheckInterval = 0
+	configStr = renderTestConfig(t, si)
+	assert.NotContains(t, configStr, "allbackups")
+	assert.NotContains(t, configStr, " backup")
+	assert.Contains(t, configStr, "    server upstream-0 /550e8400-e29b-41d4-a716-446655440000-80-0.sock weight 3\n")
+	assert.Contains(t, configStr, "    server upstream-3 /550e8400-e29b-41d4-a716-446655440000-80-3.sock weight 1\n")
+}
+
+func TestConfigTemplate_Traffic(t *testing.T) {
+	si := &models.ServiceInjection{
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "HTTP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440000",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1", "10.0.0.2"},
+	}
+	configStr := renderTestConfig(t, si)
+	assert.Contains(t, configStr, "    maxconn     1024\n")
+	assert.Contains(t, configStr, "    option http-server-close\n", "client keep-alive by default")
+	for _, timeout := range []string{"connect", "client", "server", "tunnel"} {
+		assert.Equal(t, 1, strings.Count(configStr, "    timeout "+timeout), "only set by the defaults section")
+	}
+
+	si.ServiceTraffic = &archermodels.ServiceTraffic{
+		MaxConnections: new(int32(1000)),
+		ConnectTimeout: new(int32(5)),
+		IdleTimeout:    new(int32(300)),
+		TunnelTimeout:  new(int32(86400)),
+		HTTPKeepAlive:  new(archermodels.ServiceTrafficHTTPKeepAliveBoth),
+	}
+	configStr = renderTestConfig(t, si)
+	assert.Contains(t, configStr, "    maxconn     2000\n", "raised to allow every server to reach its limit")
+	assert.Contains(t, configStr, "    timeout client          300s\n")
+	assert.Contains(t, configStr, "    timeout connect         5s\n")
+	assert.Contains(t, configStr, "    timeout server          300s\n")
+	assert.Contains(t, configStr, "    timeout tunnel          86400s\n")
+	assert.Contains(t, configStr, "    option http-keep-alive\n")
+	assert.Contains(t, configStr, "    server upstream-0 /550e8400-e29b-41d4-a716-446655440000-80-0.sock weight 1 maxconn 1000\n")
+	assert.Contains(t, configStr, "    server upstream-1 /550e8400-e29b-41d4-a716-446655440000-80-1.sock weight 1 maxconn 1000\n")
+
+	si.ServiceTraffic.HTTPKeepAlive = new(archermodels.ServiceTrafficHTTPKeepAliveNone)
+	assert.Contains(t, renderTestConfig(t, si), "    option httpclose\n")
+}
+
+func TestConfigTemplate_HealthChecks(t *testing.T) {
+	origInterval := config.Global.Agent.HealthCheckInterval
+	defer func() { config.Global.Agent.HealthCheckInterval = origInterval }()
+
+	first := &models.ServiceInjection{
+		ServicePorts:       []int32{80},
+		ServiceProtocol:    "TCP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440000",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1", "10.0.0.2"},
+	}
+	first.ID = "3ad9b1f0-4e5a-44c3-ada6-71696925ae64"
+	second := *first
+	second.ID = "3ad9b1f0-4e5a-44c3-ada6-71696925ae65"
+
+	config.Global.Agent.HealthCheckInterval = 0
+	configStr := renderTestConfig(t, first, &second)
+	assert.NotContains(t, configStr, "health_", "no health checks when disabled")
+	assert.NotContains(t, configStr, " track ")
+
+	config.Global.Agent.HealthCheckInterval = 5 * time.Second
+	configStr = renderTestConfig(t, first, &second)
+	assert.Contains(t, configStr, "    option redispatch\n")
+	assert.Equal(t, 1, strings.Count(configStr, "backend health_550e8400-e29b-41d4-a716-446655440000_80\n"),
+		"endpoints of the same service share the health checks")
+	assert.Contains(t, configStr, "    option tcp-check\n    tcp-check expect string UP\n")
+	assert.Contains(t, configStr, "    server upstream-0 /550e8400-e29b-41d4-a716-446655440000-80-0.chk check inter 5000ms\n")
+	assert.Contains(t, configStr, "    server upstream-1 /550e8400-e29b-41d4-a716-446655440000-80-1.chk check inter 5000ms\n")
+	assert.Equal(t, 2, strings.Count(configStr,
+		"    server upstream-1 /550e8400-e29b-41d4-a716-446655440000-80-1.sock weight 1 track health_550e8400-e29b-41d4-a716-446655440000_80/upstream-1\n"),
+		"the servers of both endpoints track the health checks")
+}
+
+func TestConfigTemplate_MultipleEndpoints(t *testing.T) {
+	// Two endpoints of different services share the network's HAProxy instance,
+	// each bound to the address of its own endpoint port.
+	first := &models.ServiceInjection{
+		ServicePorts:       []int32{443},
+		ServiceProtocol:    "TCP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440000",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.1"},
+	}
+	first.ID = "3ad9b1f0-4e5a-44c3-ada6-71696925ae64"
+	first.IPAddress = "192.168.1.10"
+	second := &models.ServiceInjection{
+		ServicePorts:       []int32{443},
+		ServiceProtocol:    "HTTP",
+		ServiceID:          "550e8400-e29b-41d4-a716-446655440001",
+		ServiceIPAddresses: []archermodels.InetAddress{"10.0.0.2"},
+	}
+	second.ID = "3ad9b1f0-4e5a-44c3-ada6-71696925ae65"
+	second.IPAddress = "fd00::10"
+
+	configStr := renderTestConfig(t, first, second)
+
+	assert.Contains(t, configStr, "frontend frontend_"+first.ID.String()+"_443\n"+
+		"    bind 192.168.1.10:443 transparent\n    mode tcp\n")
+	assert.Contains(t, configStr, "frontend frontend_"+second.ID.String()+"_443\n"+
+		"    bind fd00::10:443 transparent\n    mode http\n")
+	assert.Contains(t, configStr, "backend backend_"+first.ID.String()+"_443\n")
+	assert.Contains(t, configStr, "backend backend_"+second.ID.String()+"_443\n")
+	assert.Contains(t, configStr, "    server upstream-0 /"+first.ServiceID.String()+"-443-0.sock weight 1\n")
+	assert.Contains(t, configStr, "    server upstream-0 /"+second.ServiceID.String()+"-443-0.sock weight 1\n")
+	assert.Equal(t, 1, strings.Count(configStr, "http-request replace-header Host .* 10.0.0.2\n"),
+		"only the HTTP endpoint rewrites the host header")
+}
+
+// fakeProcess creates the /proc entries of a process below the fake procfs.Root.
+func fakeProcess(t *testing.T, pid int, comm string, ppid int) {
+	t.Helper()
+	dir := filepath.Join(procfs.Root, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"),
+		[]byte(fmt.Sprintf("%d (%s) S %d", pid, comm, ppid)), 0o644))
+}
+
+func TestAdopt(t *testing.T) {
+	cleanup := setupHaproxyTempDir(t)
+	defer cleanup()
+	origRoot := procfs.Root
+	defer func() { procfs.Root = origRoot }()
+	procfs.Root = t.TempDir()
+	require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(testNetworkID), 0o777))
+
+	h := NewHAProxyController()
+	assert.Error(t, h.Adopt(testNetworkID), "no pidfile, nothing to adopt")
+
+	// The pid was reused by another process
+	const pid = 4242
+	pidFile := GetPidFilePath(testNetworkID)
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(pid)), 0o600))
+	fakeProcess(t, pid, "sshd", 1)
+	assert.ErrorContains(t, h.Adopt(testNetworkID), "not haproxy")
+	assert.NoFileExists(t, pidFile)
+	assert.Empty(t, h.instances)
+
+	// A live HAProxy answering on its stats socket
+	listener, err := net.Listen("unix", GetStatsSocketPath(testNetworkID))
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = bufio.NewReader(conn).ReadString('\n')
+			_, _ = conn.Write([]byte("Name: HAProxy\nVersion: 3.0.0\nPid: 4243\n"))
+			_ = conn.Close()
+		}
+	}()
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(pid)), 0o600))
+	fakeProcess(t, pid, "haproxy", 1)
+	require.NoError(t, h.Adopt(testNetworkID))
+	require.Contains(t, h.instances, testNetworkID)
+	assert.Equal(t, pid, h.instances[testNetworkID].pid)
+	assert.Equal(t, GetConfigFilePath(testNetworkID), h.instances[testNetworkID].config)
+
+	// Only HAProxy processes other than the adopted master and its workers are killed
+	stray := exec.Command("sleep", "60")
+	require.NoError(t, stray.Start())
+	exited := make(chan error, 1)
+	go func() { exited <- stray.Wait() }()
+	fakeProcess(t, pid+1, "haproxy", pid)
+	fakeProcess(t, pid+2, "socat", 1)
+	fakeProcess(t, stray.Process.Pid, "haproxy", 1)
+
+	assert.Equal(t, 1, h.KillStrays(testNetworkID, []int{pid, pid + 1, pid + 2, stray.Process.Pid}))
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		_ = stray.Process.Kill()
+		t.Fatal("stray HAProxy was not killed")
+	}
+}
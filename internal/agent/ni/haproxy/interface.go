@@ -13,7 +13,9 @@ import (
 type HAProxy interface {
 	CollectStats()
 	IsRunning(string) bool
-	AddInstance(injection *models.ServiceInjection) error
+	AddInstance(networkID string, injections []*models.ServiceInjection) error
 	RemoveInstance(networkID string) error
+	Adopt(networkID string) error
+	KillStrays(networkID string, pids []int) int
 	Run(ctx context.Context)
 }
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package haproxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bcicen/go-haproxy"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/models"
+)
+
+var (
+	proxyLabelNames  = []string{"network", "endpoint", "service", "port"}
+	serverLabelNames = append(append([]string{}, proxyLabelNames...), "server")
+
+	frontendCurrentSessions = prometheus.NewDesc("haproxy_frontend_current_sessions",
+		"Current number of sessions of an endpoint port", proxyLabelNames, nil)
+	frontendSessions = prometheus.NewDesc("haproxy_frontend_sessions_total",
+		"Total number of sessions of an endpoint port", proxyLabelNames, nil)
+	frontendBytesIn = prometheus.NewDesc("haproxy_frontend_bytes_in_total",
+		"Bytes received from clients of an endpoint port", proxyLabelNames, nil)
+	frontendBytesOut = prometheus.NewDesc("haproxy_frontend_bytes_out_total",
+		"Bytes sent to clients of an endpoint port", proxyLabelNames, nil)
+	frontendRequestErrors = prometheus.NewDesc("haproxy_frontend_request_errors_total",
+		"Client connections or requests of an endpoint port that failed", proxyLabelNames, nil)
+	frontendDeniedConnections = prometheus.NewDesc("haproxy_frontend_denied_connections_total",
+		"Client connections of an endpoint port rejected by the allowed CIDRs", proxyLabelNames, nil)
+
+	backendCurrentSessions = prometheus.NewDesc("haproxy_backend_current_sessions",
+		"Current number of sessions to the service of an endpoint port", proxyLabelNames, nil)
+	backendSessions = prometheus.NewDesc("haproxy_backend_sessions_total",
+		"Total number of sessions to the service of an endpoint port", proxyLabelNames, nil)
+	backendBytesIn = prometheus.NewDesc("haproxy_backend_bytes_in_total",
+		"Bytes sent to the service of an endpoint port", proxyLabelNames, nil)
+	backendBytesOut = prometheus.NewDesc("haproxy_backend_bytes_out_total",
+		"Bytes received from the service of an endpoint port", proxyLabelNames, nil)
+	backendConnectionErrors = prometheus.NewDesc("haproxy_backend_connection_errors_total",
+		"Failed connection attempts to the service of an endpoint port", proxyLabelNames, nil)
+	backendResponseErrors = prometheus.NewDesc("haproxy_backend_response_errors_total",
+		"Aborted or invalid responses of the service of an endpoint port", proxyLabelNames, nil)
+	backendCurrentQueue = prometheus.NewDesc("haproxy_backend_current_queue",
+		"Current number of sessions of an endpoint port queued for a service member", proxyLabelNames, nil)
+	backendQueueTime = prometheus.NewDesc("haproxy_backend_queue_time_average_seconds",
+		"Average queue time of the last 1024 sessions of an endpoint port", proxyLabelNames, nil)
+	backendUp = prometheus.NewDesc("haproxy_backend_up",
+		"Whether the service of an endpoint port is reachable through any member", proxyLabelNames, nil)
+
+	serverUp = prometheus.NewDesc("haproxy_server_up",
+		"Whether a service member of an endpoint port is up", serverLabelNames, nil)
+	serverCurrentSessions = prometheus.NewDesc("haproxy_server_current_sessions",
+		"Current number of sessions to a service member of an endpoint port", serverLabelNames, nil)
+	serverConnectionErrors = prometheus.NewDesc("haproxy_server_connection_errors_total",
+		"Failed connection attempts to a service member of an endpoint port", serverLabelNames, nil)
+)
+
+// proxyLabels are the metric labels of the frontend and backend of an endpoint port.
+type proxyLabels struct {
+	endpoint string
+	service  string
+	port     string
+}
+
+// endpointProxies maps the frontend and backend names in the HAProxy config to the endpoint
+// port they serve; the names only carry the endpoint ID and port.
+func endpointProxies(injections []*models.ServiceInjection) map[string]proxyLabels {
+	proxies := make(map[string]proxyLabels)
+	for _, si := range injections {
+		for _, listener := range si.Listeners() {
+			labels := proxyLabels{
+				endpoint: si.ID.String(),
+				service:  si.ServiceID.String(),
+				port:     strconv.Itoa(int(listener.Port)),
+			}
+			proxies[fmt.Sprintf("frontend_%s_%d", si.ID, listener.Port)] = labels
+			proxies[fmt.Sprintf("backend_%s_%d", si.ID, listener.Port)] = labels
+		}
+	}
+	return proxies
+}
+
+// endpointStat is a "show stat" line of an endpoint port's frontend, backend or server.
+type endpointStat struct {
+	proxyLabels
+	*haproxy.Stat
+}
+
+// statsCollector exports the last collected "show stat" lines of all networks. Collecting
+// from a snapshot rather than setting gauges drops the series of removed endpoints.
+type statsCollector struct {
+	mu    sync.Mutex
+	stats map[string][]endpointStat
+}
+
+var endpointStats = &statsCollector{stats: make(map[string][]endpointStat)}
+
+func init() {
+	prometheus.MustRegister(endpointStats)
+}
+
+// update replaces the stats of a network, lines of proxies not serving an endpoint are skipped.
+func (c *statsCollector) update(networkID string, proxies map[string]proxyLabels, stats []*haproxy.Stat) {
+	var endpoints []endpointStat
+	for _, stat := range stats {
+		if labels, ok := proxies[stat.PxName]; ok {
+			endpoints = append(endpoints, endpointStat{labels, stat})
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats[networkID] = endpoints
+}
+
+// remove drops the stats of a network.
+func (c *statsCollector) remove(networkID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.stats, networkID)
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		frontendCurrentSessions, frontendSessions, frontendBytesIn, frontendBytesOut,
+		frontendRequestErrors, frontendDeniedConnections,
+		backendCurrentSessions, backendSessions, backendBytesIn, backendBytesOut, backendConnectionErrors,
+		backendResponseErrors, backendCurrentQueue, backendQueueTime, backendUp,
+		serverUp, serverCurrentSessions, serverConnectionErrors,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	gauge := func(desc *prometheus.Desc, value float64, labels []string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	}
+	counter := func(desc *prometheus.Desc, value uint64, labels []string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), labels...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for networkID, stats := range c.stats {
+		for _, s := range stats {
+			labels := []string{networkID, s.endpoint, s.service, s.port}
+			switch s.SvName {
+			case "FRONTEND":
+				gauge(frontendCurrentSessions, float64(s.Scur), labels)
+				counter(frontendSessions, s.Stot, labels)
+				counter(frontendBytesIn, s.Bin, labels)
+				counter(frontendBytesOut, s.Bout, labels)
+				counter(frontendRequestErrors, s.Ereq, labels)
+				counter(frontendDeniedConnections, s.Dcon, labels)
+			case "BACKEND":
+				gauge(backendCurrentSessions, float64(s.Scur), labels)
+				counter(backendSessions, s.Stot, labels)
+				counter(backendBytesIn, s.Bin, labels)
+				counter(backendBytesOut, s.Bout, labels)
+				counter(backendConnectionErrors, s.Econ, labels)
+				counter(backendResponseErrors, s.Eresp, labels)
+				gauge(backendCurrentQueue, float64(s.Qcur), labels)
+				gauge(backendQueueTime, float64(s.Qtime)/1000, labels)
+				gauge(backendUp, statusUp(s.Status), labels)
+			default:
+				labels = append(labels, s.SvName)
+				gauge(serverUp, statusUp(s.Status), labels)
+				gauge(serverCurrentSessions, float64(s.Scur), labels)
+				counter(serverConnectionErrors, s.Econ, labels)
+			}
+		}
+	}
+}
+
+// statusUp returns 1 for the HAProxy states of a backend or server accepting sessions. Servers
+// without health checks report "no check", "UP 1/3" is a server about to go down.
+func statusUp(status string) float64 {
+	if strings.HasPrefix(status, "UP") || status == "no check" {
+		return 1
+	}
+	return 0
+}
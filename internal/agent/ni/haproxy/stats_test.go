@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package haproxy
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bcicen/go-haproxy"
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/models"
+	"github.com/sapcc/archer/v2/internal/agent/ni/proxy"
+	archermodels "github.com/sapcc/archer/v2/models"
+)
+
+// testStats is "show stat" output reduced to the exported columns, they are matched by name.
+const testStats = `# pxname,svname,qcur,scur,stot,bin,bout,ereq,econ,eresp,status,qtime,dcon
+frontend_880e8400-e29b-41d4-a716-446655440000_8443,FRONTEND,,2,42,1000,2000,1,,,OPEN,,4
+backend_880e8400-e29b-41d4-a716-446655440000_8443,upstream-0,0,1,40,900,1800,,3,1,UP,0,
+backend_880e8400-e29b-41d4-a716-446655440000_8443,upstream-1,0,0,2,100,200,,0,0,no check,0,
+backend_880e8400-e29b-41d4-a716-446655440000_8443,BACKEND,0,1,42,1000,2000,,3,1,UP,25,
+stats,FRONTEND,,0,1,0,0,0,,,OPEN,,0
+`
+
+const testEndpointID = strfmt.UUID("880e8400-e29b-41d4-a716-446655440000")
+
+// serveStatsSocket answers "show info" and "show stat" on the stats socket of the test network.
+func serveStatsSocket(t *testing.T) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(proxy.GetNetworkDir(testNetworkID), 0o777))
+	listener, err := net.Listen("unix", GetStatsSocketPath(testNetworkID))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			cmd, _ := bufio.NewReader(conn).ReadString('\n')
+			if strings.HasPrefix(cmd, "show stat") {
+				_, _ = conn.Write([]byte(testStats))
+			} else {
+				_, _ = conn.Write([]byte("Name: HAProxy\nVersion: 3.0.0\nCurrConns: 2\nTotalBytesOut: 2000\n"))
+			}
+			_ = conn.Close()
+		}
+	}()
+}
+
+func TestEndpointProxies(t *testing.T) {
+	si := &models.ServiceInjection{
+		Endpoint: archermodels.Endpoint{
+			ID:           testEndpointID,
+			PortMappings: []*archermodels.PortMapping{{ListenPort: new(int32(8443)), ServicePort: new(int32(443))}},
+		},
+		ServiceID:    strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+		ServicePorts: []int32{80, 443},
+	}
+
+	labels := proxyLabels{endpoint: testEndpointID.String(), service: si.ServiceID.String(), port: "8443"}
+	proxies := endpointProxies([]*models.ServiceInjection{si})
+	assert.Len(t, proxies, 4, "a frontend and backend per listener")
+	assert.Equal(t, labels, proxies["frontend_880e8400-e29b-41d4-a716-446655440000_8443"])
+	assert.Equal(t, labels, proxies["backend_880e8400-e29b-41d4-a716-446655440000_8443"])
+	assert.Equal(t, "80", proxies["backend_880e8400-e29b-41d4-a716-446655440000_80"].port)
+}
+
+func TestCollectStats(t *testing.T) {
+	cleanup := setupHaproxyTempDir(t)
+	defer cleanup()
+	serveStatsSocket(t)
+	defer endpointStats.remove(testNetworkID)
+
+	si := &models.ServiceInjection{
+		Endpoint: archermodels.Endpoint{
+			ID:           testEndpointID,
+			PortMappings: []*archermodels.PortMapping{{ListenPort: new(int32(8443)), ServicePort: new(int32(443))}},
+		},
+		ServiceID:    strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
+		ServicePorts: []int32{443},
+	}
+	h := NewHAProxyController()
+	h.instances[testNetworkID] = &haProxyInstance{
+		client:  &haproxy.HAProxyClient{Addr: "unix://" + GetStatsSocketPath(testNetworkID)},
+		proxies: endpointProxies([]*models.ServiceInjection{si}),
+	}
+	h.CollectStats()
+
+	serverLabels := `network="660e8400-e29b-41d4-a716-446655440000",port="8443"`
+	labels := serverLabels + `,service="550e8400-e29b-41d4-a716-446655440000"`
+	expected := `
+# HELP haproxy_frontend_sessions_total Total number of sessions of an endpoint port
+# TYPE haproxy_frontend_sessions_total counter
+haproxy_frontend_sessions_total{endpoint="880e8400-e29b-41d4-a716-446655440000",` + labels + `} 42
+# HELP haproxy_frontend_denied_connections_total Client connections of an endpoint port rejected by the allowed CIDRs
+# TYPE haproxy_frontend_denied_connections_total counter
+haproxy_frontend_denied_connections_total{endpoint="880e8400-e29b-41d4-a716-446655440000",` + labels + `} 4
+# HELP haproxy_backend_connection_errors_total Failed connection attempts to the service of an endpoint port
+# TYPE haproxy_backend_connection_errors_total counter
+haproxy_backend_connection_errors_total{endpoint="880e8400-e29b-41d4-a716-446655440000",` + labels + `} 3
+# HELP haproxy_backend_queue_time_average_seconds Average queue time of the last 1024 sessions of an endpoint port
+# TYPE haproxy_backend_queue_time_average_seconds gauge
+haproxy_backend_queue_time_average_seconds{endpoint="880e8400-e29b-41d4-a716-446655440000",` + labels + `} 0.025
+# HELP haproxy_server_up Whether a service member of an endpoint port is up
+# TYPE haproxy_server_up gauge
+haproxy_server_up{endpoint="880e8400-e29b-41d4-a716-446655440000",` + serverLabels + `,server="upstream-0",service="550e8400-e29b-41d4-a716-446655440000"} 1
+haproxy_server_up{endpoint="880e8400-e29b-41d4-a716-446655440000",` + serverLabels + `,server="upstream-1",service="550e8400-e29b-41d4-a716-446655440000"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(endpointStats, strings.NewReader(expected),
+		"haproxy_frontend_sessions_total", "haproxy_frontend_denied_connections_total",
+		"haproxy_backend_connection_errors_total", "haproxy_backend_queue_time_average_seconds", "haproxy_server_up"))
+	assert.Equal(t, 21, testutil.CollectAndCount(endpointStats), "the stats frontend is not exported")
+
+	endpointStats.remove(testNetworkID)
+	assert.Zero(t, testutil.CollectAndCount(endpointStats))
+}
+
+func TestStatusUp(t *testing.T) {
+	for status, up := range map[string]float64{
+		"UP": 1, "UP 1/3": 1, "no check": 1, "DOWN": 0, "DOWN 1/2": 0, "MAINT": 0, "NOLB": 0,
+	} {
+		assert.Equal(t, up, statusUp(status), status)
+	}
+}
@@ -6,18 +6,33 @@ package models
 import (
 	"github.com/go-openapi/strfmt"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/models"
 )
 
 // ServiceInjection contains all data needed to set up network injection for an endpoint.
 // It combines endpoint data with service configuration for HAProxy and netlink setup.
 type ServiceInjection struct {
-	models.Endpoint              // Embedded endpoint with ID, status, etc.
-	PortId           strfmt.UUID // Neutron port ID for the endpoint
-	Network          strfmt.UUID // Network ID where the endpoint resides
-	ServiceID        strfmt.UUID // ID of the service this endpoint belongs to
-	ServicePorts     []int       // Ports exposed by the service
-	ServiceProtocol  string      // Protocol type (HTTP or TCP)
-	ServiceIPAddress string      // First IP address of the service (upstream target)
-	ProxyProtocol    bool        // Whether to send proxy protocol v2 to upstream
+	models.Endpoint                            // Embedded endpoint with ID, status, etc.
+	PortId             strfmt.UUID             // Neutron port ID for the endpoint
+	Network            strfmt.UUID             // Network ID where the endpoint resides
+	ServiceID          strfmt.UUID             // ID of the service this endpoint belongs to
+	ServicePorts       []int32                 // Ports exposed by the service
+	ServiceProtocol    string                  // Protocol type (HTTP or TCP)
+	ServiceIPAddresses []models.InetAddress    // IP addresses of the service (upstream targets)
+	ServiceMembers     []*models.ServiceMember // Load balancing options of the service IP addresses
+	ServiceTraffic     *models.ServiceTraffic  // Traffic settings of the service, nil uses the defaults
+	ProxyProtocol      bool                    // Whether to send proxy protocol v2 to upstream
+	MaxConn            *int32                  // Concurrent connection limit, endpoint override or service default
+	MaxSessionRate     *int32                  // New connections per second limit, endpoint override or service default
+}
+
+// Members returns the upstream members of the service, see internal.Members.
+func (si *ServiceInjection) Members() []*models.ServiceMember {
+	return internal.Members(si.ServiceIPAddresses, si.ServiceMembers)
+}
+
+// Listeners returns the listeners of the endpoint, see internal.Listeners.
+func (si *ServiceInjection) Listeners() []internal.Listener {
+	return internal.Listeners(si.ServicePorts, si.Ports, si.PortMappings)
 }
@@ -5,7 +5,6 @@ package netlink
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
@@ -37,11 +36,22 @@ func (ns *FakeNetlink) Close() error {
 
 func (ns *FakeNetlink) EnsureNetworkNamespace(_ context.Context, port *ports.Port, _ *gophercloud.ServiceClient) error {
 	// Fake network namespace implementation for debugging
-	ns.name = fmt.Sprintf("qinjector-%s", port.NetworkID)
+	ns.name = namespacePrefix + port.NetworkID
 	log.Infof("FakeNetlink: ensuring network namespace '%s'", ns.name)
 	return nil
 }
 
+func (ns *FakeNetlink) SyncRoutes(_ context.Context, port *ports.Port, _ *gophercloud.ServiceClient) error {
+	log.Infof("FakeNetlink: syncing routes of port %s in network namespace '%s'", port.ID, ns.name)
+	return nil
+}
+
+func (ns *FakeNetlink) OpenNetworkNamespace(networkID string) error {
+	ns.name = namespacePrefix + networkID
+	log.Infof("FakeNetlink: opening network namespace '%s'", ns.name)
+	return nil
+}
+
 func (ns *FakeNetlink) DeleteNetworkNamespace() error {
 	log.Infof("FakeNetlink: deleting network namespace '%s'", ns.name)
 	return nil
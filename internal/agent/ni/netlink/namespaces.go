@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package netlink
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/procfs"
+)
+
+// namespacePrefix is the name prefix of the injection network namespaces, followed by the network ID.
+const namespacePrefix = "qinjector-"
+
+// namespaceDir is where named network namespaces are bind mounted; a var so tests can point it elsewhere.
+var namespaceDir = "/run/netns"
+
+// ListNetworkNamespaces returns the network IDs of all injection network namespaces.
+func ListNetworkNamespaces() ([]string, error) {
+	entries, err := os.ReadDir(namespaceDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var networks []string
+	for _, entry := range entries {
+		if networkID, ok := strings.CutPrefix(entry.Name(), namespacePrefix); ok {
+			networks = append(networks, networkID)
+		}
+	}
+	return networks, nil
+}
+
+// NetworkNamespacePids returns the processes running in the injection network namespace of a network.
+func NetworkNamespacePids(networkID string) ([]int, error) {
+	ino := procfs.Inode(fmt.Sprintf("%s/%s%s", namespaceDir, namespacePrefix, networkID))
+	if ino == 0 {
+		return nil, fmt.Errorf("network namespace '%s%s' not found", namespacePrefix, networkID)
+	}
+
+	all, err := procfs.Pids()
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, pid := range all {
+		if procfs.NetworkNamespace(pid) == ino {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
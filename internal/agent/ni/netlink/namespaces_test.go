@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package netlink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListNetworkNamespaces(t *testing.T) {
+	orig := namespaceDir
+	defer func() { namespaceDir = orig }()
+
+	namespaceDir = filepath.Join(t.TempDir(), "netns")
+	networks, err := ListNetworkNamespaces()
+	require.NoError(t, err, "missing namespace dir means no namespaces")
+	assert.Empty(t, networks)
+
+	require.NoError(t, os.MkdirAll(namespaceDir, 0o755))
+	for _, name := range []string{"qinjector-660e8400-e29b-41d4-a716-446655440000", "qrouter-1", "qinjector-net2"} {
+		require.NoError(t, os.WriteFile(filepath.Join(namespaceDir, name), nil, 0o644))
+	}
+	networks, err = ListNetworkNamespaces()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"660e8400-e29b-41d4-a716-446655440000", "net2"}, networks)
+}
+
+func TestNetworkNamespacePids(t *testing.T) {
+	if _, err := os.Stat("/proc/self/ns/net"); err != nil {
+		t.Skip("no network namespaces")
+	}
+	orig := namespaceDir
+	defer func() { namespaceDir = orig }()
+	namespaceDir = t.TempDir()
+
+	_, err := NetworkNamespacePids("net")
+	assert.Error(t, err)
+
+	// The test process runs in the namespace the link points to
+	require.NoError(t, os.Symlink("/proc/self/ns/net", filepath.Join(namespaceDir, "qinjector-net")))
+	pids, err := NetworkNamespacePids("net")
+	require.NoError(t, err)
+	assert.Contains(t, pids, os.Getpid())
+}
@@ -12,6 +12,8 @@ import (
 
 type Netlink interface {
 	EnsureNetworkNamespace(ctx context.Context, port *ports.Port, client *gophercloud.ServiceClient) error
+	SyncRoutes(ctx context.Context, port *ports.Port, client *gophercloud.ServiceClient) error
+	OpenNetworkNamespace(networkID string) error
 	EnableNetworkNamespace() error
 	DisableNetworkNamespace() error
 	DeleteNetworkNamespace() error
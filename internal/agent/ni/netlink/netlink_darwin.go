@@ -8,3 +8,11 @@ package netlink
 func NewNetworkNamespace() Netlink {
 	return NewFakeNetlink()
 }
+
+func ListTapPorts(_ string) ([]string, error) {
+	return nil, nil
+}
+
+func DeleteTapPort(_ string) error {
+	return nil
+}
@@ -7,10 +7,13 @@ package netlink
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"runtime"
+	"slices"
 	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -19,8 +22,13 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netns"
+
+	"github.com/sapcc/archer/v2/internal/config"
 )
 
+// sourceRouteTableOffset is added to the interface index to get the routing table of an endpoint port.
+const sourceRouteTableOffset = 1000
+
 type LinuxNetworkNamespace struct {
 	name     string
 	newns    netns.NsHandle
@@ -117,9 +125,26 @@ func (ns *LinuxNetworkNamespace) Close() error {
 	return err
 }
 
-// EnsureNetworkNamespace ensures that a network namespace for the given port exists
+// OpenNetworkNamespace opens the existing network namespace of the given network ID
+func (ns *LinuxNetworkNamespace) OpenNetworkNamespace(networkID string) error {
+	name := namespacePrefix + networkID
+	if ns.Valid() {
+		return fmt.Errorf("network namespace '%s' already open", ns.name)
+	}
+
+	handle, err := netns.GetFromName(name)
+	if err != nil {
+		return fmt.Errorf("failed to open namespace '%s': %w", name, err)
+	}
+	ns.name = name
+	ns.newns = handle
+	return nil
+}
+
+// EnsureNetworkNamespace ensures that the network namespace of the port's network exists
+// and the port is plugged into it. Every endpoint port of a network gets its own veth pair.
 func (ns *LinuxNetworkNamespace) EnsureNetworkNamespace(ctx context.Context, port *ports.Port, client *gophercloud.ServiceClient) error {
-	name := fmt.Sprintf("qinjector-%s", port.NetworkID)
+	name := namespacePrefix + port.NetworkID
 
 	// Check if namespace already exists and matches
 	if existingNS, err := netns.GetFromName(name); err == nil {
@@ -138,63 +163,22 @@ func (ns *LinuxNetworkNamespace) EnsureNetworkNamespace(ctx context.Context, por
 			_ = existingNS.Close()
 		}
 
-		// Validate veth pair exists
-		handle, err := netlink.NewHandleAt(ns.newns)
-		if err != nil {
-			return fmt.Errorf("failed to get handle for namespace '%s': %w", name, err)
+		if ns.isPlugged(port.ID[:11]) {
+			return ns.SyncRoutes(ctx, port, client)
 		}
-		defer handle.Close()
-
-		peerName := fmt.Sprintf("tap%s", port.ID[:11])
-		if _, err := netlink.LinkByName(peerName); err != nil {
-			log.Warnf("namespace '%s' exists but veth pair '%s' not found, will recreate", name, peerName)
-			// Namespace exists but veth is missing, delete and recreate
-			if err := ns.deleteNamespace(name); err != nil {
-				return fmt.Errorf("failed to delete broken namespace: %w", err)
-			}
-			ns.newns = -1
-			// Fall through to create new namespace
-		} else {
-			return nil
-		}
-	}
-
-	// Create veth pair
-	mac, err := net.ParseMAC(port.MACAddress)
-	if err != nil {
-		return fmt.Errorf("failed to parse MAC address: %w", err)
-	}
-
-	peerName := fmt.Sprintf("tap%s", port.ID[:11])
-	veth := netlink.Veth{
-		LinkAttrs: netlink.LinkAttrs{
-			Name:         "veth0",
-			HardwareAddr: mac,
-		},
-		// Magic name tap<port-id> is detected by linuxbridge agent
-		PeerName: peerName,
-	}
-
-	if err = netlink.LinkAdd(&veth); err != nil {
-		return fmt.Errorf("failed to create veth pair: %w", err)
+		log.Infof("plugging port %s into existing namespace '%s'", port.ID, name)
+		return ns.plugPort(ctx, port, client)
 	}
 
 	// Create network namespace and associate handle
 	newns, err := createNamespace(name)
 	if err != nil {
-		// Clean up veth pair
-		if delErr := netlink.LinkDel(&veth); delErr != nil {
-			log.Warnf("failed to clean up veth pair after namespace creation failure: %v", delErr)
-		}
 		return fmt.Errorf("failed to create namespace: %w", err)
 	}
 
 	handle, err := netlink.NewHandleAt(newns)
 	if err != nil {
-		// Clean up namespace and veth
-		_ = netns.DeleteNamed(name)
-		_ = newns.Close()
-		_ = netlink.LinkDel(&veth)
+		ns.cleanupFailedNamespace(name, newns, nil)
 		return fmt.Errorf("failed to get handle for new namespace: %w", err)
 	}
 	defer handle.Close()
@@ -202,58 +186,215 @@ func (ns *LinuxNetworkNamespace) EnsureNetworkNamespace(ctx context.Context, por
 	// bring up loopback device
 	link, err := handle.LinkByName("lo")
 	if err != nil {
-		ns.cleanupFailedNamespace(name, newns, &veth)
+		ns.cleanupFailedNamespace(name, newns, nil)
 		return fmt.Errorf("failed to find loopback device: %w", err)
 	}
 	if err = handle.LinkSetUp(link); err != nil {
-		ns.cleanupFailedNamespace(name, newns, &veth)
+		ns.cleanupFailedNamespace(name, newns, nil)
 		return fmt.Errorf("failed to bring up loopback: %w", err)
 	}
 
-	// Put veth0 into network namespace and set ip addresses
-	if err = netlink.LinkSetNsFd(&veth, int(newns)); err != nil {
-		ns.cleanupFailedNamespace(name, newns, &veth)
-		return fmt.Errorf("failed to move veth to namespace: %w", err)
+	ns.name = name
+	ns.newns = newns
+	if err = ns.plugPort(ctx, port, client); err != nil {
+		ns.cleanupFailedNamespace(name, newns, nil)
+		ns.newns = -1
+		return err
+	}
+	return nil
+}
+
+// isPlugged returns whether the port with the given ID prefix is plugged into the namespace:
+// the tap of its veth pair is left in the host namespace, an OVS internal port is moved into
+// the namespace itself.
+func (ns *LinuxNetworkNamespace) isPlugged(prefix string) bool {
+	name := "tap" + prefix
+	if link, err := netlink.LinkByName(name); err == nil && link.Type() == "veth" {
+		return true
+	}
+	handle, err := netlink.NewHandleAt(ns.newns)
+	if err != nil {
+		return false
+	}
+	defer handle.Close()
+	_, err = handle.LinkByName(name)
+	return err == nil
+}
+
+// plugPort plugs a port into the namespace, as veth pair or as OVS internal port depending on
+// the plug mode, and configures the fixed IPs of the port. Each address is source routed via
+// its own interface, replies leaving through another port of the network would be dropped by
+// Neutron's port security; the gateway and host routes of the subnets go into the same table.
+func (ns *LinuxNetworkNamespace) plugPort(ctx context.Context, port *ports.Port, client *gophercloud.ServiceClient) error {
+	mac, err := net.ParseMAC(port.MACAddress)
+	if err != nil {
+		return fmt.Errorf("failed to parse MAC address: %w", err)
 	}
 
+	portSubnets, err := getPortSubnets(ctx, client, port)
+	if err != nil {
+		return err
+	}
+
+	handle, err := netlink.NewHandleAt(ns.newns)
+	if err != nil {
+		return fmt.Errorf("failed to get handle for namespace '%s': %w", ns.name, err)
+	}
+	defer handle.Close()
+
+	var name string
+	if ovsPlugMode() {
+		name, err = ns.plugOVSPort(port, mac)
+	} else {
+		name, err = ns.plugVeth(port, mac)
+	}
+	if err != nil {
+		return err
+	}
+
+	unplug := func() {
+		if err := DeleteTapPort(port.ID[:11]); err != nil {
+			log.Warnf("failed to unplug port %s during cleanup: %v", port.ID, err)
+		}
+	}
+
+	link, err := handle.LinkByName(name)
+	if err != nil {
+		unplug()
+		return fmt.Errorf("failed to find '%s' in namespace: %w", name, err)
+	}
+
+	var addrs []*netlink.Addr
 	for _, fixedIP := range port.FixedIPs {
 		ip := net.ParseIP(fixedIP.IPAddress)
 		if ip == nil {
-			ns.cleanupFailedNamespace(name, newns, nil)
+			unplug()
 			return fmt.Errorf("failed parsing ip address '%s'", fixedIP.IPAddress)
 		}
 
-		subnet, err := subnets.Get(ctx, client, fixedIP.SubnetID).Extract()
-		if err != nil {
-			ns.cleanupFailedNamespace(name, newns, nil)
-			return fmt.Errorf("failed to get subnet %s: %w", fixedIP.SubnetID, err)
-		}
+		subnetIdx := slices.IndexFunc(portSubnets, func(s *subnets.Subnet) bool { return s.ID == fixedIP.SubnetID })
+		subnet := portSubnets[subnetIdx]
 
 		prefix := subnet.CIDR[strings.Index(subnet.CIDR, "/"):]
 		ipaddress := fmt.Sprintf("%s%s", ip.String(), prefix)
 		addr, err := netlink.ParseAddr(ipaddress)
 		if err != nil {
-			ns.cleanupFailedNamespace(name, newns, nil)
+			unplug()
 			return fmt.Errorf("failed to parse address %s: %w", ipaddress, err)
 		}
 
-		if err = handle.AddrAdd(&veth, addr); err != nil {
-			ns.cleanupFailedNamespace(name, newns, nil)
+		if err = handle.AddrAdd(link, addr); err != nil {
+			unplug()
 			return fmt.Errorf("failed to add address: %w", err)
 		}
+		addrs = append(addrs, addr)
 	}
 
-	// set veth0 up
-	if err := handle.LinkSetUp(&veth); err != nil {
-		ns.cleanupFailedNamespace(name, newns, nil)
-		return fmt.Errorf("failed to bring up veth: %w", err)
+	if err := handle.LinkSetUp(link); err != nil {
+		unplug()
+		return fmt.Errorf("failed to bring up '%s': %w", name, err)
 	}
 
-	ns.name = name
-	ns.newns = newns
+	for _, addr := range addrs {
+		if err := addSourceRoute(handle, link, addr); err != nil {
+			unplug()
+			return err
+		}
+	}
+	if err = syncSubnetRoutes(handle, link, portSubnets); err != nil {
+		unplug()
+		return err
+	}
 	return nil
 }
 
+// plugVeth creates the veth pair of a port and moves one end into the namespace, returning its
+// name there.
+func (ns *LinuxNetworkNamespace) plugVeth(port *ports.Port, mac net.HardwareAddr) (string, error) {
+	veth := netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:         fmt.Sprintf("ns%s", port.ID[:11]),
+			HardwareAddr: mac,
+		},
+		// Magic name tap<port-id> is detected by linuxbridge agent
+		PeerName: fmt.Sprintf("tap%s", port.ID[:11]),
+	}
+
+	if err := netlink.LinkAdd(&veth); err != nil {
+		return "", fmt.Errorf("failed to create veth pair: %w", err)
+	}
+
+	// Put the veth into network namespace
+	if err := netlink.LinkSetNsFd(&veth, int(ns.newns)); err != nil {
+		_ = netlink.LinkDel(&veth)
+		return "", fmt.Errorf("failed to move veth to namespace: %w", err)
+	}
+	return veth.Name, nil
+}
+
+// plugOVSPort adds an internal port tagged with the Neutron port to the integration bridge and
+// moves it into the namespace, returning its name there. The openvswitch agent keeps binding
+// the port after the move, it is tracked by the bridge rather than the host namespace.
+func (ns *LinuxNetworkNamespace) plugOVSPort(port *ports.Port, mac net.HardwareAddr) (string, error) {
+	bridge := config.Global.Agent.IntegrationBridge
+	name := fmt.Sprintf("tap%s", port.ID[:11])
+	if err := ovsVsctl(addOVSPortArgs(bridge, name, port)...); err != nil {
+		return "", fmt.Errorf("failed to add OVS port: %w", err)
+	}
+	unplug := func() {
+		if err := ovsVsctl(deleteOVSPortArgs(bridge, name)...); err != nil {
+			log.Warnf("failed to delete OVS port '%s' during cleanup: %v", name, err)
+		}
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		unplug()
+		return "", fmt.Errorf("failed to find OVS port '%s': %w", name, err)
+	}
+	if err = netlink.LinkSetHardwareAddr(link, mac); err != nil {
+		unplug()
+		return "", fmt.Errorf("failed to set MAC address of '%s': %w", name, err)
+	}
+	if err = netlink.LinkSetNsFd(link, int(ns.newns)); err != nil {
+		unplug()
+		return "", fmt.Errorf("failed to move OVS port to namespace: %w", err)
+	}
+	return name, nil
+}
+
+// addSourceRoute routes traffic sourced from the address via the link, using a
+// routing table per link.
+func addSourceRoute(handle *netlink.Handle, link netlink.Link, addr *netlink.Addr) error {
+	table := sourceRouteTableOffset + link.Attrs().Index
+
+	route := &netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Dst:       &net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask},
+		Scope:     netlink.SCOPE_LINK,
+		Table:     table,
+	}
+	if err := handle.RouteReplace(route); err != nil {
+		return fmt.Errorf("failed to add route %s to table %d: %w", route.Dst, table, err)
+	}
+
+	rule := netlink.NewRule()
+	rule.Src = hostNet(addr.IP)
+	rule.Table = table
+	if err := handle.RuleAdd(rule); err != nil && !errors.Is(err, syscall.EEXIST) {
+		return fmt.Errorf("failed to add rule from %s to table %d: %w", rule.Src, table, err)
+	}
+	return nil
+}
+
+// hostNet returns the single host network of the IP address.
+func hostNet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
 func createNamespace(name string) (netns.NsHandle, error) {
 	log.Debugf("creating network namespace '%s'", name)
 
@@ -294,6 +435,10 @@ func (ns *LinuxNetworkNamespace) DeleteNetworkNamespace() error {
 		return fmt.Errorf("cannot delete namespace while it is enabled")
 	}
 
+	if ovsPlugMode() {
+		// OVS internal ports would be left behind as stale ports of the integration bridge
+		ns.unplugOVSPorts()
+	}
 	return ns.deleteNamespace(ns.name)
 }
 
@@ -322,6 +467,30 @@ func (ns *LinuxNetworkNamespace) deleteNamespace(name string) error {
 	return nil
 }
 
+// unplugOVSPorts deletes the OVS internal ports plugged into the namespace from the bridge.
+func (ns *LinuxNetworkNamespace) unplugOVSPorts() {
+	handle, err := netlink.NewHandleAt(ns.newns)
+	if err != nil {
+		log.Warnf("failed to get handle for namespace '%s': %v", ns.name, err)
+		return
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		log.Warnf("failed to list links of namespace '%s': %v", ns.name, err)
+		return
+	}
+	for _, link := range links {
+		if link.Type() != "openvswitch" {
+			continue
+		}
+		if err = ovsVsctl(deleteOVSPortArgs(config.Global.Agent.IntegrationBridge, link.Attrs().Name)...); err != nil {
+			log.Warnf("failed to delete OVS port '%s' of namespace '%s': %v", link.Attrs().Name, ns.name, err)
+		}
+	}
+}
+
 // cleanupFailedNamespace cleans up resources after a failed namespace setup
 func (ns *LinuxNetworkNamespace) cleanupFailedNamespace(name string, handle netns.NsHandle, veth *netlink.Veth) {
 	if err := netns.DeleteNamed(name); err != nil {
@@ -337,6 +506,77 @@ func (ns *LinuxNetworkNamespace) cleanupFailedNamespace(name string, handle netn
 	}
 }
 
+// ListTapPorts returns the port ID prefixes of the tap veths and OVS internal ports plugged into
+// the injection namespace of a network. Taps are found through the peers inside the namespace,
+// so the taps of e.g. DHCP namespaces in the host namespace are never considered.
+func ListTapPorts(networkID string) ([]string, error) {
+	name := namespacePrefix + networkID
+	nsHandle, err := netns.GetFromName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open namespace '%s': %w", name, err)
+	}
+	defer func() { _ = nsHandle.Close() }()
+
+	handle, err := netlink.NewHandleAt(nsHandle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get handle for namespace '%s': %w", name, err)
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	for _, link := range links {
+		name := link.Attrs().Name
+		switch {
+		case link.Type() == "openvswitch":
+		case link.Type() == "veth" && link.Attrs().ParentIndex != 0:
+			// the parent of a veth is its peer
+			peer, err := netlink.LinkByIndex(link.Attrs().ParentIndex)
+			if err != nil {
+				continue
+			}
+			name = peer.Attrs().Name
+		default:
+			continue
+		}
+		if prefix, ok := strings.CutPrefix(name, "tap"); ok {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes, nil
+}
+
+// DeleteTapPort unplugs the endpoint port with the given port ID prefix, deleting the tap
+// removes the whole veth pair together with the address and routes inside the namespace.
+// OVS internal ports are not found in the host namespace and deleted from the bridge instead.
+func DeleteTapPort(prefix string) error {
+	name := "tap" + prefix
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if !errors.As(err, &notFound) {
+			return err
+		}
+		if ovsPlugMode() {
+			return ovsVsctl(deleteOVSPortArgs(config.Global.Agent.IntegrationBridge, name)...)
+		}
+		return nil
+	}
+	switch link.Type() {
+	case "veth":
+		return netlink.LinkDel(link)
+	case "openvswitch":
+		// e.g. left behind by a failed move into the namespace
+		return ovsVsctl(deleteOVSPortArgs(config.Global.Agent.IntegrationBridge, name)...)
+	default:
+		return fmt.Errorf("link '%s' is neither a veth nor an OVS port", name)
+	}
+}
+
 func (ns *LinuxNetworkNamespace) Valid() bool {
 	return ns.newns != -1
 }
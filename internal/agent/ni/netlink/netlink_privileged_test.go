@@ -8,10 +8,16 @@ package netlink
 
 import (
 	"fmt"
+	"net"
+	"os"
 	"time"
 
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netns"
+
+	"github.com/sapcc/archer/v2/internal/config"
 )
 
 // TestNamespaceCreateDelete tests creating and deleting a network namespace.
@@ -117,6 +123,100 @@ func (s *NetlinkSuite) TestVethPairCreation() {
 	s.Error(err, "veth0 should not exist in host namespace after move")
 }
 
+// TestPortLinkLegacyVeth tests finding the inner end of a veth pair named veth0 by its host tap.
+func (s *NetlinkSuite) TestPortLinkLegacyVeth() {
+	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
+	prefix := fmt.Sprintf("%011d", time.Now().UnixNano()%100000000000)
+
+	veth := netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "veth0"},
+		PeerName:  "tap" + prefix,
+	}
+	err := netlink.LinkAdd(&veth)
+	s.Require().NoError(err, "Failed to create veth pair")
+	defer func() { _ = DeleteTapPort(prefix) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	defer func() {
+		_ = netns.DeleteNamed(name)
+		_ = newns.Close()
+	}()
+	s.Require().NoError(netlink.LinkSetNsFd(&veth, int(newns)), "Failed to move veth to namespace")
+
+	handle, err := netlink.NewHandleAt(newns)
+	s.Require().NoError(err, "Failed to get handle for namespace")
+	defer handle.Close()
+
+	link, err := portLink(handle, prefix)
+	s.Require().NoError(err)
+	s.Equal("veth0", link.Attrs().Name)
+
+	_, err = portLink(handle, "00000000-00")
+	s.Error(err, "unknown ports are not plugged")
+}
+
+// TestSyncRoutesLegacyVeth tests that syncing the routes of a legacy veth0 port without source
+// routing adds the rules looking up its table.
+func (s *NetlinkSuite) TestSyncRoutesLegacyVeth() {
+	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
+	prefix := fmt.Sprintf("%011d", time.Now().UnixNano()%100000000000)
+
+	veth := netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "veth0"},
+		PeerName:  "tap" + prefix,
+	}
+	s.Require().NoError(netlink.LinkAdd(&veth), "Failed to create veth pair")
+	defer func() { _ = DeleteTapPort(prefix) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	defer func() {
+		_ = netns.DeleteNamed(name)
+		_ = newns.Close()
+	}()
+	s.Require().NoError(netlink.LinkSetNsFd(&veth, int(newns)), "Failed to move veth to namespace")
+
+	handle, err := netlink.NewHandleAt(newns)
+	s.Require().NoError(err, "Failed to get handle for namespace")
+	defer handle.Close()
+
+	link, err := portLink(handle, prefix)
+	s.Require().NoError(err)
+	s.Require().NoError(handle.LinkSetUp(link))
+	addr, err := netlink.ParseAddr("10.180.0.10/24")
+	s.Require().NoError(err)
+	s.Require().NoError(handle.AddrAdd(link, addr))
+
+	table := sourceRouteTableOffset + link.Attrs().Index
+	hasRule := func() bool {
+		rules, err := handle.RuleList(netlink.FAMILY_V4)
+		s.Require().NoError(err)
+		for _, rule := range rules {
+			if rule.Table == table && rule.Src != nil && rule.Src.String() == "10.180.0.10/32" {
+				return true
+			}
+		}
+		return false
+	}
+	s.Require().False(hasRule(), "legacy ports start without source routing")
+
+	subnet := &subnets.Subnet{ID: "subnet", CIDR: "10.180.0.0/24", GatewayIP: "10.180.0.1"}
+	s.Require().NoError(syncPortRoutes(handle, link, []*subnets.Subnet{subnet}))
+	s.True(hasRule(), "rule from the address to the port table should exist")
+
+	routes, err := handle.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	s.Require().NoError(err)
+	s.Require().Len(routes, 2, "table should hold the subnet and the gateway route")
+	var gwRoutes []netlink.Route
+	for _, route := range routes {
+		if route.Gw != nil {
+			gwRoutes = append(gwRoutes, route)
+		}
+	}
+	s.Equal([]string{"0.0.0.0/0 via 10.180.0.1"}, routeStrings(gwRoutes))
+}
+
 // TestCleanupOnFailure tests that cleanupFailedNamespace properly cleans up resources.
 func (s *NetlinkSuite) TestCleanupOnFailure() {
 	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
@@ -260,3 +360,184 @@ func (s *NetlinkSuite) TestDeleteWhileEnabled() {
 	// Cleanup
 	_ = ns.DisableNetworkNamespace()
 }
+
+// TestSourceRoute tests that addresses of an endpoint port are routed via the port's own table.
+func (s *NetlinkSuite) TestSourceRoute() {
+	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
+	defer func() { _ = netns.DeleteNamed(name) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	defer func() { _ = newns.Close() }()
+
+	handle, err := netlink.NewHandleAt(newns)
+	s.Require().NoError(err, "Failed to get handle for namespace")
+	defer handle.Close()
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "nsport"}, PeerName: "nspeer"}
+	s.Require().NoError(handle.LinkAdd(veth), "Failed to create veth pair")
+	link, err := handle.LinkByName("nsport")
+	s.Require().NoError(err)
+	s.Require().NoError(handle.LinkSetUp(link))
+
+	addr, err := netlink.ParseAddr("10.180.0.10/24")
+	s.Require().NoError(err)
+	s.Require().NoError(handle.AddrAdd(link, addr))
+
+	s.Require().NoError(addSourceRoute(handle, link, addr), "addSourceRoute should succeed")
+	// Applying it again is a no-op
+	s.Require().NoError(addSourceRoute(handle, link, addr), "addSourceRoute should be idempotent")
+
+	table := sourceRouteTableOffset + link.Attrs().Index
+	routes, err := handle.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	s.Require().NoError(err)
+	s.Require().Len(routes, 1, "table should hold the subnet route")
+	s.Equal("10.180.0.0/24", routes[0].Dst.String())
+	s.Equal(link.Attrs().Index, routes[0].LinkIndex)
+
+	rules, err := handle.RuleList(netlink.FAMILY_V4)
+	s.Require().NoError(err)
+	var found bool
+	for _, rule := range rules {
+		if rule.Table == table && rule.Src != nil && rule.Src.String() == "10.180.0.10/32" {
+			found = true
+		}
+	}
+	s.True(found, "rule from the address to the port table should exist")
+}
+
+// TestSubnetRoutes tests syncing the gateway and host routes of the subnet into the port table.
+func (s *NetlinkSuite) TestSubnetRoutes() {
+	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
+	defer func() { _ = netns.DeleteNamed(name) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	defer func() { _ = newns.Close() }()
+
+	handle, err := netlink.NewHandleAt(newns)
+	s.Require().NoError(err, "Failed to get handle for namespace")
+	defer handle.Close()
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "nsport"}, PeerName: "nspeer"}
+	s.Require().NoError(handle.LinkAdd(veth), "Failed to create veth pair")
+	link, err := handle.LinkByName("nsport")
+	s.Require().NoError(err)
+	s.Require().NoError(handle.LinkSetUp(link))
+	addr, err := netlink.ParseAddr("10.180.0.10/24")
+	s.Require().NoError(err)
+	s.Require().NoError(handle.AddrAdd(link, addr))
+	s.Require().NoError(addSourceRoute(handle, link, addr))
+
+	table := sourceRouteTableOffset + link.Attrs().Index
+	tableRoutes := func() []string {
+		routes, err := handle.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+		s.Require().NoError(err)
+		var gwRoutes []netlink.Route
+		for _, route := range routes {
+			if route.Gw != nil {
+				gwRoutes = append(gwRoutes, route)
+			}
+		}
+		return routeStrings(gwRoutes)
+	}
+
+	subnet := &subnets.Subnet{
+		ID:         "subnet",
+		CIDR:       "10.180.0.0/24",
+		GatewayIP:  "10.180.0.1",
+		HostRoutes: []subnets.HostRoute{{DestinationCIDR: "10.200.0.0/16", NextHop: "10.180.0.254"}},
+	}
+	s.Require().NoError(syncSubnetRoutes(handle, link, []*subnets.Subnet{subnet}))
+	s.ElementsMatch([]string{"0.0.0.0/0 via 10.180.0.1", "10.200.0.0/16 via 10.180.0.254"}, tableRoutes())
+
+	// the subnet changed: new gateway, host route removed
+	subnet.GatewayIP = "10.180.0.2"
+	subnet.HostRoutes = nil
+	s.Require().NoError(syncSubnetRoutes(handle, link, []*subnets.Subnet{subnet}))
+	s.Equal([]string{"0.0.0.0/0 via 10.180.0.2"}, tableRoutes())
+
+	subnet.GatewayIP = ""
+	s.Require().NoError(syncSubnetRoutes(handle, link, []*subnets.Subnet{subnet}))
+	s.Empty(tableRoutes())
+
+	routes, err := handle.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	s.Require().NoError(err)
+	s.Len(routes, 1, "the subnet route of the address is kept")
+
+	link, err = portLink(handle, "port")
+	s.Require().NoError(err, "the inner end of the veth pair is found")
+	s.Equal("nsport", link.Attrs().Name)
+}
+
+// TestTapPorts tests listing and deleting the tap veths plugged into an injection namespace.
+func (s *NetlinkSuite) TestTapPorts() {
+	networkID := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	name := namespacePrefix + networkID
+	defer func() { _ = netns.DeleteNamed(name) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	defer func() { _ = newns.Close() }()
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "nstestgc0001"}, PeerName: "taptestgc0001"}
+	s.Require().NoError(netlink.LinkAdd(veth), "Failed to create veth pair")
+	defer func() { _ = netlink.LinkDel(veth) }()
+	s.Require().NoError(netlink.LinkSetNsFd(veth, int(newns)))
+
+	// a tap veth with its peer outside the namespace is not listed
+	other := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "ns-testgc0002"}, PeerName: "taptestgc0002"}
+	s.Require().NoError(netlink.LinkAdd(other), "Failed to create veth pair")
+	defer func() { _ = netlink.LinkDel(other) }()
+
+	prefixes, err := ListTapPorts(networkID)
+	s.Require().NoError(err)
+	s.Equal([]string{"testgc0001"}, prefixes)
+
+	s.Require().NoError(DeleteTapPort("testgc0001"))
+	prefixes, err = ListTapPorts(networkID)
+	s.Require().NoError(err)
+	s.Empty(prefixes, "deleting the tap should remove the whole pair")
+
+	s.NoError(DeleteTapPort("testgc0001"), "deleting a missing tap is a no-op")
+}
+
+// TestPlugOVSPort tests moving an OVS internal port into the namespace, with a veth standing in
+// for the port ovs-vsctl would create.
+func (s *NetlinkSuite) TestPlugOVSPort() {
+	name := fmt.Sprintf("test-ns-%d", time.Now().UnixNano())
+	defer func() { _ = netns.DeleteNamed(name) }()
+
+	newns, err := createNamespace(name)
+	s.Require().NoError(err, "Failed to create namespace")
+	ns := &LinuxNetworkNamespace{name: name, newns: newns, origin: -1}
+	defer func() { _ = ns.Close() }()
+
+	argsFile := fakeOVSVsctl(s.T(), "0")
+	origBridge := config.Global.Agent.IntegrationBridge
+	defer func() { config.Global.Agent.IntegrationBridge = origBridge }()
+	config.Global.Agent.IntegrationBridge = "br-int"
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "taptestovs-001"}, PeerName: "peertestovs001"}
+	s.Require().NoError(netlink.LinkAdd(veth), "Failed to create veth pair")
+	defer func() { _ = netlink.LinkDel(veth) }()
+
+	port := &ports.Port{ID: "testovs-001-4e29b-41d4-a716-446655440000", MACAddress: "fa:16:3e:00:00:01"}
+	mac, _ := net.ParseMAC(port.MACAddress)
+	linkName, err := ns.plugOVSPort(port, mac)
+	s.Require().NoError(err)
+	s.Equal("taptestovs-001", linkName, "the port keeps its name in the namespace")
+
+	args, err := os.ReadFile(argsFile)
+	s.Require().NoError(err)
+	s.Contains(string(args), "add-port br-int taptestovs-001")
+	s.Contains(string(args), "external_ids:iface-id="+port.ID)
+
+	handle, err := netlink.NewHandleAt(newns)
+	s.Require().NoError(err)
+	defer handle.Close()
+	link, err := handle.LinkByName(linkName)
+	s.Require().NoError(err, "the port should be moved into the namespace")
+	s.Equal(mac, link.Attrs().HardwareAddr)
+	s.True(ns.isPlugged("testovs-001"))
+}
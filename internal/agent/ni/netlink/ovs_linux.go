@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package netlink
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+// ovsTimeout bounds waiting for ovsdb-server and ovs-vswitchd in seconds.
+const ovsTimeout = 10
+
+// ovsPlugMode returns whether endpoint ports are plugged as Open vSwitch internal ports.
+func ovsPlugMode() bool {
+	return config.Global.Agent.PlugMode == "openvswitch"
+}
+
+// ovsVsctl runs ovs-vsctl, which waits until ovs-vswitchd applied the change.
+func ovsVsctl(args ...string) error {
+	ovsVsctlPath, err := exec.LookPath("ovs-vsctl")
+	if err != nil {
+		return fmt.Errorf("ovs-vsctl binary not found in PATH: %w", err)
+	}
+	cmd := exec.Command(ovsVsctlPath, append([]string{fmt.Sprintf("--timeout=%d", ovsTimeout)}, args...)...)
+	log.Debugf("running %s", cmd.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ovs-vsctl %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// addOVSPortArgs returns the ovs-vsctl arguments adding an internal port for a Neutron port to
+// the bridge. The openvswitch agent binds the port by its iface-id, like the ports of DHCP and
+// router namespaces.
+func addOVSPortArgs(bridge, name string, port *ports.Port) []string {
+	return []string{
+		"--", "--may-exist", "add-port", bridge, name,
+		"--", "set", "Interface", name, "type=internal",
+		"external_ids:iface-id=" + port.ID,
+		"external_ids:iface-status=active",
+		"external_ids:attached-mac=" + port.MACAddress,
+	}
+}
+
+// deleteOVSPortArgs returns the ovs-vsctl arguments deleting a port from the bridge.
+func deleteOVSPortArgs(bridge, name string) []string {
+	return []string{"--", "--if-exists", "del-port", bridge, name}
+}
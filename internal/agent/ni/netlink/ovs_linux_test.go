@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package netlink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOVSVsctl puts an ovs-vsctl into PATH recording its arguments, failing with output
+// unless the exit code is 0. It returns the file the arguments are recorded in.
+func fakeOVSVsctl(t *testing.T, exitCode string) string {
+	t.Helper()
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "ovs-vsctl"),
+		[]byte("#!/bin/sh\necho \"$@\" >> "+argsFile+"\necho 'ovs-vsctl: no bridge named br-int' >&2\nexit "+exitCode+"\n"), 0o700))
+	t.Setenv("PATH", binDir)
+	return argsFile
+}
+
+func TestAddOVSPortArgs(t *testing.T) {
+	port := &ports.Port{ID: "550e8400-e29b-41d4-a716-446655440000", MACAddress: "fa:16:3e:00:00:01"}
+	assert.Equal(t, []string{
+		"--", "--may-exist", "add-port", "br-int", "tap550e8400-e2",
+		"--", "set", "Interface", "tap550e8400-e2", "type=internal",
+		"external_ids:iface-id=550e8400-e29b-41d4-a716-446655440000",
+		"external_ids:iface-status=active",
+		"external_ids:attached-mac=fa:16:3e:00:00:01",
+	}, addOVSPortArgs("br-int", "tap550e8400-e2", port))
+	assert.Equal(t, []string{"--", "--if-exists", "del-port", "br-int", "tap550e8400-e2"},
+		deleteOVSPortArgs("br-int", "tap550e8400-e2"))
+}
+
+func TestOVSVsctl(t *testing.T) {
+	argsFile := fakeOVSVsctl(t, "0")
+	require.NoError(t, ovsVsctl("--", "--if-exists", "del-port", "br-int", "tap550e8400-e2"))
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "--timeout=10 -- --if-exists del-port br-int tap550e8400-e2\n", string(args))
+
+	fakeOVSVsctl(t, "1")
+	err = ovsVsctl("--", "--may-exist", "add-port", "br-int", "tap550e8400-e2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no bridge named br-int", "the output is part of the error")
+
+	t.Setenv("PATH", t.TempDir())
+	assert.Error(t, ovsVsctl("show"), "missing ovs-vsctl")
+}
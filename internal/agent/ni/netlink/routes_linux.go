@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package netlink
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// SyncRoutes applies the current gateway and host routes of the port's subnets to the port
+// plugged into the namespace, Neutron does not notify about subnet changes.
+func (ns *LinuxNetworkNamespace) SyncRoutes(ctx context.Context, port *ports.Port, client *gophercloud.ServiceClient) error {
+	handle, err := netlink.NewHandleAt(ns.newns)
+	if err != nil {
+		return fmt.Errorf("failed to get handle for namespace '%s': %w", ns.name, err)
+	}
+	defer handle.Close()
+
+	link, err := portLink(handle, port.ID[:11])
+	if err != nil {
+		return err
+	}
+	portSubnets, err := getPortSubnets(ctx, client, port)
+	if err != nil {
+		return err
+	}
+	return syncPortRoutes(handle, link, portSubnets)
+}
+
+// syncPortRoutes source routes the addresses of the link via its table and syncs the subnet
+// routes into it. Ports plugged before source routing, such as legacy veth0 ports, have no
+// rules yet and would never look up the table otherwise.
+func syncPortRoutes(handle *netlink.Handle, link netlink.Link, portSubnets []*subnets.Subnet) error {
+	addrs, err := handle.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list addresses of '%s': %w", link.Attrs().Name, err)
+	}
+	for i := range addrs {
+		// link-local addresses are not routed
+		if addrs[i].Scope != int(netlink.SCOPE_UNIVERSE) {
+			continue
+		}
+		if err = addSourceRoute(handle, link, &addrs[i]); err != nil {
+			return err
+		}
+	}
+	return syncSubnetRoutes(handle, link, portSubnets)
+}
+
+// portLink returns the link of a port inside the namespace: the inner end of its veth pair or
+// its OVS internal port, whichever it was plugged as. Legacy namespaces name the inner end
+// veth0, it is found as the peer of the tap left in the host namespace.
+func portLink(handle *netlink.Handle, prefix string) (netlink.Link, error) {
+	for _, name := range []string{"ns" + prefix, "tap" + prefix} {
+		if link, err := handle.LinkByName(name); err == nil {
+			return link, nil
+		}
+	}
+	if tap, err := netlink.LinkByName("tap" + prefix); err == nil {
+		if veth, ok := tap.(*netlink.Veth); ok {
+			index, err := netlink.VethPeerIndex(veth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get peer of tap%s: %w", prefix, err)
+			}
+			if link, err := handle.LinkByIndex(index); err == nil {
+				return link, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("port %s is not plugged into the namespace", prefix)
+}
+
+// getPortSubnets returns the subnets of the fixed IPs of a port, in the order of the fixed IPs.
+func getPortSubnets(ctx context.Context, client *gophercloud.ServiceClient, port *ports.Port) ([]*subnets.Subnet, error) {
+	var portSubnets []*subnets.Subnet
+	for _, fixedIP := range port.FixedIPs {
+		if slices.ContainsFunc(portSubnets, func(s *subnets.Subnet) bool { return s.ID == fixedIP.SubnetID }) {
+			continue
+		}
+		subnet, err := subnets.Get(ctx, client, fixedIP.SubnetID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subnet %s: %w", fixedIP.SubnetID, err)
+		}
+		portSubnets = append(portSubnets, subnet)
+	}
+	return portSubnets, nil
+}
+
+// subnetRoutes returns the gateway and host routes of a subnet. A host route to the default
+// destination takes precedence over the gateway, as for instances configured by Neutron's DHCP.
+// Nexthops outside the subnet are flagged on-link.
+func subnetRoutes(subnet *subnets.Subnet) ([]netlink.Route, error) {
+	_, cidr, err := net.ParseCIDR(subnet.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CIDR of subnet %s: %w", subnet.ID, err)
+	}
+
+	var routes []netlink.Route
+	addRoute := func(destination, nexthop string) error {
+		_, dst, err := net.ParseCIDR(destination)
+		if err != nil {
+			return fmt.Errorf("failed to parse route destination '%s' of subnet %s: %w", destination, subnet.ID, err)
+		}
+		gw := net.ParseIP(nexthop)
+		if gw == nil {
+			return fmt.Errorf("failed to parse nexthop '%s' of subnet %s", nexthop, subnet.ID)
+		}
+		route := netlink.Route{Dst: dst, Gw: gw}
+		if !cidr.Contains(gw) {
+			route.SetFlag(netlink.FLAG_ONLINK)
+		}
+		routes = append(routes, route)
+		return nil
+	}
+
+	defaultDst := "0.0.0.0/0"
+	if cidr.IP.To4() == nil {
+		defaultDst = "::/0"
+	}
+	for _, hostRoute := range subnet.HostRoutes {
+		if err = addRoute(hostRoute.DestinationCIDR, hostRoute.NextHop); err != nil {
+			return nil, err
+		}
+	}
+	if subnet.GatewayIP != "" && !slices.ContainsFunc(subnet.HostRoutes, func(r subnets.HostRoute) bool {
+		return r.DestinationCIDR == defaultDst
+	}) {
+		if err = addRoute(defaultDst, subnet.GatewayIP); err != nil {
+			return nil, err
+		}
+	}
+	return routes, nil
+}
+
+// syncSubnetRoutes replaces the gateway and host routes in the source routing table of the
+// link with the ones of the subnets, routes removed from the subnets are deleted. The on-link
+// routes of the addresses are left alone.
+func syncSubnetRoutes(handle *netlink.Handle, link netlink.Link, portSubnets []*subnets.Subnet) error {
+	table := sourceRouteTableOffset + link.Attrs().Index
+
+	wanted := make(map[string]struct{})
+	for _, subnet := range portSubnets {
+		routes, err := subnetRoutes(subnet)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			route.LinkIndex = link.Attrs().Index
+			route.Table = table
+			if err = handle.RouteReplace(&route); err != nil {
+				return fmt.Errorf("failed to add route %s via %s to table %d: %w", route.Dst, route.Gw, table, err)
+			}
+			wanted[routeKey(route)] = struct{}{}
+		}
+	}
+
+	existing, err := handle.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("failed to list routes of table %d: %w", table, err)
+	}
+	for _, route := range existing {
+		if route.Gw == nil {
+			continue
+		}
+		if _, ok := wanted[routeKey(route)]; ok {
+			continue
+		}
+		log.Infof("deleting stale route %s via %s from table %d", route.Dst, route.Gw, table)
+		if err = handle.RouteDel(&route); err != nil {
+			return fmt.Errorf("failed to delete route %s via %s from table %d: %w", route.Dst, route.Gw, table, err)
+		}
+	}
+	return nil
+}
+
+// routeKey identifies a gateway route by destination and nexthop. Default routes may be listed
+// without destination.
+func routeKey(route netlink.Route) string {
+	dst := route.Dst
+	if dst == nil {
+		dst = &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+		if route.Gw.To4() == nil {
+			dst = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+		}
+	}
+	return dst.String() + " via " + route.Gw.String()
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package netlink
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/subnets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+// routeStrings formats routes as "<dst> via <gw>", with " onlink" appended if flagged.
+func routeStrings(routes []netlink.Route) []string {
+	var s []string
+	for _, route := range routes {
+		r := routeKey(route)
+		if route.Flags&int(netlink.FLAG_ONLINK) != 0 {
+			r += " onlink"
+		}
+		s = append(s, r)
+	}
+	return s
+}
+
+func TestSubnetRoutes(t *testing.T) {
+	subnet := &subnets.Subnet{
+		ID:        "subnet",
+		CIDR:      "10.180.0.0/24",
+		GatewayIP: "10.180.0.1",
+		HostRoutes: []subnets.HostRoute{
+			{DestinationCIDR: "10.200.0.0/16", NextHop: "10.180.0.254"},
+			{DestinationCIDR: "10.210.0.0/16", NextHop: "10.190.0.1"},
+		},
+	}
+	routes, err := subnetRoutes(subnet)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"10.200.0.0/16 via 10.180.0.254",
+		"10.210.0.0/16 via 10.190.0.1 onlink",
+		"0.0.0.0/0 via 10.180.0.1",
+	}, routeStrings(routes))
+
+	subnet.HostRoutes = []subnets.HostRoute{{DestinationCIDR: "0.0.0.0/0", NextHop: "10.180.0.2"}}
+	routes, err = subnetRoutes(subnet)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.0.0.0/0 via 10.180.0.2"}, routeStrings(routes),
+		"a default host route replaces the gateway")
+
+	routes, err = subnetRoutes(&subnets.Subnet{ID: "v6", CIDR: "2001:db8::/64", GatewayIP: "2001:db8::1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"::/0 via 2001:db8::1"}, routeStrings(routes))
+
+	routes, err = subnetRoutes(&subnets.Subnet{ID: "isolated", CIDR: "10.180.0.0/24"})
+	require.NoError(t, err)
+	assert.Empty(t, routes, "subnets without gateway get no default route")
+
+	_, err = subnetRoutes(&subnets.Subnet{ID: "invalid", CIDR: "10.180.0.0/24",
+		HostRoutes: []subnets.HostRoute{{DestinationCIDR: "10.200.0.0/16", NextHop: "invalid"}}})
+	assert.Error(t, err)
+}
+
+func TestRouteKey(t *testing.T) {
+	gw := netlink.Route{Gw: []byte{10, 180, 0, 1}}
+	assert.Equal(t, "0.0.0.0/0 via 10.180.0.1", routeKey(gw), "default routes may be listed without destination")
+}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
@@ -57,12 +59,17 @@ func (a *Agent) SetupOpenStack() error {
 	return nil
 }
 
-func (a *Agent) EnableInjection(ctx context.Context, si *models.ServiceInjection) error {
+// EnableInjection plugs the endpoint port into the namespace of its network and applies
+// the injections of all endpoints in the network.
+func (a *Agent) EnableInjection(ctx context.Context, si *models.ServiceInjection, injections []*models.ServiceInjection) error {
 	injectorPort, err := ports.Get(ctx, a.neutron.ServiceClient, si.PortId.String()).Extract()
 	if err != nil {
 		return fmt.Errorf("failed to get port %s: %w", si.PortId, err)
 	}
 
+	a.injectionMu.Lock()
+	defer a.injectionMu.Unlock()
+
 	// Create network namespace with ip/mac
 	ns := netlink.NewNetworkNamespace()
 	defer func() { _ = ns.Close() }()
@@ -71,25 +78,83 @@ func (a *Agent) EnableInjection(ctx context.Context, si *models.ServiceInjection
 		return fmt.Errorf("failed to ensure network namespace: %w", err)
 	}
 
-	if a.haproxy.IsRunning(injectorPort.NetworkID) {
-		// Nothing to do
+	return a.applyInjections(ns, si.Network, injections)
+}
+
+// DisableInjection applies the remaining injections of the endpoint's network.
+func (a *Agent) DisableInjection(si *models.ServiceInjection, injections []*models.ServiceInjection) error {
+	// Don't delete the namespace, the endpoint port is left plugged.
+	// The namespace will be cleaned up when the Neutron port is deleted,
+	// or reused if another endpoint is created for this network.
+	// Use the Network field directly instead of fetching the port from Neutron.
+	// This allows cleanup to proceed even if the port was manually deleted.
+	return a.UpdateInjections(si.Network, injections)
+}
+
+// UpdateInjections applies the injections of a network whose namespace exists already.
+func (a *Agent) UpdateInjections(networkID strfmt.UUID, injections []*models.ServiceInjection) error {
+	a.injectionMu.Lock()
+	defer a.injectionMu.Unlock()
+
+	ns := netlink.NewNetworkNamespace()
+	defer func() { _ = ns.Close() }()
+
+	if len(injections) > 0 {
+		if err := ns.OpenNetworkNamespace(networkID.String()); err != nil {
+			return fmt.Errorf("failed to open network namespace: %w", err)
+		}
+	}
+
+	return a.applyInjections(ns, networkID, injections)
+}
+
+// applyInjections reconciles the proxies and the HAProxy instance of a network with the
+// injections of all its endpoints: one proxy set per service, one HAProxy instance
+// serving all endpoints. Without injections both are stopped.
+func (a *Agent) applyInjections(ns netlink.Netlink, networkID strfmt.UUID, injections []*models.ServiceInjection) error {
+	// Service ports to proxy, the union of the listeners of all endpoints of a service
+	services := make(map[strfmt.UUID]*models.ServiceInjection)
+	servicePorts := make(map[strfmt.UUID][]int32)
+	for _, si := range injections {
+		services[si.ServiceID] = si
+		for _, listener := range si.Listeners() {
+			servicePorts[si.ServiceID] = append(servicePorts[si.ServiceID], listener.ServicePort)
+		}
+	}
+
+	for _, serviceID := range a.proxyManager.Services(networkID) {
+		if _, ok := services[serviceID]; !ok {
+			a.proxyManager.StopProxy(networkID, serviceID)
+		}
+	}
+
+	if len(injections) == 0 {
+		if a.haproxy.IsRunning(networkID.String()) {
+			if err := a.haproxy.RemoveInstance(networkID.String()); err != nil {
+				return fmt.Errorf("failed to remove haproxy instance: %w", err)
+			}
+		}
 		return nil
 	}
 
-	// Ensure the per-network directory exists (holds the proxy socket + HAProxy
-	// files) and start the unprivileged proxy for this network's service before
-	// HAProxy, whose backend connects to the proxy socket.
-	if err = os.MkdirAll(proxy.GetNetworkDir(si.Network.String()), 0o777); err != nil {
+	// Ensure the per-network directory exists (holds the proxy sockets + HAProxy
+	// files) and start the unprivileged proxies of the network's services before
+	// HAProxy, whose backends connect to the proxy sockets.
+	if err := os.MkdirAll(proxy.GetNetworkDir(networkID.String()), 0o777); err != nil {
 		return fmt.Errorf("failed to create network dir: %w", err)
 	}
-	ports := make([]int32, len(si.ServicePorts))
-	for i, p := range si.ServicePorts {
-		ports[i] = int32(p)
+	for serviceID, si := range services {
+		ports := servicePorts[serviceID]
+		slices.Sort(ports)
+		upstreams := make([]string, 0, len(si.ServiceIPAddresses))
+		for _, ip := range si.ServiceIPAddresses {
+			upstreams = append(upstreams, string(ip))
+		}
+		a.proxyManager.StartProxy(networkID, serviceID, upstreams, slices.Compact(ports))
 	}
-	a.proxyManager.StartProxy(si.Network, si.ServiceIPAddress, ports)
 
 	// Run haproxy inside network namespace
-	if err = ns.EnableNetworkNamespace(); err != nil {
+	if err := ns.EnableNetworkNamespace(); err != nil {
 		return fmt.Errorf("failed to enable network namespace: %w", err)
 	}
 	defer func() {
@@ -98,34 +163,16 @@ func (a *Agent) EnableInjection(ctx context.Context, si *models.ServiceInjection
 		}
 	}()
 
-	if err = a.haproxy.AddInstance(si); err != nil {
+	if err := a.haproxy.AddInstance(networkID.String(), injections); err != nil {
 		log.Errorf("Error enabling haproxy: %s, dumping conf", err)
-		haproxy.Dump(haproxy.GetConfigFilePath(si.Network.String()))
-		haproxy.TryRemoveFile(haproxy.GetConfigFilePath(si.Network.String()))
+		haproxy.Dump(haproxy.GetConfigFilePath(networkID.String()))
+		haproxy.TryRemoveFile(haproxy.GetConfigFilePath(networkID.String()))
 		return fmt.Errorf("failed to add haproxy instance: %w", err)
 	}
 
 	return nil
 }
 
-func (a *Agent) DisableInjection(si *models.ServiceInjection) error {
-	// Use the Network field directly instead of fetching from Neutron.
-	// This allows cleanup to proceed even if the port was manually deleted.
-	networkID := si.Network.String()
-
-	// Only stop haproxy - don't delete the namespace.
-	// Other endpoints may still be using this network's namespace.
-	// The namespace will be cleaned up when the Neutron port is deleted,
-	// or reused if another endpoint is created for this network.
-	if a.haproxy.IsRunning(networkID) {
-		if err := a.haproxy.RemoveInstance(networkID); err != nil {
-			return fmt.Errorf("failed to remove haproxy instance: %w", err)
-		}
-	}
-	a.proxyManager.StopProxy(si.Network)
-	return nil
-}
-
 func (a *Agent) CollectStats() {
 	a.haproxy.CollectStats()
 }
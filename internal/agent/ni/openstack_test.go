@@ -28,7 +28,7 @@ import (
 
 // noopStartProc is a proxy.StartProc that never spawns socat; it blocks until
 // the manager cancels it, so agent tests need no socat/root.
-func noopStartProc(ctx context.Context, _ strfmt.UUID, _ string, _ []int32) error {
+func noopStartProc(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
 	<-ctx.Done()
 	return nil
 }
@@ -136,11 +136,11 @@ func TestAgent_EnableInjection_PortNotFound(t *testing.T) {
 	si := &models.ServiceInjection{
 		PortId:          strfmt.UUID(portID),
 		Network:         networkID,
-		ServicePorts:    []int{80},
+		ServicePorts:    []int32{80},
 		ServiceProtocol: "tcp",
 	}
 
-	err := agent.EnableInjection(t.Context(), si)
+	err := agent.EnableInjection(t.Context(), si, []*models.ServiceInjection{si})
 	assert.Error(t, err)
 }
 
@@ -169,14 +169,15 @@ func TestAgent_EnableInjection_Success(t *testing.T) {
 	fixture.SetupHandler(t, fakeServer, "/v2.0/ports/"+si.PortId.String(), "GET",
 		"", portFixture, http.StatusOK)
 
-	assert.NoError(t, a.EnableInjection(t.Context(), si))
+	injections := []*models.ServiceInjection{si}
+	assert.NoError(t, a.EnableInjection(t.Context(), si, injections))
 
 	a.haproxy.(*haproxy.FakeHaproxy).AddInstanceReturnError = fmt.Errorf("haproxy error")
-	assert.Error(t, a.EnableInjection(t.Context(), si))
+	assert.Error(t, a.EnableInjection(t.Context(), si, injections))
 
 	a.haproxy.(*haproxy.FakeHaproxy).Running = true
 	a.haproxy.(*haproxy.FakeHaproxy).AddInstanceReturnError = nil
-	assert.NoError(t, a.EnableInjection(t.Context(), si))
+	assert.NoError(t, a.EnableInjection(t.Context(), si, injections))
 }
 
 func TestAgent_DisableInjection_PortNotFound(t *testing.T) {
@@ -194,11 +195,11 @@ func TestAgent_DisableInjection_PortNotFound(t *testing.T) {
 	si := &models.ServiceInjection{
 		PortId:          strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"),
 		Network:         networkID,
-		ServicePorts:    []int{80},
+		ServicePorts:    []int32{80},
 		ServiceProtocol: "tcp",
 	}
 
-	err := agent.DisableInjection(si)
+	err := agent.DisableInjection(si, nil)
 	assert.NoError(t, err)
 }
 
@@ -212,10 +213,64 @@ func TestAgent_DisableInjection(t *testing.T) {
 		Network: strfmt.UUID("660e8400-e29b-41d4-a716-446655440000"),
 	}
 
-	assert.NoError(t, a.DisableInjection(si))
+	assert.NoError(t, a.DisableInjection(si, nil))
 
 	a.haproxy.(*haproxy.FakeHaproxy).Running = true
-	assert.NoError(t, a.DisableInjection(si))
+	assert.NoError(t, a.DisableInjection(si, nil))
+}
+
+func TestAgent_Injection_MultipleServices(t *testing.T) {
+	fakeServer := th.SetupHTTP()
+	defer fakeServer.Teardown()
+
+	portFixture := `{
+		"port": {
+			"id": "550e8400-e29b-41d4-a716-446655440001",
+			"network_id": "660e8400-e29b-41d4-a716-446655440000",
+			"tenant_id": "26a7980765d0414dbc1fc1f88cdb7e6e"
+        }
+}`
+
+	config.Global.Agent.RunDir = t.TempDir()
+	fakeHaproxy := haproxy.NewFakeHaproxy()
+	a := &Agent{
+		neutron:      &neutron.NeutronClient{ServiceClient: fake.ServiceClient(fakeServer)},
+		haproxy:      fakeHaproxy,
+		proxyManager: proxy.NewManager(t.Context(), noopStartProc),
+	}
+
+	// Two endpoints of different services in the same network
+	networkID := strfmt.UUID("660e8400-e29b-41d4-a716-446655440000")
+	first := &models.ServiceInjection{
+		PortId:       "550e8400-e29b-41d4-a716-446655440000",
+		Network:      networkID,
+		ServiceID:    "770e8400-e29b-41d4-a716-446655440000",
+		ServicePorts: []int32{80},
+	}
+	second := &models.ServiceInjection{
+		PortId:       "550e8400-e29b-41d4-a716-446655440001",
+		Network:      networkID,
+		ServiceID:    "770e8400-e29b-41d4-a716-446655440001",
+		ServicePorts: []int32{443},
+	}
+	fixture.SetupHandler(t, fakeServer, "/v2.0/ports/"+second.PortId.String(), "GET",
+		"", portFixture, http.StatusOK)
+
+	// The second endpoint is wired up although HAProxy already runs for the network
+	fakeHaproxy.Running = true
+	injections := []*models.ServiceInjection{first, second}
+	assert.NoError(t, a.EnableInjection(t.Context(), second, injections))
+	assert.Equal(t, injections, fakeHaproxy.Injections)
+	assert.Equal(t, []strfmt.UUID{first.ServiceID, second.ServiceID}, a.proxyManager.Services(networkID))
+
+	// Removing the first endpoint keeps serving the second one
+	assert.NoError(t, a.DisableInjection(first, []*models.ServiceInjection{second}))
+	assert.Equal(t, []*models.ServiceInjection{second}, fakeHaproxy.Injections)
+	assert.Equal(t, []strfmt.UUID{second.ServiceID}, a.proxyManager.Services(networkID))
+
+	// Removing the last endpoint stops all proxies
+	assert.NoError(t, a.DisableInjection(second, nil))
+	assert.Empty(t, a.proxyManager.Services(networkID))
 }
 
 func TestAgent_CollectStats(t *testing.T) {
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package procfs reads the process information of the NI agent's HAProxy and socat
+// processes from /proc, used to find processes left running by a previous agent.
+package procfs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Root is the mount point of the proc filesystem; a var so tests can point it elsewhere.
+var Root = "/proc"
+
+// Pids returns the IDs of all running processes.
+func Pids() ([]int, error) {
+	entries, err := os.ReadDir(Root)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
+
+// Comm returns the command name of a process, empty if it does not exist.
+func Comm(pid int) string {
+	comm, err := os.ReadFile(fmt.Sprintf("%s/%d/comm", Root, pid))
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(comm))
+}
+
+// Cmdline returns the command line arguments of a process.
+func Cmdline(pid int) []string {
+	cmdline, err := os.ReadFile(fmt.Sprintf("%s/%d/cmdline", Root, pid))
+	if err != nil || len(cmdline) == 0 {
+		return nil
+	}
+	return strings.Split(string(bytes.TrimRight(cmdline, "\x00")), "\x00")
+}
+
+// Parent returns the parent process ID of a process, 0 if it does not exist.
+func Parent(pid int) int {
+	stat, err := os.ReadFile(fmt.Sprintf("%s/%d/stat", Root, pid))
+	if err != nil {
+		return 0
+	}
+
+	// The command name in parentheses may contain spaces, the fields after it are
+	// state and parent process ID.
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	return ppid
+}
+
+// NetworkNamespace returns the network namespace inode of a process, 0 if unknown.
+func NetworkNamespace(pid int) uint64 {
+	info, err := os.Stat(fmt.Sprintf("%s/%d/ns/net", Root, pid))
+	if err != nil {
+		return 0
+	}
+	return inode(info)
+}
+
+// Inode returns the inode number of a file, 0 if it does not exist.
+func Inode(path string) uint64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return inode(info)
+}
+
+func inode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package procfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProcess creates the /proc entries of a process below a temporary Root.
+func fakeProcess(t *testing.T, pid, comm, stat, cmdline string) {
+	t.Helper()
+	dir := filepath.Join(Root, pid)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o644))
+}
+
+func TestProcfs(t *testing.T) {
+	orig := Root
+	defer func() { Root = orig }()
+	Root = t.TempDir()
+
+	fakeProcess(t, "42", "haproxy", "42 (haproxy) S 1 42 42 0 -1 4194624",
+		"/usr/sbin/haproxy\x00-f\x00/run/archer/net/haproxy.conf\x00")
+	fakeProcess(t, "43", "socat", "43 (so cat) (x) S 42 42 42 0 -1 4194624", "")
+	require.NoError(t, os.MkdirAll(filepath.Join(Root, "self"), 0o755))
+
+	pids, err := Pids()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{42, 43}, pids)
+
+	assert.Equal(t, "haproxy", Comm(42))
+	assert.Empty(t, Comm(44))
+	assert.Equal(t, []string{"/usr/sbin/haproxy", "-f", "/run/archer/net/haproxy.conf"}, Cmdline(42))
+	assert.Nil(t, Cmdline(43))
+	assert.Equal(t, 1, Parent(42))
+	assert.Equal(t, 42, Parent(43), "command names with spaces and parentheses are skipped")
+	assert.Equal(t, 0, Parent(44))
+}
+
+func TestNetworkNamespace(t *testing.T) {
+	if _, err := os.Stat("/proc/self/ns/net"); err != nil {
+		t.Skip("no network namespaces")
+	}
+	assert.NotZero(t, NetworkNamespace(os.Getpid()))
+	assert.Equal(t, Inode("/proc/self/ns/net"), NetworkNamespace(os.Getpid()))
+	assert.Zero(t, Inode("/nonexistent"))
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+// Replies of the health sockets, HAProxy's tcp-check expects HealthUp.
+const (
+	HealthUp   = "UP\n"
+	HealthDown = "DOWN\n"
+)
+
+// GetHealthSocketName returns the file name of the health socket of a service upstream and
+// port: <service>-<port>-<upstream>.chk.
+func GetHealthSocketName(serviceID string, upstream int, port int32) string {
+	return fmt.Sprintf("%s-%d-%d.chk", serviceID, port, upstream)
+}
+
+// GetHealthSocketPath returns the host path of the health socket of a service upstream and port.
+func GetHealthSocketPath(networkID string, serviceID string, upstream int, port int32) string {
+	return fmt.Sprintf("%s/%s", GetNetworkDir(networkID), GetHealthSocketName(serviceID, upstream, port))
+}
+
+// withHealthSockets runs the health sockets of a service alongside its proxies. HAProxy in the
+// injection namespace cannot reach the upstreams and the proxy sockets accept connections
+// regardless of the upstream, so checking the health sockets is how HAProxy detects failed
+// upstreams. Both are restarted together when either exits.
+func withHealthSockets(startProc StartProc) StartProc {
+	return func(ctx context.Context, networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errCh := make(chan error, 1)
+		go func() {
+			// a failed health socket would mark every upstream down, restart the proxies with it
+			defer cancel()
+			errCh <- runHealthSockets(ctx, networkID, serviceID, upstreams, ports)
+		}()
+		err := startProc(ctx, networkID, serviceID, upstreams, ports)
+		cancel()
+		if healthErr := <-errCh; err == nil {
+			err = healthErr
+		}
+		return err
+	}
+}
+
+// runHealthSockets listens on the health sockets of every upstream and port of a service. Each
+// connection is answered with HealthUp if the upstream accepts a TCP connection on the port and
+// HealthDown otherwise. It blocks until ctx is cancelled or any listener fails.
+func runHealthSockets(ctx context.Context, networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) error {
+	cred, err := lookupRunUser()
+	if err != nil {
+		return fmt.Errorf("failed to look up run user %s: %w", config.Global.Agent.RunUser, err)
+	}
+
+	var listeners []net.Listener
+	defer func() {
+		for _, ln := range listeners {
+			_ = ln.Close()
+		}
+	}()
+
+	errCh := make(chan error, len(upstreams)*len(ports))
+	for i, upstream := range upstreams {
+		for _, port := range ports {
+			path := GetHealthSocketPath(networkID.String(), serviceID.String(), i, port)
+			ln, err := listenUnix(path)
+			if err != nil {
+				return err
+			}
+			listeners = append(listeners, ln)
+
+			address := net.JoinHostPort(upstream, strconv.Itoa(int(port)))
+			go func() {
+				errCh <- healthLoop(ln, address, cred)
+			}()
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err = <-errCh:
+		return err
+	}
+}
+
+// healthLoop answers the health checks of a listener until it is closed.
+func healthLoop(ln net.Listener, address string, cred *credential) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go checkUpstream(conn, address, cred)
+	}
+}
+
+// checkUpstream answers a health check with the reachability of the upstream.
+func checkUpstream(client net.Conn, address string, cred *credential) {
+	defer func() { _ = client.Close() }()
+
+	reply := HealthUp
+	upstream, err := dialUpstream(address, cred)
+	if err != nil {
+		log.WithError(err).Debugf("proxyhealth: upstream %s is down", address)
+		reply = HealthDown
+	} else {
+		_ = upstream.Close()
+	}
+	_, _ = client.Write([]byte(reply))
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+// readHealth returns the reply of a health socket.
+func readHealth(t *testing.T, path string) string {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRunHealthSockets(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440004")
+	setupRelayTest(t, networkID)
+	upPort := startEchoServer(t)
+
+	// a port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	downPort := int32(ln.Addr().(*net.TCPAddr).Port)
+	_ = ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- runHealthSockets(ctx, networkID, serviceID, []string{"127.0.0.1"}, []int32{upPort, downPort})
+	}()
+
+	upPath := GetHealthSocketPath(networkID.String(), serviceID.String(), 0, upPort)
+	downPath := GetHealthSocketPath(networkID.String(), serviceID.String(), 0, downPort)
+	waitForSocket(t, upPath)
+	waitForSocket(t, downPath)
+	assert.Equal(t, HealthUp, readHealth(t, upPath))
+	assert.Equal(t, HealthDown, readHealth(t, downPath))
+
+	cancel()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runHealthSockets should return once cancelled")
+	}
+}
+
+func TestWithHealthSockets(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440005")
+	setupRelayTest(t, networkID)
+	port := startEchoServer(t)
+
+	// the health sockets are torn down together with the proxies
+	exit := make(chan error)
+	startProc := withHealthSockets(func(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
+		return <-exit
+	})
+	done := make(chan error)
+	go func() {
+		done <- startProc(context.Background(), networkID, serviceID, []string{"127.0.0.1"}, []int32{port})
+	}()
+
+	path := GetHealthSocketPath(networkID.String(), serviceID.String(), 0, port)
+	waitForSocket(t, path)
+	exit <- errors.New("proxy exited")
+	select {
+	case err := <-done:
+		require.EqualError(t, err, "proxy exited")
+	case <-time.After(2 * time.Second):
+		t.Fatal("the health sockets should stop with the proxies")
+	}
+	_, err := net.Dial("unix", path)
+	assert.Error(t, err, "nothing listens anymore")
+
+	// the proxies are torn down together with failed health sockets
+	config.Global.Agent.RunUser = "archer-unknown-user"
+	startProc = withHealthSockets(func(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
+		<-ctx.Done()
+		return nil
+	})
+	go func() {
+		done <- startProc(context.Background(), networkID, serviceID, []string{"127.0.0.1"}, []int32{port})
+	}()
+	select {
+	case err = <-done:
+		assert.ErrorContains(t, err, "failed to look up run user archer-unknown-user")
+	case <-time.After(2 * time.Second):
+		t.Fatal("the proxies should stop with the health sockets")
+	}
+}
+
+func TestManager_HealthSockets(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440006")
+	setupRelayTest(t, networkID)
+	origInterval := config.Global.Agent.HealthCheckInterval
+	defer func() { config.Global.Agent.HealthCheckInterval = origInterval }()
+	config.Global.Agent.HealthCheckInterval = 5 * time.Second
+	port := startEchoServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx, func(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
+		<-ctx.Done()
+		return nil
+	})
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{port})
+
+	path := GetHealthSocketPath(networkID.String(), serviceID.String(), 0, port)
+	waitForSocket(t, path)
+	assert.Equal(t, HealthUp, readHealth(t, path))
+
+	m.StopProxy(networkID, serviceID)
+	assert.NoFileExists(t, path, "stopping removes the health socket")
+}
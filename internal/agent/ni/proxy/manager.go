@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/sapcc/archer/v2/internal/agent/ni/procfs"
 	"github.com/sapcc/archer/v2/internal/config"
 )
 
@@ -22,74 +26,105 @@ func GetNetworkDir(networkID string) string {
 	return fmt.Sprintf("%s/%s", config.Global.Agent.RunDir, networkID)
 }
 
-// GetSocketPath returns the host path of a proxy socket: /run/archer/<network>/<port>.sock.
-func GetSocketPath(networkID string, port int32) string {
-	return fmt.Sprintf("%s/%d.sock", GetNetworkDir(networkID), port)
+// GetSocketName returns the file name of the proxy socket of a service upstream (index into
+// the upstream IPs) and port: <service>-<port>-<upstream>.sock.
+func GetSocketName(serviceID string, upstream int, port int32) string {
+	return fmt.Sprintf("%s-%d-%d.sock", serviceID, port, upstream)
+}
+
+// GetSocketPath returns the host path of the proxy socket of a service upstream and port:
+// /run/archer/<network>/<service>-<port>-<upstream>.sock.
+func GetSocketPath(networkID string, serviceID string, upstream int, port int32) string {
+	return fmt.Sprintf("%s/%s", GetNetworkDir(networkID), GetSocketName(serviceID, upstream, port))
 }
 
 // restartBackoffForTest is the supervisor restart delay; a var so tests can shorten it.
 var restartBackoffForTest = 2 * time.Second
 
-// networkProxy holds the cancel function for a network's supervised socat processes.
+// proxyKey identifies the proxies of a service in a network.
+type proxyKey struct {
+	network strfmt.UUID
+	service strfmt.UUID
+}
+
+// networkProxy holds the cancel function for the supervised proxies of a service in a network.
 type networkProxy struct {
-	cancel context.CancelFunc
-	ports  []int32
+	cancel    context.CancelFunc
+	upstreams []string
+	ports     []int32
 }
 
-// StartProc runs the proxies for a network and blocks until they exit. The
-// default spawns socat; tests inject a stub to avoid needing socat/root.
-type StartProc func(ctx context.Context, networkID strfmt.UUID, upstreamIP string, ports []int32) error
+// StartProc runs the proxies of a service in a network and blocks until they exit. The
+// default spawns socat or relays in-process; tests inject a stub to avoid needing socat/root.
+type StartProc func(ctx context.Context, networkID, serviceID strfmt.UUID, upstreamIPs []string, ports []int32) error
 
-// Manager supervises unprivileged socat processes (one per port) per network.
+// Manager supervises the proxies (one per upstream IP and port) per service and network.
 type Manager struct {
 	mu        sync.RWMutex
-	proxies   map[strfmt.UUID]*networkProxy
+	proxies   map[proxyKey]*networkProxy
 	parentCtx context.Context
 	startProc StartProc
 }
 
-// NewManager creates a proxy manager. By default it spawns unprivileged socat
-// processes; pass a StartProc (e.g. in tests) to override how proxies are run.
+// NewManager creates a proxy manager. By default it spawns unprivileged socat processes or,
+// with proxy-mode relay, relays in-process; pass a StartProc (e.g. in tests) to override how
+// proxies are run. With health checks enabled, the health sockets are served alongside.
 func NewManager(ctx context.Context, startProc ...StartProc) *Manager {
 	m := &Manager{
-		proxies:   make(map[strfmt.UUID]*networkProxy),
+		proxies:   make(map[proxyKey]*networkProxy),
 		parentCtx: ctx,
 	}
-	if len(startProc) > 0 && startProc[0] != nil {
+	switch {
+	case len(startProc) > 0 && startProc[0] != nil:
 		m.startProc = startProc[0]
-	} else {
+	case config.Global.Agent.ProxyMode == "relay":
+		m.startProc = runRelay
+	default:
 		m.startProc = m.spawnSocat
 	}
+	if config.Global.Agent.HealthCheckInterval > 0 {
+		m.startProc = withHealthSockets(m.startProc)
+	}
 	return m
 }
 
-// StartProxy starts the supervised socat processes for a network; idempotent (no-op if already running, mirroring HAProxy).
-func (m *Manager) StartProxy(networkID strfmt.UUID, upstream string, ports []int32) {
+// StartProxy starts the supervised proxies for a service in a network; idempotent (no-op if
+// already running with the same upstreams and ports, the whole set is restarted if they changed).
+func (m *Manager) StartProxy(networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, ok := m.proxies[networkID]; ok {
-		return
+	key := proxyKey{networkID, serviceID}
+	if proxy, ok := m.proxies[key]; ok {
+		if slices.Equal(proxy.upstreams, upstreams) && slices.Equal(proxy.ports, ports) {
+			return
+		}
+		log.Infof("proxymanager: upstreams or ports of service %s in network %s changed, restarting",
+			serviceID, networkID)
+		m.stop(key, proxy)
 	}
 
 	ctx, cancel := context.WithCancel(m.parentCtx)
-	m.proxies[networkID] = &networkProxy{cancel: cancel, ports: ports}
+	m.proxies[key] = &networkProxy{cancel: cancel, upstreams: upstreams, ports: ports}
 
-	log.Infof("proxymanager: starting proxy for network %s, upstream=%s, ports=%v", networkID, upstream, ports)
-	go m.supervise(ctx, networkID, upstream, ports)
+	log.Infof("proxymanager: starting proxy for service %s in network %s, upstreams=%v, ports=%v",
+		serviceID, networkID, upstreams, ports)
+	go m.supervise(ctx, networkID, serviceID, upstreams, ports)
 }
 
 // supervise runs the proxies and restarts them with bounded backoff on unexpected exit, until ctx is cancelled.
-func (m *Manager) supervise(ctx context.Context, networkID strfmt.UUID, upstream string, ports []int32) {
+func (m *Manager) supervise(ctx context.Context, networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) {
 	for {
-		err := m.startProc(ctx, networkID, upstream, ports)
+		err := m.startProc(ctx, networkID, serviceID, upstreams, ports)
 		if ctx.Err() != nil {
 			return
 		}
 		if err != nil {
-			log.WithError(err).Errorf("proxymanager: proxy for network %s exited, restarting", networkID)
+			log.WithError(err).Errorf("proxymanager: proxy for service %s in network %s exited, restarting",
+				serviceID, networkID)
 		} else {
-			log.Warnf("proxymanager: proxy for network %s exited unexpectedly, restarting", networkID)
+			log.Warnf("proxymanager: proxy for service %s in network %s exited unexpectedly, restarting",
+				serviceID, networkID)
 		}
 		select {
 		case <-ctx.Done():
@@ -99,8 +134,8 @@ func (m *Manager) supervise(ctx context.Context, networkID strfmt.UUID, upstream
 	}
 }
 
-// spawnSocat runs one socat process per port and blocks until any exits (or ctx is cancelled).
-func (m *Manager) spawnSocat(ctx context.Context, networkID strfmt.UUID, upstream string, ports []int32) error {
+// spawnSocat runs one socat process per upstream and port and blocks until any exits (or ctx is cancelled).
+func (m *Manager) spawnSocat(ctx context.Context, networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) error {
 	socatPath, err := exec.LookPath("socat")
 	if err != nil {
 		return fmt.Errorf("socat binary not found in PATH: %w", err)
@@ -119,24 +154,27 @@ func (m *Manager) spawnSocat(ctx context.Context, networkID strfmt.UUID, upstrea
 	}
 
 	var wg sync.WaitGroup
-	errCh := make(chan error, len(ports))
-	for _, port := range ports {
-		// mode=0666 so HAProxy can connect without chown (which needs CAP_CHOWN); safe as the socket sits in the 0700-root per-network dir.
-		// No chroot: with fork each child re-applies options after su-d drops root, so its chroot() would EPERM — and socat only forwards bytes.
-		listen := fmt.Sprintf("UNIX-LISTEN:%s,fork,mode=0666,su-d=%s",
-			GetSocketPath(networkID.String(), port), config.Global.Agent.RunUser)
-		connect := fmt.Sprintf("TCP:%s:%d", upstream, port)
-
-		args := append(append([]string{}, verbosity...), listen, connect)
-		cmd := exec.CommandContext(ctx, socatPath, args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		log.Infof("proxymanager: exec %s", cmd.String())
-
-		wg.Go(func() {
-			defer cancel()
-			errCh <- cmd.Run()
-		})
+	errCh := make(chan error, len(upstreams)*len(ports))
+	for i, upstream := range upstreams {
+		for _, port := range ports {
+			// mode=0666 so HAProxy can connect without chown (which needs CAP_CHOWN); safe as the socket sits in the 0700-root per-network dir.
+			// No chroot: with fork each child re-applies options after su-d drops root, so its chroot() would EPERM — and socat only forwards bytes.
+			// unlink-early removes a stale socket left behind by a killed socat.
+			listen := fmt.Sprintf("UNIX-LISTEN:%s,fork,unlink-early,mode=0666,su-d=%s",
+				GetSocketPath(networkID.String(), serviceID.String(), i, port), config.Global.Agent.RunUser)
+			connect := fmt.Sprintf("TCP:%s:%d", upstream, port)
+
+			args := append(append([]string{}, verbosity...), listen, connect)
+			cmd := exec.CommandContext(ctx, socatPath, args...)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			log.Infof("proxymanager: exec %s", cmd.String())
+
+			wg.Go(func() {
+				defer cancel()
+				errCh <- cmd.Run()
+			})
+		}
 	}
 
 	wg.Wait()
@@ -149,39 +187,103 @@ func (m *Manager) spawnSocat(ctx context.Context, networkID strfmt.UUID, upstrea
 	return nil
 }
 
-// StopProxy stops the supervised socat processes for a network and removes its socket(s).
-func (m *Manager) StopProxy(networkID strfmt.UUID) {
+// StopProxy stops the supervised proxies for a service in a network and removes its socket(s).
+func (m *Manager) StopProxy(networkID, serviceID strfmt.UUID) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if proxy, ok := m.proxies[networkID]; ok {
-		log.Infof("proxymanager: stopping proxy for network %s", networkID)
-		proxy.cancel()
+	key := proxyKey{networkID, serviceID}
+	if proxy, ok := m.proxies[key]; ok {
+		log.Infof("proxymanager: stopping proxy for service %s in network %s", serviceID, networkID)
+		m.stop(key, proxy)
+	}
+
+	for key := range m.proxies {
+		if key.network == networkID {
+			return
+		}
+	}
+	deleteRelayMetrics(networkID.String())
+}
+
+// stop cancels the proxies of a service and removes its proxy and health sockets; m.mu must be held.
+func (m *Manager) stop(key proxyKey, proxy *networkProxy) {
+	proxy.cancel()
+	for i := range proxy.upstreams {
 		for _, p := range proxy.ports {
-			if err := os.Remove(GetSocketPath(networkID.String(), p)); err != nil && !os.IsNotExist(err) {
-				log.WithError(err).Warnf("proxymanager: failed to remove socket for network %s port %d", networkID, p)
+			for _, path := range []string{
+				GetSocketPath(key.network.String(), key.service.String(), i, p),
+				GetHealthSocketPath(key.network.String(), key.service.String(), i, p),
+			} {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					log.WithError(err).Warnf("proxymanager: failed to remove socket %s", path)
+				}
 			}
 		}
-		delete(m.proxies, networkID)
 	}
+	delete(m.proxies, key)
 }
 
-// IsRunning reports whether a proxy is supervised for a network.
-func (m *Manager) IsRunning(networkID strfmt.UUID) bool {
+// IsRunning reports whether a proxy is supervised for a service in a network.
+func (m *Manager) IsRunning(networkID, serviceID strfmt.UUID) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	_, ok := m.proxies[networkID]
+	_, ok := m.proxies[proxyKey{networkID, serviceID}]
 	return ok
 }
 
-// StopAll stops all supervised socat processes.
+// Services returns the sorted IDs of the services proxied in a network.
+func (m *Manager) Services(networkID strfmt.UUID) []strfmt.UUID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var services []strfmt.UUID
+	for key := range m.proxies {
+		if key.network == networkID {
+			services = append(services, key.service)
+		}
+	}
+	slices.Sort(services)
+	return services
+}
+
+// StopAll stops all supervised proxies.
 func (m *Manager) StopAll() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	for networkID, proxy := range m.proxies {
-		log.Debugf("proxymanager: stopping proxy for network %s", networkID)
+	for key, proxy := range m.proxies {
+		log.Debugf("proxymanager: stopping proxy for service %s in network %s", key.service, key.network)
 		proxy.cancel()
 	}
-	m.proxies = make(map[strfmt.UUID]*networkProxy)
+	m.proxies = make(map[proxyKey]*networkProxy)
+}
+
+// KillOrphans kills the socat listeners left running by a previous agent; they cannot be
+// supervised anymore and are restarted by the sync. Forked children keep serving their
+// connections until closed. It returns the number of processes killed.
+func KillOrphans() int {
+	pids, err := procfs.Pids()
+	if err != nil {
+		log.WithError(err).Warn("proxymanager: failed to list processes")
+		return 0
+	}
+
+	prefix := fmt.Sprintf("UNIX-LISTEN:%s/", config.Global.Agent.RunDir)
+	var killed int
+	for _, pid := range pids {
+		if procfs.Comm(pid) != "socat" || procfs.Comm(procfs.Parent(pid)) == "socat" {
+			continue
+		}
+		if !slices.ContainsFunc(procfs.Cmdline(pid), func(arg string) bool { return strings.HasPrefix(arg, prefix) }) {
+			continue
+		}
+		log.Infof("proxymanager: killing orphaned socat PID %d", pid)
+		if err = syscall.Kill(pid, syscall.SIGTERM); err != nil {
+			log.WithError(err).Warnf("proxymanager: failed to kill socat PID %d", pid)
+			continue
+		}
+		killed++
+	}
+	return killed
 }
@@ -5,16 +5,26 @@ package proxy
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/sapcc/archer/v2/internal/agent/ni/procfs"
 	"github.com/sapcc/archer/v2/internal/config"
 )
 
+const serviceID = strfmt.UUID("770e8400-e29b-41d4-a716-446655440000")
+
 // newStubManager returns a Manager whose startProc blocks until ctx is cancelled
 // and records how many times it was invoked per network, so supervision can be
 // tested without spawning real socat processes.
@@ -23,7 +33,7 @@ func newStubManager(ctx context.Context) (*Manager, func(strfmt.UUID) int) {
 
 	var mu sync.Mutex
 	runs := map[strfmt.UUID]int{}
-	m.startProc = func(ctx context.Context, networkID strfmt.UUID, _ string, _ []int32) error {
+	m.startProc = func(ctx context.Context, networkID, _ strfmt.UUID, _ []string, _ []int32) error {
 		mu.Lock()
 		runs[networkID]++
 		mu.Unlock()
@@ -46,7 +56,7 @@ func TestNewManager(t *testing.T) {
 
 func TestManager_IsRunning_Empty(t *testing.T) {
 	m := NewManager(context.Background())
-	assert.False(t, m.IsRunning(strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")))
+	assert.False(t, m.IsRunning(strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"), serviceID))
 }
 
 func TestManager_StartStop(t *testing.T) {
@@ -56,12 +66,12 @@ func TestManager_StartStop(t *testing.T) {
 	m, runs := newStubManager(ctx)
 	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
 
-	m.StartProxy(networkID, "127.0.0.1", []int32{18080, 18443})
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080, 18443})
 	assert.Eventually(t, func() bool { return runs(networkID) == 1 }, time.Second, 5*time.Millisecond)
-	assert.True(t, m.IsRunning(networkID))
+	assert.True(t, m.IsRunning(networkID, serviceID))
 
-	m.StopProxy(networkID)
-	assert.False(t, m.IsRunning(networkID))
+	m.StopProxy(networkID, serviceID)
+	assert.False(t, m.IsRunning(networkID, serviceID))
 }
 
 func TestManager_StartProxy_Idempotent(t *testing.T) {
@@ -71,25 +81,49 @@ func TestManager_StartProxy_Idempotent(t *testing.T) {
 	m, runs := newStubManager(ctx)
 	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
 
-	m.StartProxy(networkID, "127.0.0.1", []int32{18080})
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
 	assert.Eventually(t, func() bool { return runs(networkID) == 1 }, time.Second, 5*time.Millisecond)
 
-	// Second StartProxy for the same network is a no-op (endpoints in a network
-	// share one proxy set, mirroring HAProxy).
-	m.StartProxy(networkID, "127.0.0.1", []int32{18443})
+	// Second StartProxy for the same service and network is a no-op (endpoints
+	// of a service in a network share one proxy set).
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
 	time.Sleep(50 * time.Millisecond)
 
 	m.mu.RLock()
 	assert.Equal(t, 1, len(m.proxies))
-	assert.Equal(t, []int32{18080}, m.proxies[networkID].ports, "second StartProxy should not replace the ports")
 	m.mu.RUnlock()
 	assert.Equal(t, 1, runs(networkID), "second StartProxy should not re-invoke startProc")
 }
 
+func TestManager_StartProxy_Changed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, runs := newStubManager(ctx)
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
+
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
+	assert.Eventually(t, func() bool { return runs(networkID) == 1 }, time.Second, 5*time.Millisecond)
+
+	// Changed ports restart the proxy set
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080, 18443})
+	assert.Eventually(t, func() bool { return runs(networkID) == 2 }, time.Second, 5*time.Millisecond)
+
+	// Changed upstreams restart the proxy set
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.2"}, []int32{18080, 18443})
+	assert.Eventually(t, func() bool { return runs(networkID) == 3 }, time.Second, 5*time.Millisecond)
+
+	m.mu.RLock()
+	assert.Equal(t, 1, len(m.proxies))
+	assert.Equal(t, []string{"127.0.0.2"}, m.proxies[proxyKey{networkID, serviceID}].upstreams)
+	assert.Equal(t, []int32{18080, 18443}, m.proxies[proxyKey{networkID, serviceID}].ports)
+	m.mu.RUnlock()
+}
+
 func TestManager_StopProxy_NotRunning(t *testing.T) {
 	m := NewManager(context.Background())
 	assert.NotPanics(t, func() {
-		m.StopProxy(strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"))
+		m.StopProxy(strfmt.UUID("550e8400-e29b-41d4-a716-446655440000"), serviceID)
 	})
 }
 
@@ -104,13 +138,13 @@ func TestManager_StopAll(t *testing.T) {
 		"550e8400-e29b-41d4-a716-446655440003",
 	}
 	for _, id := range ids {
-		m.StartProxy(id, "127.0.0.1", []int32{18080})
+		m.StartProxy(id, serviceID, []string{"127.0.0.1"}, []int32{18080})
 	}
-	assert.Eventually(t, func() bool { return m.IsRunning(ids[0]) && m.IsRunning(ids[2]) }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return m.IsRunning(ids[0], serviceID) && m.IsRunning(ids[2], serviceID) }, time.Second, 5*time.Millisecond)
 
 	m.StopAll()
 	for _, id := range ids {
-		assert.False(t, m.IsRunning(id))
+		assert.False(t, m.IsRunning(id, serviceID))
 	}
 }
 
@@ -121,7 +155,7 @@ func TestManager_Supervise_Restarts(t *testing.T) {
 	m := NewManager(ctx)
 	var mu sync.Mutex
 	var count int
-	m.startProc = func(ctx context.Context, _ strfmt.UUID, _ string, _ []int32) error {
+	m.startProc = func(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
 		mu.Lock()
 		count++
 		mu.Unlock()
@@ -133,7 +167,7 @@ func TestManager_Supervise_Restarts(t *testing.T) {
 	defer func() { restartBackoffForTest = orig }()
 
 	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
-	m.StartProxy(networkID, "127.0.0.1", []int32{18080})
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
 
 	assert.Eventually(t, func() bool {
 		mu.Lock()
@@ -141,7 +175,7 @@ func TestManager_Supervise_Restarts(t *testing.T) {
 		return count >= 3
 	}, time.Second, 5*time.Millisecond, "supervisor should restart after unexpected exit")
 
-	m.StopProxy(networkID)
+	m.StopProxy(networkID, serviceID)
 }
 
 func TestManager_InjectedStartProc(t *testing.T) {
@@ -150,15 +184,15 @@ func TestManager_InjectedStartProc(t *testing.T) {
 
 	// NewManager accepts a StartProc override (used by callers' tests to avoid
 	// spawning socat).
-	m := NewManager(ctx, func(ctx context.Context, _ strfmt.UUID, _ string, _ []int32) error {
+	m := NewManager(ctx, func(ctx context.Context, _, _ strfmt.UUID, _ []string, _ []int32) error {
 		<-ctx.Done()
 		return nil
 	})
 	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
-	m.StartProxy(networkID, "127.0.0.1", []int32{18080})
-	assert.True(t, m.IsRunning(networkID))
-	m.StopProxy(networkID)
-	assert.False(t, m.IsRunning(networkID))
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
+	assert.True(t, m.IsRunning(networkID, serviceID))
+	m.StopProxy(networkID, serviceID)
+	assert.False(t, m.IsRunning(networkID, serviceID))
 }
 
 // TestGetSocketPaths pins the per-network path layout the HAProxy backend and
@@ -167,5 +201,65 @@ func TestGetSocketPaths(t *testing.T) {
 	config.Global.Agent.RunDir = "/run/archer"
 	net := "660e8400-e29b-41d4-a716-446655440000"
 	assert.Equal(t, "/run/archer/"+net, GetNetworkDir(net))
-	assert.Equal(t, "/run/archer/"+net+"/80.sock", GetSocketPath(net, 80))
+	assert.Equal(t, "/run/archer/"+net+"/"+serviceID.String()+"-80-0.sock",
+		GetSocketPath(net, serviceID.String(), 0, 80))
+	assert.Equal(t, "/run/archer/"+net+"/"+serviceID.String()+"-443-1.sock",
+		GetSocketPath(net, serviceID.String(), 1, 443))
+}
+
+func TestManager_Services(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, _ := newStubManager(ctx)
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440000")
+	otherServiceID := strfmt.UUID("770e8400-e29b-41d4-a716-446655440001")
+
+	// Several services share a network, each with its own proxy set.
+	m.StartProxy(networkID, otherServiceID, []string{"127.0.0.2"}, []int32{18443})
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{18080})
+	m.StartProxy("550e8400-e29b-41d4-a716-446655440001", serviceID, []string{"127.0.0.1"}, []int32{18080})
+	assert.Equal(t, []strfmt.UUID{serviceID, otherServiceID}, m.Services(networkID))
+
+	m.StopProxy(networkID, serviceID)
+	assert.Equal(t, []strfmt.UUID{otherServiceID}, m.Services(networkID))
+	assert.True(t, m.IsRunning("550e8400-e29b-41d4-a716-446655440001", serviceID))
+}
+
+func TestKillOrphans(t *testing.T) {
+	origRoot := procfs.Root
+	defer func() { procfs.Root = origRoot }()
+	procfs.Root = t.TempDir()
+	config.Global.Agent.RunDir = "/run/archer"
+
+	fakeProcess := func(pid int, comm string, ppid int, cmdline ...string) {
+		dir := filepath.Join(procfs.Root, strconv.Itoa(pid))
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"),
+			[]byte(fmt.Sprintf("%d (%s) S %d", pid, comm, ppid)), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"),
+			[]byte(strings.Join(cmdline, "\x00")+"\x00"), 0o644))
+	}
+
+	// The orphaned listener is a real process posing as socat
+	orphan := exec.Command("sleep", "60")
+	require.NoError(t, orphan.Start())
+	exited := make(chan error, 1)
+	go func() { exited <- orphan.Wait() }()
+
+	listen := "UNIX-LISTEN:/run/archer/net/svc-80-0.sock,fork,unlink-early,mode=0666,su-d=nobody"
+	fakeProcess(orphan.Process.Pid, "socat", 1, "/usr/bin/socat", "-d", listen, "TCP:10.0.0.1:80")
+	// its forked child serving a connection is left alone
+	fakeProcess(4242, "socat", orphan.Process.Pid, "/usr/bin/socat", "-d", listen, "TCP:10.0.0.1:80")
+	// socat not spawned by the agent
+	fakeProcess(4243, "socat", 1, "/usr/bin/socat", "UNIX-LISTEN:/tmp/other.sock", "TCP:10.0.0.1:80")
+
+	assert.Equal(t, 1, KillOrphans())
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		_ = orphan.Process.Kill()
+		t.Fatal("orphaned socat was not killed")
+	}
 }
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+var (
+	relayConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "archer_ni_relay_connections",
+		Help: "Counter of connections relayed to upstreams",
+	}, []string{"network", "outcome"})
+	relayActiveConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "archer_ni_relay_active_connections",
+		Help: "Number of open relayed connections",
+	}, []string{"network"})
+	relayBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "archer_ni_relay_bytes",
+		Help: "Counter of bytes relayed to (upstream) and from (downstream) upstreams",
+	}, []string{"network", "direction"})
+)
+
+// relayDialTimeout bounds connecting to an upstream; a var so tests can shorten it.
+var relayDialTimeout = 10 * time.Second
+
+// credential is the unprivileged user upstream connections are opened as, nil keeps the agent's.
+type credential struct {
+	uid, gid int
+}
+
+// lookupRunUser resolves the run user and its primary group, like socat's su-d.
+func lookupRunUser() (*credential, error) {
+	if config.Global.Agent.RunUser == "" {
+		return nil, nil
+	}
+	u, err := user.Lookup(config.Global.Agent.RunUser)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return nil, err
+	}
+	return &credential{uid: uid, gid: gid}, nil
+}
+
+// runRelay is the in-process alternative to spawnSocat: it listens on the unix sockets of every
+// upstream and port of a service and relays each connection to the upstream over TCP. It blocks
+// until ctx is cancelled or any listener fails; open connections are served until closed.
+func runRelay(ctx context.Context, networkID, serviceID strfmt.UUID, upstreams []string, ports []int32) error {
+	cred, err := lookupRunUser()
+	if err != nil {
+		return fmt.Errorf("failed to look up run user %s: %w", config.Global.Agent.RunUser, err)
+	}
+
+	var listeners []net.Listener
+	defer func() {
+		for _, ln := range listeners {
+			_ = ln.Close()
+		}
+	}()
+
+	errCh := make(chan error, len(upstreams)*len(ports))
+	for i, upstream := range upstreams {
+		for _, port := range ports {
+			path := GetSocketPath(networkID.String(), serviceID.String(), i, port)
+			ln, err := listenUnix(path)
+			if err != nil {
+				return err
+			}
+			listeners = append(listeners, ln)
+
+			address := net.JoinHostPort(upstream, strconv.Itoa(int(port)))
+			log.Infof("proxyrelay: relaying %s to %s", path, address)
+			go func() {
+				errCh <- acceptLoop(ln, networkID.String(), address, cred)
+			}()
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err = <-errCh:
+		return err
+	}
+}
+
+// listenUnix listens on a unix socket HAProxy can connect to. A stale socket is removed first;
+// mode 0666 avoids a chown, the socket sits in the 0700-root per-network dir. Closing does not
+// unlink the socket, a replaced relay closing late would remove its successor's; the manager
+// removes sockets when stopping.
+func listenUnix(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	ln.SetUnlinkOnClose(false)
+	if err = os.Chmod(path, 0o666); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// acceptLoop relays the connections of a listener until it is closed.
+func acceptLoop(ln net.Listener, networkID, address string, cred *credential) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go relay(conn, networkID, address, cred)
+	}
+}
+
+// relay connects a client to the upstream and copies in both directions until both are done.
+func relay(client net.Conn, networkID, address string, cred *credential) {
+	defer func() { _ = client.Close() }()
+
+	upstream, err := dialUpstream(address, cred)
+	if err != nil {
+		log.WithError(err).Warnf("proxyrelay: failed to connect to upstream %s of network %s", address, networkID)
+		relayConnections.WithLabelValues(networkID, "error").Inc()
+		return
+	}
+	defer func() { _ = upstream.Close() }()
+
+	log.Debugf("proxyrelay: relaying connection to upstream %s of network %s", address, networkID)
+	relayConnections.WithLabelValues(networkID, "success").Inc()
+	active := relayActiveConnections.WithLabelValues(networkID)
+	active.Inc()
+	defer active.Dec()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		pipe(upstream, client, relayBytes.WithLabelValues(networkID, "upstream"))
+	})
+	pipe(client, upstream, relayBytes.WithLabelValues(networkID, "downstream"))
+	wg.Wait()
+}
+
+// pipe copies src to dst and half-closes dst once src is drained, so either side can
+// finish sending and still receive. On errors both connections are torn down.
+func pipe(dst, src net.Conn, bytes prometheus.Counter) {
+	if _, err := io.Copy(&countingWriter{dst, bytes}, src); err != nil {
+		_ = dst.Close()
+		_ = src.Close()
+		return
+	}
+	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	} else {
+		_ = dst.Close()
+	}
+}
+
+// countingWriter adds the bytes written to a counter, so long-lived connections are accounted
+// continuously.
+type countingWriter struct {
+	w     io.Writer
+	bytes prometheus.Counter
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.bytes.Add(float64(n))
+	return n, err
+}
+
+// deleteRelayMetrics removes the metrics of a network that is no longer proxied.
+func deleteRelayMetrics(networkID string) {
+	labels := prometheus.Labels{"network": networkID}
+	relayConnections.DeletePartialMatch(labels)
+	relayActiveConnections.DeletePartialMatch(labels)
+	relayBytes.DeletePartialMatch(labels)
+}
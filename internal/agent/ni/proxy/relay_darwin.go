@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build darwin
+
+package proxy
+
+import (
+	"net"
+)
+
+// dialUpstream connects to the upstream; file system IDs are Linux only, the credential is ignored.
+func dialUpstream(address string, _ *credential) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: relayDialTimeout}
+	return dialer.Dial("tcp", address)
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package proxy
+
+import (
+	"net"
+	"runtime"
+	"syscall"
+)
+
+// dialUpstream connects to the upstream. With a credential the socket is created with the file
+// system user and group of the run user, so like socat's connections after su-d it is owned by
+// and filtered (e.g. iptables owner match) as the unprivileged user. The IDs are per thread, the
+// thread is locked until they are restored and discarded if restoring fails.
+func dialUpstream(address string, cred *credential) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: relayDialTimeout}
+	if cred == nil {
+		return dialer.Dial("tcp", address)
+	}
+
+	runtime.LockOSThread()
+	prevGID := setfsgid(cred.gid)
+	prevUID := setfsuid(cred.uid)
+	conn, err := dialer.Dial("tcp", address)
+	setfsuid(prevUID)
+	setfsgid(prevGID)
+	// setfs[ug]id returns the previous value, reapplying it reports whether the restore took effect
+	if setfsuid(prevUID) == prevUID && setfsgid(prevGID) == prevGID {
+		runtime.UnlockOSThread()
+	}
+	return conn, err
+}
+
+func setfsuid(uid int) int {
+	prev, _, _ := syscall.RawSyscall(syscall.SYS_SETFSUID, uintptr(uid), 0, 0)
+	return int(prev)
+}
+
+func setfsgid(gid int) int {
+	prev, _, _ := syscall.RawSyscall(syscall.SYS_SETFSGID, uintptr(gid), 0, 0)
+	return int(prev)
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialUpstream_Credential(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("changing the file system user requires root")
+	}
+	port := startEchoServer(t)
+	address := fmt.Sprintf("127.0.0.1:%d", port)
+
+	conn, err := dialUpstream(address, &credential{uid: 65534, gid: 65534})
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	// the socket inode is owned by the file system user it was created with
+	f, err := conn.(*net.TCPConn).File()
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	require.NoError(t, err)
+	stat := info.Sys().(*syscall.Stat_t)
+	assert.Equal(t, uint32(65534), stat.Uid)
+	assert.Equal(t, uint32(65534), stat.Gid)
+
+	// the agent's own sockets are unaffected
+	own, err := dialUpstream(address, nil)
+	require.NoError(t, err)
+	defer func() { _ = own.Close() }()
+	ownFile, err := own.(*net.TCPConn).File()
+	require.NoError(t, err)
+	defer func() { _ = ownFile.Close() }()
+	info, err = ownFile.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), info.Sys().(*syscall.Stat_t).Uid)
+}
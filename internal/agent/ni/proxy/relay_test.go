@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal/config"
+)
+
+// startEchoServer returns the port of a TCP server answering with everything it received
+// once the client half-closed the connection.
+func startEchoServer(t *testing.T) int32 {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				data, _ := io.ReadAll(conn)
+				_, _ = conn.Write(data)
+			}()
+		}
+	}()
+	return int32(ln.Addr().(*net.TCPAddr).Port)
+}
+
+func setupRelayTest(t *testing.T, networkID strfmt.UUID) {
+	t.Helper()
+	origDir, origUser := config.Global.Agent.RunDir, config.Global.Agent.RunUser
+	t.Cleanup(func() { config.Global.Agent.RunDir, config.Global.Agent.RunUser = origDir, origUser })
+	// t.TempDir() exceeds the length limit of unix socket paths
+	dir, err := os.MkdirTemp("", "relay")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	config.Global.Agent.RunDir = dir
+	config.Global.Agent.RunUser = ""
+	require.NoError(t, os.MkdirAll(GetNetworkDir(networkID.String()), 0o700))
+	deleteRelayMetrics(networkID.String())
+}
+
+// waitForSocket waits until the relay listens on the socket.
+func waitForSocket(t *testing.T, path string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond, "relay should listen on %s", path)
+}
+
+func TestRunRelay(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440001")
+	setupRelayTest(t, networkID)
+	port := startEchoServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- runRelay(ctx, networkID, serviceID, []string{"127.0.0.1"}, []int32{port}) }()
+
+	path := GetSocketPath(networkID.String(), serviceID.String(), 0, port)
+	waitForSocket(t, path)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o666), info.Mode().Perm(), "HAProxy must be able to connect")
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	// the upstream only answers after the client finished sending
+	require.NoError(t, conn.(*net.UnixConn).CloseWrite())
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(data))
+	_ = conn.Close()
+
+	network := networkID.String()
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(relayActiveConnections.WithLabelValues(network)) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	// the probe connections of waitForSocket were relayed as well
+	assert.GreaterOrEqual(t, testutil.ToFloat64(relayConnections.WithLabelValues(network, "success")), 2.0)
+	assert.Equal(t, 4.0, testutil.ToFloat64(relayBytes.WithLabelValues(network, "upstream")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(relayBytes.WithLabelValues(network, "downstream")))
+
+	cancel()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runRelay should return once cancelled")
+	}
+	_, err = os.Stat(path)
+	assert.NoError(t, err, "closing leaves removing the socket to the manager")
+}
+
+func TestRunRelay_UpstreamDown(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440002")
+	setupRelayTest(t, networkID)
+
+	// a port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := int32(ln.Addr().(*net.TCPAddr).Port)
+	_ = ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = runRelay(ctx, networkID, serviceID, []string{"127.0.0.1"}, []int32{port}) }()
+
+	path := GetSocketPath(networkID.String(), serviceID.String(), 0, port)
+	waitForSocket(t, path)
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err, "the client is disconnected")
+	_ = conn.Close()
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(relayConnections.WithLabelValues(networkID.String(), "error")) >= 2
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_Relay(t *testing.T) {
+	networkID := strfmt.UUID("550e8400-e29b-41d4-a716-446655440003")
+	setupRelayTest(t, networkID)
+	origMode := config.Global.Agent.ProxyMode
+	defer func() { config.Global.Agent.ProxyMode = origMode }()
+	config.Global.Agent.ProxyMode = "relay"
+	port := startEchoServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewManager(ctx)
+	m.StartProxy(networkID, serviceID, []string{"127.0.0.1"}, []int32{port})
+
+	path := GetSocketPath(networkID.String(), serviceID.String(), 0, port)
+	waitForSocket(t, path)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(relayConnections.WithLabelValues(networkID.String(), "success")) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	m.StopProxy(networkID, serviceID)
+	assert.NoFileExists(t, path, "stopping removes the socket")
+	assert.False(t, relayConnections.DeleteLabelValues(networkID.String(), "success"),
+		"metrics of networks without proxies are removed")
+}
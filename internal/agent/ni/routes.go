@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/go-openapi/strfmt"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/agent/ni/netlink"
+	"github.com/sapcc/archer/v2/internal/config"
+	"github.com/sapcc/archer/v2/internal/db"
+	"github.com/sapcc/archer/v2/models"
+)
+
+// RouteSyncLoop applies the current gateway and host routes of their subnets to the plugged
+// endpoint ports. Subnet updates in Neutron are not notified to the agent, the routes are only
+// applied when plugging or re-enabling an endpoint otherwise.
+func (a *Agent) RouteSyncLoop(ctx context.Context) error {
+	sql, args := db.Select("DISTINCT ep.port_id").
+		From("endpoint_port ep").
+		Join("endpoint e ON e.id = ep.endpoint_id").
+		Join("service s ON s.id = e.service_id").
+		Where("s.host = ?", config.Global.Default.Host).
+		Where("s.provider = 'cp'").
+		Where("e.status = ?", models.EndpointStatusAVAILABLE).
+		MustSql()
+
+	var portIDs []strfmt.UUID
+	if err := pgxscan.Select(ctx, a.pool, &portIDs, sql, args...); err != nil {
+		return err
+	}
+
+	for _, portID := range portIDs {
+		if err := a.syncPortRoutes(ctx, portID); err != nil {
+			log.WithError(err).Warnf("RouteSyncLoop: failed syncing routes of port %s", portID)
+		}
+	}
+	return nil
+}
+
+// syncPortRoutes applies the routes of the subnets of an endpoint port to its namespace.
+func (a *Agent) syncPortRoutes(ctx context.Context, portID strfmt.UUID) error {
+	port, err := ports.Get(ctx, a.neutron.ServiceClient, portID.String()).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to get port %s: %w", portID, err)
+	}
+
+	a.injectionMu.Lock()
+	defer a.injectionMu.Unlock()
+
+	ns := netlink.NewNetworkNamespace()
+	defer func() { _ = ns.Close() }()
+
+	if err = ns.OpenNetworkNamespace(port.NetworkID); err != nil {
+		return err
+	}
+	return ns.SyncRoutes(ctx, port, a.neutron.ServiceClient)
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"slices"
+)
+
+// Capabilities are optional features an agent reports on registration and with
+// every heartbeat. Services requiring a capability are only placed on agents
+// reporting it.
+const (
+	// CapabilityWildcardPort: the agent can provision services with ports [0].
+	CapabilityWildcardPort = "wildcard_port"
+	// CapabilitySnatPool: the agent can allocate more than one SNAT address per service.
+	CapabilitySnatPool = "snat_pool"
+)
+
+// RequiredCapabilities returns the agent capabilities needed to provision a
+// service with the given ports and SNAT pool size.
+func RequiredCapabilities(ports []int32, snatPoolSize *int32) []string {
+	var capabilities []string
+	if slices.Contains(ports, 0) {
+		capabilities = append(capabilities, CapabilityWildcardPort)
+	}
+	if snatPoolSize != nil && *snatPoolSize > 1 {
+		capabilities = append(capabilities, CapabilitySnatPool)
+	}
+	return capabilities
+}
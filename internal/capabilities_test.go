@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredCapabilities(t *testing.T) {
+	assert.Empty(t, RequiredCapabilities([]int32{80, 443}, nil))
+	assert.Empty(t, RequiredCapabilities([]int32{80}, new(int32(1))))
+	assert.Equal(t, []string{CapabilityWildcardPort}, RequiredCapabilities([]int32{0}, nil))
+	assert.Equal(t, []string{CapabilityWildcardPort, CapabilitySnatPool}, RequiredCapabilities([]int32{0}, new(int32(4))))
+	assert.Equal(t, []string{CapabilitySnatPool}, RequiredCapabilities([]int32{443}, new(int32(2))))
+}
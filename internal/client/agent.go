@@ -24,31 +24,53 @@ func (*AgentList) Execute(_ []string) error {
 		return err
 	}
 
-	DefaultColumns = []string{"host", "availability_zone", "provider", "enabled", "services", "last_heartbeat"}
+	DefaultColumns = []string{"host", "availability_zone", "provider", "enabled", "services", "version", "last_heartbeat"}
 
 	type agentRow struct {
-		Host             string `json:"host"`
-		AvailabilityZone string `json:"availability_zone"`
-		Provider         string `json:"provider"`
-		Enabled          bool   `json:"enabled"`
-		Physnet          string `json:"physnet"`
-		Services         int64  `json:"services"`
-		LastHeartbeat    string `json:"last_heartbeat"`
+		Host             string   `json:"host"`
+		AvailabilityZone string   `json:"availability_zone"`
+		Provider         string   `json:"provider"`
+		Enabled          bool     `json:"enabled"`
+		Physnet          string   `json:"physnet"`
+		Physnets         []string `json:"physnets"`
+		Services         int64    `json:"services"`
+		Version          string   `json:"version"`
+		DeviceType       string   `json:"device_type"`
+		FailoverState    string   `json:"failover_state"`
+		ActiveDevice     string   `json:"active_device"`
+		Capabilities     []string `json:"capabilities"`
+		QueueDepth       int64    `json:"queue_depth"`
+		LastHeartbeat    string   `json:"last_heartbeat"`
 	}
 
 	rows := make([]agentRow, 0, len(resp.Payload.Items))
 	for _, a := range resp.Payload.Items {
-		var az, physnet string
+		var az, physnet, version, deviceType, failoverState string
 		if a.AvailabilityZone != nil {
 			az = *a.AvailabilityZone
 		}
 		if a.Physnet != nil {
 			physnet = *a.Physnet
 		}
+		if a.Version != nil {
+			version = *a.Version
+		}
+		if a.DeviceType != nil {
+			deviceType = *a.DeviceType
+		}
+		if a.FailoverState != nil {
+			failoverState = *a.FailoverState
+		}
 		var enabled bool
 		if a.Enabled != nil {
 			enabled = *a.Enabled
 		}
+		var queueDepth int64
+		var activeDevice string
+		if a.Health != nil {
+			queueDepth = a.Health.QueueDepth
+			activeDevice = a.Health.ActiveDevice
+		}
 		elapsed := time.Since(a.HeartbeatAt)
 		rows = append(rows, agentRow{
 			Host:             a.Host,
@@ -56,7 +78,14 @@ func (*AgentList) Execute(_ []string) error {
 			Provider:         a.Provider,
 			Enabled:          enabled,
 			Physnet:          physnet,
+			Physnets:         a.Physnets,
 			Services:         a.Services,
+			Version:          version,
+			DeviceType:       deviceType,
+			FailoverState:    failoverState,
+			ActiveDevice:     activeDevice,
+			Capabilities:     a.Capabilities,
+			QueueDepth:       queueDepth,
 			LastHeartbeat:    elapsed.Truncate(time.Second).String(),
 		})
 	}
@@ -7,6 +7,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -112,14 +115,19 @@ func (*EndpointShow) Execute(_ []string) error {
 }
 
 type EndpointCreate struct {
-	Name                string   `short:"n" long:"name" description:"New endpoint name"`
-	Description         string   `long:"description" description:"Set endpoint description"`
-	Tags                []string `long:"tag" description:"Tag to be added to the endpoint (repeat option to set multiple tags)"`
-	Network             *string  `long:"network" description:"Endpoint network (name or ID)"`
-	Port                *string  `long:"port" description:"Endpoint port (ID)"`
-	Subnet              *string  `long:"subnet" description:"Endpoint subnet (ID)"`
-	ConnectionMirroring bool     `long:"connection-mirroring" description:"Enable BIG-IP connection mirroring for HA failover (only affects provider type 'tenant')"`
-	Wait                bool     `long:"wait" description:"Wait for endpoint to be ready"`
+	Name                string        `short:"n" long:"name" description:"New endpoint name"`
+	Description         string        `long:"description" description:"Set endpoint description"`
+	Tags                []string      `long:"tag" description:"Tag to be added to the endpoint (repeat option to set multiple tags)"`
+	Network             *string       `long:"network" description:"Endpoint network (name or ID)"`
+	Port                *string       `long:"port" description:"Endpoint port (ID)"`
+	Subnet              *string       `long:"subnet" description:"Endpoint subnet (ID)"`
+	ConnectionMirroring bool          `long:"connection-mirroring" description:"Enable BIG-IP connection mirroring for HA failover (only affects provider type 'tenant')"`
+	ConnectionLimit     *int32        `long:"connection-limit" description:"Maximum concurrent connections, overrides the service limit"`
+	RateLimit           *int32        `long:"rate-limit" description:"Maximum new connections per second, overrides the service limit"`
+	AllowedCIDRs        []models.CIDR `long:"allowed-cidr" description:"Source network allowed to connect (CIDR, repeat option for multiple networks)"`
+	ServicePorts        []int32       `long:"service-port" description:"Service port exposed by the endpoint (repeat option for multiple ports, default all ports of the service)"`
+	PortMappings        []string      `long:"port-mapping" description:"Listen on a port differing from the service port (LISTEN_PORT:SERVICE_PORT, repeat option for multiple mappings)"`
+	Wait                bool          `long:"wait" description:"Wait for endpoint to be ready"`
 	Positional          struct {
 		Service string `positional-arg-name:"service" description:"Service to reference (name or ID)"`
 	} `positional-args:"yes" required:"yes"`
@@ -148,12 +156,22 @@ func (*EndpointCreate) Execute(_ []string) error {
 		subnetID = &id
 	}
 
+	portMappings, err := parsePortMappings(EndpointOptions.EndpointCreate.PortMappings)
+	if err != nil {
+		return err
+	}
+
 	sv := models.Endpoint{
 		Name:                EndpointOptions.EndpointCreate.Name,
 		Description:         EndpointOptions.EndpointCreate.Description,
 		ServiceID:           serviceID,
 		Tags:                EndpointOptions.EndpointCreate.Tags,
 		ConnectionMirroring: boolFlag(EndpointOptions.EndpointCreate.ConnectionMirroring, false),
+		ConnectionLimit:     EndpointOptions.EndpointCreate.ConnectionLimit,
+		RateLimit:           EndpointOptions.EndpointCreate.RateLimit,
+		AllowedCidrs:        EndpointOptions.EndpointCreate.AllowedCIDRs,
+		Ports:               EndpointOptions.EndpointCreate.ServicePorts,
+		PortMappings:        portMappings,
 		Target: models.EndpointTarget{
 			Network: networkID,
 			Port:    portID,
@@ -207,13 +225,21 @@ type EndpointSet struct {
 	Positional struct {
 		Endpoint string `positional-arg-name:"endpoint" description:"Endpoint to set (name or ID)"`
 	} `positional-args:"yes" required:"yes"`
-	Name                  *string  `short:"n" long:"name" description:"New endpoint name"`
-	Description           *string  `long:"description" description:"Set endpoint description"`
-	ConnectionMirroring   bool     `long:"connection-mirroring" description:"Enable BIG-IP connection mirroring for HA failover (only affects provider type 'tenant')"`
-	NoConnectionMirroring bool     `long:"no-connection-mirroring" description:"Disable BIG-IP connection mirroring"`
-	NoTags                bool     `long:"no-tag" description:"Clear tags associated with the endpoint. Specify both --tag and --no-tag to overwrite current tags"`
-	Tags                  []string `long:"tag" description:"Tag to be added to the endpoint (repeat option to set multiple tags)"`
-	Wait                  bool     `long:"wait" description:"Wait for endpoint to be ready"`
+	Name                  *string       `short:"n" long:"name" description:"New endpoint name"`
+	Description           *string       `long:"description" description:"Set endpoint description"`
+	ConnectionMirroring   bool          `long:"connection-mirroring" description:"Enable BIG-IP connection mirroring for HA failover (only affects provider type 'tenant')"`
+	NoConnectionMirroring bool          `long:"no-connection-mirroring" description:"Disable BIG-IP connection mirroring"`
+	ConnectionLimit       *int32        `long:"connection-limit" description:"Maximum concurrent connections, 0 for no limit, -1 to inherit the service limit"`
+	RateLimit             *int32        `long:"rate-limit" description:"Maximum new connections per second, 0 for no limit, -1 to inherit the service limit"`
+	NoAllowedCIDRs        bool          `long:"no-allowed-cidr" description:"Allow connections from all sources"`
+	AllowedCIDRs          []models.CIDR `long:"allowed-cidr" description:"Source network allowed to connect (CIDR), replaces the current networks (repeat option for multiple networks)"`
+	NoServicePorts        bool          `long:"no-service-port" description:"Expose all ports of the service"`
+	ServicePorts          []int32       `long:"service-port" description:"Service port exposed by the endpoint, replaces the current ports (repeat option for multiple ports)"`
+	NoPortMappings        bool          `long:"no-port-mapping" description:"Listen on the service ports"`
+	PortMappings          []string      `long:"port-mapping" description:"Listen on a port differing from the service port (LISTEN_PORT:SERVICE_PORT), replaces the current mappings (repeat option for multiple mappings)"`
+	NoTags                bool          `long:"no-tag" description:"Clear tags associated with the endpoint. Specify both --tag and --no-tag to overwrite current tags"`
+	Tags                  []string      `long:"tag" description:"Tag to be added to the endpoint (repeat option to set multiple tags)"`
+	Wait                  bool          `long:"wait" description:"Wait for endpoint to be ready"`
 }
 
 func (*EndpointSet) Execute(_ []string) error {
@@ -237,6 +263,23 @@ func (*EndpointSet) Execute(_ []string) error {
 		tags = append(EndpointOptions.EndpointSet.Tags, resp.Payload.Tags...)
 	}
 
+	var allowedCIDRs []models.CIDR
+	if EndpointOptions.EndpointSet.NoAllowedCIDRs || len(EndpointOptions.EndpointSet.AllowedCIDRs) > 0 {
+		allowedCIDRs = append(make([]models.CIDR, 0), EndpointOptions.EndpointSet.AllowedCIDRs...)
+	}
+
+	var servicePorts []int32
+	if EndpointOptions.EndpointSet.NoServicePorts || len(EndpointOptions.EndpointSet.ServicePorts) > 0 {
+		servicePorts = append(make([]int32, 0), EndpointOptions.EndpointSet.ServicePorts...)
+	}
+
+	var portMappings []*models.PortMapping
+	if EndpointOptions.EndpointSet.NoPortMappings || len(EndpointOptions.EndpointSet.PortMappings) > 0 {
+		if portMappings, err = parsePortMappings(EndpointOptions.EndpointSet.PortMappings); err != nil {
+			return err
+		}
+	}
+
 	params := endpoint.
 		NewPutEndpointEndpointIDParams().
 		WithEndpointID(endpointID).
@@ -244,6 +287,11 @@ func (*EndpointSet) Execute(_ []string) error {
 			Name:                EndpointOptions.EndpointSet.Name,
 			Description:         EndpointOptions.EndpointSet.Description,
 			ConnectionMirroring: boolFlag(EndpointOptions.EndpointSet.ConnectionMirroring, EndpointOptions.EndpointSet.NoConnectionMirroring),
+			ConnectionLimit:     EndpointOptions.EndpointSet.ConnectionLimit,
+			RateLimit:           EndpointOptions.EndpointSet.RateLimit,
+			AllowedCidrs:        allowedCIDRs,
+			Ports:               servicePorts,
+			PortMappings:        portMappings,
 			Tags:                tags,
 		})
 	resp, err := ArcherClient.Endpoint.PutEndpointEndpointID(params, nil)
@@ -293,3 +341,27 @@ func waitForEndpoint(id strfmt.UUID, deleted bool) (*models.Endpoint, error) {
 	}
 	return res, nil
 }
+
+// parsePortMappings parses port mappings given as LISTEN_PORT:SERVICE_PORT.
+func parsePortMappings(values []string) ([]*models.PortMapping, error) {
+	mappings := make([]*models.PortMapping, 0, len(values))
+	for _, value := range values {
+		listen, service, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid port mapping %q, expected LISTEN_PORT:SERVICE_PORT", value)
+		}
+		listenPort, err := strconv.ParseInt(listen, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listen port in port mapping %q: %w", value, err)
+		}
+		servicePort, err := strconv.ParseInt(service, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service port in port mapping %q: %w", value, err)
+		}
+		mappings = append(mappings, &models.PortMapping{
+			ListenPort:  new(int32(listenPort)),
+			ServicePort: new(int32(servicePort)),
+		})
+	}
+	return mappings, nil
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"github.com/sapcc/archer/v2/client/irule"
+	"github.com/sapcc/archer/v2/models"
+)
+
+var IRuleOptions struct {
+	IRuleList   `command:"list" description:"List iRules"`
+	IRuleCreate `command:"create" description:"Add iRule to the catalog"`
+	IRuleShow   `command:"show" description:"Show iRule detail"`
+	IRuleSet    `command:"set" description:"Set iRule properties"`
+	IRuleDelete `command:"delete" description:"Remove iRule from the catalog"`
+}
+
+type IRuleList struct{}
+
+func (*IRuleList) Execute(_ []string) error {
+	params := irule.NewGetIrulesParams()
+	resp, err := ArcherClient.Irule.GetIrules(params, nil)
+	if err != nil {
+		return err
+	}
+
+	Table.AppendHeader(table.Row{"ID", "Name", "Description", "Created", "Updated"})
+	for _, r := range resp.Payload.Items {
+		Table.AppendRow(table.Row{r.ID, *r.Name, r.Description, r.CreatedAt, r.UpdatedAt})
+	}
+	Table.Render()
+	return nil
+}
+
+type IRuleCreate struct {
+	Name        string `long:"name" description:"Unique name of the iRule." required:"true"`
+	Description string `long:"description" description:"Description of the iRule."`
+	File        string `long:"file" description:"File containing the TCL source of the iRule." required:"true"`
+}
+
+func (*IRuleCreate) Execute(_ []string) error {
+	content, err := os.ReadFile(IRuleOptions.IRuleCreate.File)
+	if err != nil {
+		return err
+	}
+
+	params := irule.NewPostIrulesParams().
+		WithBody(&models.Irule{
+			Name:        &IRuleOptions.IRuleCreate.Name,
+			Description: IRuleOptions.IRuleCreate.Description,
+			Content:     new(string(content)),
+		})
+	resp, err := ArcherClient.Irule.PostIrules(params, nil)
+	if err != nil {
+		return err
+	}
+
+	return WriteTable(resp.GetPayload())
+}
+
+type IRuleShow struct {
+	Positional struct {
+		IRule strfmt.UUID `description:"iRule to display (ID)"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (*IRuleShow) Execute(_ []string) error {
+	params := irule.NewGetIrulesIruleIDParams().
+		WithIruleID(IRuleOptions.IRuleShow.Positional.IRule)
+	resp, err := ArcherClient.Irule.GetIrulesIruleID(params, nil)
+	if err != nil {
+		return err
+	}
+
+	return WriteTable(resp.GetPayload())
+}
+
+type IRuleDelete struct {
+	Positional struct {
+		IRule strfmt.UUID `description:"iRule to delete (ID)"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (*IRuleDelete) Execute(_ []string) error {
+	params := irule.NewDeleteIrulesIruleIDParams().
+		WithIruleID(IRuleOptions.IRuleDelete.Positional.IRule)
+	_, err := ArcherClient.Irule.DeleteIrulesIruleID(params, nil)
+	return err
+}
+
+type IRuleSet struct {
+	Positional struct {
+		IRule strfmt.UUID `description:"iRule to update (ID)"`
+	} `positional-args:"yes" required:"yes"`
+	Description *string `long:"description" description:"Description of the iRule."`
+	File        *string `long:"file" description:"File containing the TCL source of the iRule."`
+}
+
+func (*IRuleSet) Execute(_ []string) error {
+	params := irule.NewPutIrulesIruleIDParams().
+		WithIruleID(IRuleOptions.IRuleSet.Positional.IRule).
+		WithBody(&models.IruleUpdatable{
+			Description: IRuleOptions.IRuleSet.Description,
+		})
+	if IRuleOptions.IRuleSet.File != nil {
+		content, err := os.ReadFile(*IRuleOptions.IRuleSet.File)
+		if err != nil {
+			return err
+		}
+		params.Body.Content = new(string(content))
+	}
+
+	resp, err := ArcherClient.Irule.PutIrulesIruleID(params, nil)
+	if err != nil {
+		return err
+	}
+
+	return WriteTable(resp.GetPayload())
+}
+
+func init() {
+	if _, err := Parser.AddCommand("irule", "iRules",
+		"iRule Catalog Commands.", &IRuleOptions); err != nil {
+		panic(err)
+	}
+}
@@ -7,6 +7,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -28,6 +31,60 @@ func toInetAddresses(addrs []string) []models.InetAddress {
 	return result
 }
 
+// parseMembers parses load balancing options given as IP[,weight=N][,priority-group=N][,backup].
+func parseMembers(values []string) ([]*models.ServiceMember, error) {
+	members := make([]*models.ServiceMember, 0, len(values))
+	for _, value := range values {
+		fields := strings.Split(value, ",")
+		member := &models.ServiceMember{IPAddress: new(models.InetAddress(fields[0]))}
+		for _, field := range fields[1:] {
+			key, val, _ := strings.Cut(field, "=")
+			switch key {
+			case "weight", "priority-group":
+				n, err := strconv.ParseInt(val, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s in member %q: %w", key, value, err)
+				}
+				if key == "weight" {
+					member.Weight = int32(n)
+				} else {
+					member.PriorityGroup = int32(n)
+				}
+			case "backup":
+				member.Backup = true
+			default:
+				return nil, fmt.Errorf("invalid option %q in member %q", field, value)
+			}
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// TrafficOptions are the traffic settings of a service, 0 uses the provider's default.
+type TrafficOptions struct {
+	MaxConnections *int32  `long:"max-connections" description:"Maximum concurrent connections to each IP address of the service (0 = unlimited)"`
+	ConnectTimeout *int32  `long:"connect-timeout" description:"Seconds to wait for connections to the service to be established (0 = default, cp provider only)"`
+	IdleTimeout    *int32  `long:"idle-timeout" description:"Seconds connections may be inactive (0 = default)"`
+	TunnelTimeout  *int32  `long:"tunnel-timeout" description:"Seconds established TCP or upgraded HTTP connections may be inactive (0 = default, cp provider only)"`
+	HTTPKeepAlive  *string `long:"http-keep-alive" description:"Connections kept alive between HTTP requests (HTTP protocol and cp provider only)" choice:"both" choice:"client" choice:"none"`
+}
+
+// traffic returns the given traffic settings, nil if none are given.
+func (o *TrafficOptions) traffic() *models.ServiceTraffic {
+	traffic := models.ServiceTraffic{
+		MaxConnections: o.MaxConnections,
+		ConnectTimeout: o.ConnectTimeout,
+		IdleTimeout:    o.IdleTimeout,
+		TunnelTimeout:  o.TunnelTimeout,
+		HTTPKeepAlive:  o.HTTPKeepAlive,
+	}
+	if traffic == (models.ServiceTraffic{}) {
+		return nil
+	}
+	return &traffic
+}
+
 var ServiceOptions struct {
 	ServiceList     `command:"list" description:"List Services"`
 	ServiceEndpoint `command:"endpoint" description:"Service Endpoint Commands"`
@@ -113,6 +170,7 @@ type ServiceCreate struct {
 	Disable           bool     `long:"disable" description:"Disable service"`
 	Network           *string  `long:"network" description:"Network (name or ID, required for tenant provider)"`
 	IPAddresses       []string `long:"ip-address" description:"IP Addresses of the providing service (IPv4 or IPv6), multiple addresses will be round robin load balanced." required:"true"`
+	Members           []string `long:"member" description:"Load balancing options of an IP address: IP[,weight=N][,priority-group=N][,backup] (repeat option for multiple addresses)"`
 	Port              []int32  `long:"port" description:"Port exposed by the service (repeat option to set multiple ports)"`
 	AllPorts          bool     `long:"all-ports" description:"Expose all TCP ports (wildcard, equivalent to --port 0). Mutually exclusive with --port."`
 	Protocol          *string  `long:"protocol" description:"Protocol type of the service" choice:"TCP" choice:"HTTP"`
@@ -121,10 +179,16 @@ type ServiceCreate struct {
 	RequireApproval   bool     `long:"require-approval" description:"Require explicit project approval for the service owner."`
 	NoRequireApproval bool     `long:"no-require-approval" description:"Disable require approval for the service owner."`
 	SnatPoolSize      *int32   `long:"snat-pool-size" description:"Number of SNAT IP addresses allocated for this service (1-8, f5 provider only). Leave unset for default behavior."`
+	ConnectionLimit   *int32   `long:"connection-limit" description:"Maximum concurrent connections of each endpoint (0 = unlimited)"`
+	RateLimit         *int32   `long:"rate-limit" description:"Maximum new connections per second of each endpoint (0 = unlimited)"`
+	TrafficOptions
+	IRules            []string `long:"irule" description:"Name of a catalog iRule attached to the endpoints (repeat option for multiple iRules, cloud admin only)"`
 	Tags              []string `long:"tag" description:"Tag to be added to the service (repeat option to set multiple tags)"`
 	Visibility        *string  `long:"visibility" description:"Set global visibility of the service. For private visibility, RBAC policies can extend the visibility to specific projects" choice:"private" choice:"public"`
 	Wait              bool     `long:"wait" description:"Wait for service to be ready"`
 	AvailabilityZone  *string  `long:"availability-zone" description:"Availability zone for the service"`
+	PinnedHost        *string  `long:"pinned-host" description:"Only schedule the service on this agent host (cloud admin only)"`
+	AntiAffinityGroup *string  `long:"anti-affinity-group" description:"Never schedule the service on an agent hosting another service of this group"`
 }
 
 func (*ServiceCreate) Execute(_ []string) error {
@@ -154,21 +218,33 @@ func (*ServiceCreate) Execute(_ []string) error {
 		networkID = &id
 	}
 
+	members, err := parseMembers(ServiceOptions.ServiceCreate.Members)
+	if err != nil {
+		return err
+	}
+
 	sv := models.Service{
-		Name:             ServiceOptions.ServiceCreate.Name,
-		Description:      ServiceOptions.ServiceCreate.Description,
-		Provider:         ServiceOptions.ServiceCreate.Provider,
-		Enabled:          boolFlag(ServiceOptions.ServiceCreate.Enable, ServiceOptions.ServiceCreate.Disable),
-		NetworkID:        networkID,
-		IPAddresses:      toInetAddresses(ServiceOptions.ServiceCreate.IPAddresses),
-		Ports:            ServiceOptions.ServiceCreate.Port,
-		Protocol:         ServiceOptions.ServiceCreate.Protocol,
-		ProxyProtocol:    boolFlag(ServiceOptions.ServiceCreate.ProxyProtocol, ServiceOptions.ServiceCreate.NoProxyProtocol),
-		RequireApproval:  boolFlag(ServiceOptions.ServiceCreate.RequireApproval, ServiceOptions.ServiceCreate.NoRequireApproval),
-		SnatPoolSize:     ServiceOptions.ServiceCreate.SnatPoolSize,
-		Tags:             ServiceOptions.ServiceCreate.Tags,
-		Visibility:       ServiceOptions.ServiceCreate.Visibility,
-		AvailabilityZone: ServiceOptions.ServiceCreate.AvailabilityZone,
+		Name:              ServiceOptions.ServiceCreate.Name,
+		Description:       ServiceOptions.ServiceCreate.Description,
+		Provider:          ServiceOptions.ServiceCreate.Provider,
+		Enabled:           boolFlag(ServiceOptions.ServiceCreate.Enable, ServiceOptions.ServiceCreate.Disable),
+		NetworkID:         networkID,
+		IPAddresses:       toInetAddresses(ServiceOptions.ServiceCreate.IPAddresses),
+		Members:           members,
+		Ports:             ServiceOptions.ServiceCreate.Port,
+		Protocol:          ServiceOptions.ServiceCreate.Protocol,
+		ProxyProtocol:     boolFlag(ServiceOptions.ServiceCreate.ProxyProtocol, ServiceOptions.ServiceCreate.NoProxyProtocol),
+		RequireApproval:   boolFlag(ServiceOptions.ServiceCreate.RequireApproval, ServiceOptions.ServiceCreate.NoRequireApproval),
+		SnatPoolSize:      ServiceOptions.ServiceCreate.SnatPoolSize,
+		ConnectionLimit:   ServiceOptions.ServiceCreate.ConnectionLimit,
+		RateLimit:         ServiceOptions.ServiceCreate.RateLimit,
+		Traffic:           ServiceOptions.ServiceCreate.traffic(),
+		Irules:            ServiceOptions.ServiceCreate.IRules,
+		Tags:              ServiceOptions.ServiceCreate.Tags,
+		Visibility:        ServiceOptions.ServiceCreate.Visibility,
+		AvailabilityZone:  ServiceOptions.ServiceCreate.AvailabilityZone,
+		PinnedHost:        ServiceOptions.ServiceCreate.PinnedHost,
+		AntiAffinityGroup: ServiceOptions.ServiceCreate.AntiAffinityGroup,
 	}
 	resp, err := ArcherClient.Service.PostService(service.NewPostServiceParams().WithBody(&sv), nil)
 	if err != nil {
@@ -195,6 +271,8 @@ type ServiceSet struct {
 	Enable            bool     `long:"enable" description:"Enable service"`
 	Disable           bool     `long:"disable" description:"Disable service"`
 	IPAddresses       []string `long:"ip-address" description:"IP Addresses of the providing service (IPv4 or IPv6), multiple addresses will be round robin load balanced."`
+	NoMembers         bool     `long:"no-member" description:"Remove the load balancing options of all IP addresses"`
+	Members           []string `long:"member" description:"Load balancing options of an IP address: IP[,weight=N][,priority-group=N][,backup], replaces the current options (repeat option for multiple addresses)"`
 	Name              *string  `long:"name" description:"Service name"`
 	Port              []int32  `long:"port" description:"Port exposed by the service (repeat option to set multiple ports)"`
 	Protocol          *string  `long:"protocol" description:"Protocol type of the service" choice:"TCP" choice:"HTTP"`
@@ -203,8 +281,15 @@ type ServiceSet struct {
 	RequireApproval   bool     `long:"require-approval" description:"Require explicit project approval for the service owner."`
 	NoRequireApproval bool     `long:"no-require-approval" description:"Disable require approval for the service owner."`
 	SnatPoolSize      *int32   `long:"snat-pool-size" description:"Number of SNAT IP addresses allocated for this service (1-8, f5 provider only)."`
+	ConnectionLimit   *int32   `long:"connection-limit" description:"Maximum concurrent connections of each endpoint, 0 removes the limit"`
+	RateLimit         *int32   `long:"rate-limit" description:"Maximum new connections per second of each endpoint, 0 removes the limit"`
+	TrafficOptions
+	NoIRules          bool     `long:"no-irule" description:"Detach all iRules from the service (cloud admin only)"`
+	IRules            []string `long:"irule" description:"Name of a catalog iRule attached to the endpoints, replaces the current iRules (repeat option for multiple iRules, cloud admin only)"`
 	Visibility        *string  `long:"visibility" description:"Set global visibility of the service. For private visibility, RBAC policies can extend the visibility to specific projects" choice:"private" choice:"public"`
 	Wait              bool     `long:"wait" description:"Wait for service to be ready"`
+	PinnedHost        *string  `long:"pinned-host" description:"Pin the service to this agent host, empty string removes the pin (cloud admin only)"`
+	AntiAffinityGroup *string  `long:"anti-affinity-group" description:"Set the anti-affinity group of the service, empty string removes it"`
 }
 
 func (*ServiceSet) Execute(_ []string) error {
@@ -233,18 +318,37 @@ func (*ServiceSet) Execute(_ []string) error {
 		tags = append(ServiceOptions.ServiceSet.Tags, resp.Payload.Tags...)
 	}
 
+	var irules []string
+	if ServiceOptions.ServiceSet.NoIRules || len(ServiceOptions.ServiceSet.IRules) > 0 {
+		irules = append(make([]string, 0), ServiceOptions.ServiceSet.IRules...)
+	}
+
+	var members []*models.ServiceMember
+	if ServiceOptions.ServiceSet.NoMembers || len(ServiceOptions.ServiceSet.Members) > 0 {
+		if members, err = parseMembers(ServiceOptions.ServiceSet.Members); err != nil {
+			return err
+		}
+	}
+
 	sv := models.ServiceUpdatable{
-		Description:     ServiceOptions.ServiceSet.Description,
-		Enabled:         boolFlag(ServiceOptions.ServiceSet.Enable, ServiceOptions.ServiceSet.Disable),
-		IPAddresses:     toInetAddresses(ServiceOptions.ServiceSet.IPAddresses),
-		Name:            ServiceOptions.ServiceSet.Name,
-		Ports:           ServiceOptions.ServiceSet.Port,
-		Protocol:        ServiceOptions.ServiceSet.Protocol,
-		ProxyProtocol:   boolFlag(ServiceOptions.ServiceSet.ProxyProtocol, ServiceOptions.ServiceSet.NoProxyProtocol),
-		RequireApproval: boolFlag(ServiceOptions.ServiceSet.RequireApproval, ServiceOptions.ServiceSet.NoRequireApproval),
-		SnatPoolSize:    ServiceOptions.ServiceSet.SnatPoolSize,
-		Tags:            tags,
-		Visibility:      ServiceOptions.ServiceSet.Visibility,
+		Description:       ServiceOptions.ServiceSet.Description,
+		Enabled:           boolFlag(ServiceOptions.ServiceSet.Enable, ServiceOptions.ServiceSet.Disable),
+		IPAddresses:       toInetAddresses(ServiceOptions.ServiceSet.IPAddresses),
+		Members:           members,
+		Name:              ServiceOptions.ServiceSet.Name,
+		Ports:             ServiceOptions.ServiceSet.Port,
+		Protocol:          ServiceOptions.ServiceSet.Protocol,
+		ProxyProtocol:     boolFlag(ServiceOptions.ServiceSet.ProxyProtocol, ServiceOptions.ServiceSet.NoProxyProtocol),
+		RequireApproval:   boolFlag(ServiceOptions.ServiceSet.RequireApproval, ServiceOptions.ServiceSet.NoRequireApproval),
+		SnatPoolSize:      ServiceOptions.ServiceSet.SnatPoolSize,
+		ConnectionLimit:   ServiceOptions.ServiceSet.ConnectionLimit,
+		RateLimit:         ServiceOptions.ServiceSet.RateLimit,
+		Traffic:           ServiceOptions.ServiceSet.traffic(),
+		Irules:            irules,
+		Tags:              tags,
+		Visibility:        ServiceOptions.ServiceSet.Visibility,
+		PinnedHost:        ServiceOptions.ServiceSet.PinnedHost,
+		AntiAffinityGroup: ServiceOptions.ServiceSet.AntiAffinityGroup,
 	}
 
 	params := service.
@@ -13,6 +13,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -93,11 +95,12 @@ type Notification struct {
 
 type Agent struct {
 	// F5 agent configuration
-	Devices                []string      `long:"device" ini-name:"device[]" description:"F5 Hostnames"`
-	VCMPs                  []string      `long:"vcmp" ini-name:"vcmp[]" description:"F5 Platform Hostnames"`
+	Devices                []string      `long:"device" ini-name:"device[]" description:"F5 Hostnames (BIG-IP ltm guests)"`
+	VCMPs                  []string      `long:"vcmp" ini-name:"vcmp[]" description:"F5 Platform Hostnames (vCMP or F5OS, L2 only)"`
 	ValidateCert           bool          `long:"validate-certificates" ini-name:"validate_certificates" description:"Validate HTTPS Certificate."`
 	PhysicalNetwork        string        `long:"physical-network" ini-name:"physical_network" env:"PHYSICAL_NETWORK" description:"Physical Network"`
 	PhysicalInterface      string        `long:"physical-interface" ini-name:"physical_interface" description:"Physical Interface" default:"portchannel1"`
+	BridgeMappings         []string      `long:"bridge-mapping" ini-name:"bridge_mapping[]" description:"Physical network and interface as <physnet>:<interface>, repeat for every physical network the agent is connected to. Overrides physical-network."`
 	PendingSyncInterval    time.Duration `long:"pending-sync-interval" ini-name:"sync-interval" default:"120s" description:"Interval for pending sync scans, supports suffix (e.g. 10s)."`
 	HealthScrapeInterval   time.Duration `long:"health-scrape-interval" ini-name:"health_scrape_interval" default:"5m" description:"Interval for health monitor status scraping."`
 	HealthScrapePrometheus string        `long:"health-scrape-prometheus" ini-name:"health_scrape_prometheus" description:"Prometheus API URL for health scraping. If set, uses Prometheus instead of direct F5 API."`
@@ -105,12 +108,22 @@ type Agent struct {
 	TCPProfile             string        `long:"tcp-profile" ini-name:"tcp_profile" description:"TCP profile to use for F5 endpoint service." default:"/Common/tcp"`
 	MaxRetries             uint64        `long:"max-retries" ini-name:"max_retries" description:"Maximum number of retries for F5 operations." default:"3"`
 	MaxDuration            time.Duration `long:"max-duration" ini-name:"max_duration" description:"Maximum duration for F5 operations, supports suffix (e.g. 10s)." default:"1.5m"`
+	DriftCheckInterval     time.Duration `long:"drift-check-interval" ini-name:"drift_check_interval" default:"30m" description:"Interval for comparing AS3 declarations on the device with the database, 0 disables."`
+	DriftRepair            bool          `long:"drift-repair" ini-name:"drift_repair" description:"Re-post AS3 declarations that drifted from the database."`
+	FailoverCheckInterval  time.Duration `long:"failover-check-interval" ini-name:"failover_check_interval" default:"10s" description:"Interval for re-electing the active device, 0 disables."`
 
 	// Network Injection (cp) agent configuration
-	RunDir     string `long:"run-dir" ini-name:"run_dir" description:"Base directory for the NI agent's per-network runtime state (HAProxy config/chroot and socat sockets)." default:"/run/archer"`
-	RunDirTemp string `long:"temp-dir" ini-name:"temp_dir" description:"Deprecated: use run-dir." hidden:"yes"`
-	RunUser    string `long:"run-user" ini-name:"run_user" description:"Unprivileged user HAProxy and socat drop to." default:"nobody"`
-	RunGroup   string `long:"run-group" ini-name:"run_group" description:"Unprivileged group HAProxy drops to." default:"nogroup"`
+	RunDir              string        `long:"run-dir" ini-name:"run_dir" description:"Base directory for the NI agent's per-network runtime state (HAProxy config/chroot and socat sockets)." default:"/run/archer"`
+	RunDirTemp          string        `long:"temp-dir" ini-name:"temp_dir" description:"Deprecated: use run-dir." hidden:"yes"`
+	RunUser             string        `long:"run-user" ini-name:"run_user" description:"Unprivileged user HAProxy and the proxies drop to." default:"nobody"`
+	RunGroup            string        `long:"run-group" ini-name:"run_group" description:"Unprivileged group HAProxy drops to." default:"nogroup"`
+	PlugMode            string        `long:"plug-mode" ini-name:"plug_mode" choice:"linuxbridge" choice:"openvswitch" default:"linuxbridge" description:"How the NI agent plugs endpoint ports into the injection namespaces: veth pairs picked up by the linuxbridge agent, or Open vSwitch internal ports bound by the openvswitch agent."`
+	IntegrationBridge   string        `long:"integration-bridge" ini-name:"integration_bridge" default:"br-int" description:"Open vSwitch bridge endpoint ports are plugged into with plug-mode openvswitch."`
+	ProxyMode           string        `long:"proxy-mode" ini-name:"proxy_mode" choice:"socat" choice:"relay" default:"socat" description:"How the NI agent relays HAProxy's unix sockets to the upstreams: one socat process per upstream and port, or in-process."`
+	NamespaceGCInterval time.Duration `long:"namespace-gc-interval" ini-name:"namespace_gc_interval" default:"1h" description:"Interval for reporting injection namespaces and tap veths without endpoint ports, 0 disables."`
+	NamespaceGCDelete   bool          `long:"namespace-gc-delete" ini-name:"namespace_gc_delete" description:"Remove orphaned injection namespaces and tap veths instead of only reporting them."`
+	RouteSyncInterval   time.Duration `long:"route-sync-interval" ini-name:"route_sync_interval" default:"10m" description:"Interval for applying changed subnet gateways and host routes to the injection namespaces, 0 disables."`
+	HealthCheckInterval time.Duration `long:"health-check-interval" ini-name:"health_check_interval" default:"5s" description:"Interval of HAProxy's health checks of every service IP address, failed addresses receive no connections until they recover. Backup members and lower priority groups only receive connections once health checks mark the active members down, 0 disables health checks and makes all members active."`
 
 	// Deprecated auto-create-service configuration (services should be created via API)
 	CreateService          bool     `long:"create-service" ini-name:"create_service" description:"Auto-create Service for network injection agent. Deprecated: services should be created via API."`
@@ -129,6 +142,9 @@ type Agent struct {
 	RebalanceDelay          time.Duration `long:"rebalance-delay" ini-name:"rebalance_delay" default:"10m" description:"Time to wait after agent recovery before auto-rebalancing."`
 	RebalanceThreshold      float64       `long:"rebalance-threshold" ini-name:"rebalance_threshold" default:"0.5" description:"Imbalance ratio (0.0-1.0) required to trigger rebalancing."`
 	RebalanceMaxMigrations  int           `long:"rebalance-max-migrations" ini-name:"rebalance_max_migrations" default:"5" description:"Maximum services to migrate per rebalance cycle."`
+	MaintenanceWindows      []string      `long:"maintenance-window" ini-name:"maintenance_window[]" description:"Cron expression (e.g. '0 22 * * 1-5') opening a maintenance window; rebalancing only migrates services inside a window. Unset means no restriction."`
+	MaintenanceWindowLength time.Duration `long:"maintenance-window-duration" ini-name:"maintenance_window_duration" default:"2h" description:"How long each maintenance window stays open."`
+	MigrationsPerHour       int           `long:"migrations-per-hour" ini-name:"migrations_per_hour" default:"0" description:"Maximum service migrations per hour before rebalancing pauses (0 = unlimited)."`
 }
 
 type AuthInfo struct {
@@ -175,6 +191,72 @@ func ResolveHost() {
 	}
 }
 
+// BridgeMapping connects a physical network to the local interface carrying it.
+type BridgeMapping struct {
+	PhysicalNetwork string
+	Interface       string
+}
+
+// GetBridgeMappings returns the configured bridge mappings in order of
+// preference. Without bridge mappings, the physical-network/physical-interface
+// pair is used; a mapping without interface uses physical-interface.
+func (a *Agent) GetBridgeMappings() []BridgeMapping {
+	if len(a.BridgeMappings) == 0 {
+		if a.PhysicalNetwork == "" {
+			return nil
+		}
+		return []BridgeMapping{{PhysicalNetwork: a.PhysicalNetwork, Interface: a.PhysicalInterface}}
+	}
+
+	mappings := make([]BridgeMapping, 0, len(a.BridgeMappings))
+	for _, m := range a.BridgeMappings {
+		physnet, iface, found := strings.Cut(m, ":")
+		if !found || iface == "" {
+			iface = a.PhysicalInterface
+		}
+		mappings = append(mappings, BridgeMapping{PhysicalNetwork: physnet, Interface: iface})
+	}
+	return mappings
+}
+
+// GetInterface returns the interface carrying the physical network, falling back to
+// physical-interface for unknown physical networks.
+func (a *Agent) GetInterface(physnet string) string {
+	for _, m := range a.GetBridgeMappings() {
+		if m.PhysicalNetwork == physnet {
+			return m.Interface
+		}
+	}
+	return a.PhysicalInterface
+}
+
+// Interfaces returns the distinct interfaces carrying the physical networks, or
+// physical-interface without any physical network.
+func (a *Agent) Interfaces() []string {
+	mappings := a.GetBridgeMappings()
+	if len(mappings) == 0 {
+		return []string{a.PhysicalInterface}
+	}
+	ifaces := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		if !slices.Contains(ifaces, m.Interface) {
+			ifaces = append(ifaces, m.Interface)
+		}
+	}
+	return ifaces
+}
+
+// PhysicalNetworks returns the physical networks the agent is connected to, in
+// order of preference.
+func (a *Agent) PhysicalNetworks() []string {
+	mappings := a.GetBridgeMappings()
+	physnets := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		physnets = append(physnets, m.PhysicalNetwork)
+	}
+	return physnets
+}
+
 func ParseConfig(parser *flags.Parser) {
 	log.SetLevel(log.InfoLevel)
 
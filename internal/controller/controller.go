@@ -9,9 +9,11 @@ import (
 
 	"github.com/go-openapi/loads"
 
+	"github.com/sapcc/archer/v2/internal/config"
 	"github.com/sapcc/archer/v2/internal/db"
 	"github.com/sapcc/archer/v2/internal/neutron"
 	"github.com/sapcc/archer/v2/internal/notifier"
+	"github.com/sapcc/archer/v2/internal/scheduler"
 )
 
 // defaultLockTimeout bounds how long a FOR UPDATE handler transaction waits for
@@ -33,3 +35,10 @@ type Controller struct {
 func NewController(pool db.PgxIface, spec *loads.Document, client *neutron.NeutronClient, n *notifier.Notifier) *Controller {
 	return &Controller{pool: pool, spec: spec, neutron: client, notifier: n, lockTimeout: defaultLockTimeout}
 }
+
+// scheduler returns a service scheduler for placement decisions taken by API
+// handlers. Agents are notified by the handlers themselves after commit.
+func (c *Controller) scheduler() *scheduler.ServiceScheduler {
+	return scheduler.NewServiceScheduler(c.pool,
+		scheduler.Config{StaleTimeout: config.Global.Agent.AgentStaleTimeout}, nil)
+}
@@ -5,9 +5,11 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -74,6 +76,7 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 	var host string
 	var requireApproval bool
 	var serviceNetwork string
+	var servicePorts []int32
 
 	if projectId := auth.GetProjectID(params.HTTPRequest); projectId != "" {
 		params.Body.ProjectID = models.Project(projectId)
@@ -107,7 +110,7 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	// Check if service is accessible
-	sql, args := db.Select("host", "require_approval", "network_id").
+	sql, args := db.Select("host", "require_approval", "network_id", "ports").
 		From("service").
 		Where(sq.Or{
 			sq.Eq{"visibility": "public"},              // public service?
@@ -125,7 +128,7 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 		Suffix("FOR UPDATE"). // Lock service/rbac row in this transaction
 		MustSql()
 
-	if err = tx.QueryRow(ctx, sql, args...).Scan(&host, &requireApproval, &serviceNetwork); err != nil {
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&host, &requireApproval, &serviceNetwork, &servicePorts); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return endpoint.NewPostEndpointBadRequest().WithPayload(&models.Error{
 				Code: 400,
@@ -139,17 +142,33 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 		panic(err)
 	}
 
+	if err = checkListeners(servicePorts, params.Body.Ports, params.Body.PortMappings); err != nil {
+		return endpoint.NewPostEndpointUnprocessableEntity().WithPayload(&models.Error{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+	}
+
 	status := models.EndpointStatusPENDINGCREATE
 	if requireApproval {
 		status = models.EndpointStatusPENDINGAPPROVAL
 	}
 
+	portMappings := params.Body.PortMappings
+	if portMappings == nil {
+		portMappings = make([]*models.PortMapping, 0)
+	}
+
 	// Insert endpoint
 	sql, args = db.Insert("endpoint").
-		Columns("service_id", "project_id", "tags", "name", "description", "status", "connection_mirroring").
+		Columns("service_id", "project_id", "tags", "name", "description", "status", "connection_mirroring",
+			"connection_limit", "rate_limit", "allowed_cidrs", "ports", "port_mappings").
 		Values(params.Body.ServiceID, params.Body.ProjectID, internal.Unique(params.Body.Tags),
-			params.Body.Name, params.Body.Description, status, params.Body.ConnectionMirroring).
-		Suffix("RETURNING id, name, description, service_id, project_id, tags, created_at, updated_at, status, connection_mirroring").
+			params.Body.Name, params.Body.Description, status, params.Body.ConnectionMirroring,
+			params.Body.ConnectionLimit, params.Body.RateLimit,
+			internal.Unique(params.Body.AllowedCidrs), internal.Unique(params.Body.Ports), portMappings).
+		Suffix("RETURNING id, name, description, service_id, project_id, tags, created_at, updated_at, status, " +
+			"connection_mirroring, connection_limit, rate_limit, allowed_cidrs, ports, port_mappings").
 		MustSql()
 	if err = pgxscan.Get(ctx, tx, &endpointResponse, sql, args...); err != nil {
 		panic(err)
@@ -199,25 +218,26 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 		})
 	}
 
-	sql, args = db.Select("physnet").
+	sql, args = db.Select("physnets").
 		From("agents").
 		Where("host = ?", host).
 		MustSql()
-	var physnet pgtype.Text
-	if err = pgxscan.Get(ctx, tx, &physnet, sql, args...); err != nil {
+	var physnets []string
+	if err = pgxscan.Get(ctx, tx, &physnets, sql, args...); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return endpoint.NewPostEndpointBadRequest().WithPayload(&models.Error{
 				Code:    400,
 				Message: fmt.Sprintf("No agent found for host '%s'.", host),
 			})
 		}
-		log.WithError(err).Errorf("Failed to get physical network for host '%s'", host)
+		log.WithError(err).Errorf("Failed to get physical networks for host '%s'", host)
 	}
 	var endpointSegmentID pgtype.Int4
-	if physnet.Valid {
-		// Fetch segment ID from neutron
+	var endpointPhysnet pgtype.Text
+	if len(physnets) > 0 {
+		// Fetch segment ID from neutron, trying each physical network of the agent
 		var seg int
-		seg, err = c.neutron.GetNetworkSegment(ctx, port.NetworkID, physnet.String)
+		seg, endpointPhysnet.String, err = c.neutron.FindNetworkSegment(ctx, port.NetworkID, physnets)
 		if err != nil {
 			if errors.Is(err, aerr.ErrNoPhysNetFound) {
 				log.WithError(err)
@@ -229,13 +249,14 @@ func (c *Controller) PostEndpointHandler(params endpoint.PostEndpointParams, tok
 			log.WithError(err).Errorf("Failed to get segment for network '%s' on host '%s'", port.NetworkID, host)
 		} else {
 			_ = endpointSegmentID.Scan(int64(seg))
+			endpointPhysnet.Valid = true
 		}
 	}
 
 	sql, args = db.Insert("endpoint_port").
-		Columns("endpoint_id", "port_id", "subnet", "network", "ip_address", "owned", "segment_id").
+		Columns("endpoint_id", "port_id", "subnet", "network", "ip_address", "owned", "segment_id", "physnet").
 		Values(endpointResponse.ID, port.ID, port.FixedIPs[0].SubnetID, port.NetworkID, port.FixedIPs[0].IPAddress,
-			owned, endpointSegmentID).
+			owned, endpointSegmentID, endpointPhysnet).
 		Suffix("RETURNING port_id, subnet, network").
 		MustSql()
 	row := tx.QueryRow(params.HTTPRequest.Context(), sql, args...)
@@ -294,12 +315,65 @@ func (c *Controller) GetEndpointEndpointIDHandler(params endpoint.GetEndpointEnd
 }
 
 func (c *Controller) PutEndpointEndpointIDHandler(params endpoint.PutEndpointEndpointIDParams, _ any) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+	tx, err := db.BeginWithLockTimeout(ctx, c.pool, c.lockTimeout)
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if params.Body.Ports != nil || params.Body.PortMappings != nil {
+		q := db.Select("service.ports", "endpoint.ports", "endpoint.port_mappings").
+			From("endpoint").
+			Join("service ON service.id = endpoint.service_id").
+			Where("endpoint.id = ?", params.EndpointID).
+			Suffix("FOR SHARE OF service") // Keep the service ports until the endpoint is updated
+		if projectId := auth.GetProjectID(params.HTTPRequest); projectId != "" {
+			q = q.Where("endpoint.project_id = ?", projectId)
+		}
+
+		var servicePorts, endpointPorts []int32
+		var portMappings []*models.PortMapping
+		sql, args := q.MustSql()
+		if err := tx.QueryRow(ctx, sql, args...).
+			Scan(&servicePorts, &endpointPorts, &portMappings); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return endpoint.NewPutEndpointEndpointIDNotFound().WithPayload(&models.Error{
+					Code:    404,
+					Message: fmt.Sprintf("Endpoint with id '%s' not found.", params.EndpointID),
+				})
+			}
+			if db.IsLockTimeout(err) {
+				db.LogLockBlockers(ctx, c.pool, "service")
+			}
+			panic(err)
+		}
+		if params.Body.Ports != nil {
+			endpointPorts = params.Body.Ports
+		}
+		if params.Body.PortMappings != nil {
+			portMappings = params.Body.PortMappings
+		}
+		if err := checkListeners(servicePorts, endpointPorts, portMappings); err != nil {
+			return endpoint.NewPutEndpointEndpointIDUnprocessableEntity().WithPayload(&models.Error{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			})
+		}
+	}
+
 	u := db.Update("endpoint").
 		Prefix("WITH endpoint AS (").
 		Set("tags", sq.Expr("COALESCE(?, tags)", internal.UniqueOrNil(params.Body.Tags))).
 		Set("name", sq.Expr("COALESCE(?, name)", params.Body.Name)).
 		Set("description", sq.Expr("COALESCE(?, description)", params.Body.Description)).
 		Set("connection_mirroring", sq.Expr("COALESCE(?, connection_mirroring)", params.Body.ConnectionMirroring)).
+		// Limits: 0 lifts the limit of the service for this endpoint, -1 inherits it again.
+		Set("connection_limit", sq.Expr("NULLIF(COALESCE(?, connection_limit), -1)", params.Body.ConnectionLimit)).
+		Set("rate_limit", sq.Expr("NULLIF(COALESCE(?, rate_limit), -1)", params.Body.RateLimit)).
+		Set("allowed_cidrs", sq.Expr("COALESCE(?, allowed_cidrs)", internal.UniqueOrNil(params.Body.AllowedCidrs))).
+		Set("ports", sq.Expr("COALESCE(?, ports)", internal.UniqueOrNil(params.Body.Ports))).
+		Set("port_mappings", sq.Expr("COALESCE(?, port_mappings)", params.Body.PortMappings)).
 		Set("updated_at", sq.Expr("NOW()")).
 		Set("status", sq.Expr("CASE WHEN status = ? THEN ? ELSE status END",
 			models.EndpointStatusAVAILABLE, models.EndpointStatusPENDINGUPDATE)).
@@ -321,7 +395,7 @@ func (c *Controller) PutEndpointEndpointIDHandler(params endpoint.PutEndpointEnd
 
 	sql, args := q.MustSql()
 	var endpointResponse models.Endpoint
-	if err := pgxscan.Get(params.HTTPRequest.Context(), c.pool, &endpointResponse, sql, args...); err != nil {
+	if err := pgxscan.Get(ctx, tx, &endpointResponse, sql, args...); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return endpoint.NewPutEndpointEndpointIDNotFound().WithPayload(&models.Error{
 				Code:    404,
@@ -336,9 +410,15 @@ func (c *Controller) PutEndpointEndpointIDHandler(params endpoint.PutEndpointEnd
 		Join("endpoint ON endpoint.service_id = service.id").
 		Where("endpoint.id = ?", params.EndpointID).
 		MustSql()
-	var host string
-	if err := pgxscan.Get(params.HTTPRequest.Context(), c.pool, &host, sql, args...); err == nil {
-		db.NotifyEndpoint(c.pool, host, params.EndpointID)
+	var host *string
+	if err := pgxscan.Get(ctx, tx, &host, sql, args...); err != nil {
+		panic(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		panic(err)
+	}
+	if host != nil {
+		db.NotifyEndpoint(c.pool, *host, params.EndpointID)
 	}
 	return endpoint.NewPutEndpointEndpointIDOK().WithPayload(&endpointResponse)
 }
@@ -379,3 +459,82 @@ func (c *Controller) DeleteEndpointEndpointIDHandler(params endpoint.DeleteEndpo
 	db.NotifyEndpoint(c.pool, host, params.EndpointID)
 	return endpoint.NewDeleteEndpointEndpointIDAccepted()
 }
+
+// checkListeners ensures the endpoint only exposes ports of the service and
+// no two of its listeners share a listen port.
+func checkListeners(servicePorts, endpointPorts []int32, mappings []*models.PortMapping) error {
+	for _, port := range endpointPorts {
+		if !slices.Contains(servicePorts, port) {
+			return fmt.Errorf("%w: port %d is not exposed by the service", aerr.ErrInvalidPorts, port)
+		}
+	}
+
+	exposed := servicePorts
+	if len(endpointPorts) > 0 {
+		exposed = endpointPorts
+	}
+	listenPorts := make(map[int32]int32, len(exposed))
+	for _, port := range exposed {
+		listenPorts[port] = port
+	}
+	for i, mapping := range mappings {
+		if !slices.Contains(exposed, *mapping.ServicePort) {
+			return fmt.Errorf("%w: mapped port %d is not exposed by the endpoint", aerr.ErrInvalidPorts, *mapping.ServicePort)
+		}
+		if slices.ContainsFunc(mappings[:i], func(m *models.PortMapping) bool { return *m.ServicePort == *mapping.ServicePort }) {
+			return fmt.Errorf("%w: port %d is mapped more than once", aerr.ErrInvalidPorts, *mapping.ServicePort)
+		}
+		listenPorts[*mapping.ServicePort] = *mapping.ListenPort
+	}
+
+	used := make(map[int32]bool, len(listenPorts))
+	for _, listenPort := range listenPorts {
+		if used[listenPort] {
+			return fmt.Errorf("%w: listen port %d is used more than once", aerr.ErrInvalidPorts, listenPort)
+		}
+		used[listenPort] = true
+	}
+	return nil
+}
+
+// checkEndpointPorts refuses service ports which would drop ports selected or
+// mapped by an endpoint of the service.
+func checkEndpointPorts(ctx context.Context, tx pgx.Tx, serviceID strfmt.UUID, servicePorts []int32) error {
+	sql, args := db.Select("id", "ports", "port_mappings").
+		From("endpoint").
+		Where("service_id = ?", serviceID).
+		Where("status != ?", models.EndpointStatusPENDINGDELETE).
+		MustSql()
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	var endpointID strfmt.UUID
+	var endpointPorts []int32
+	var mappings []*models.PortMapping
+	_, err = pgx.ForEachRow(rows, []any{&endpointID, &endpointPorts, &mappings}, func() error {
+		if removed := removedPorts(servicePorts, endpointPorts, mappings); len(removed) > 0 {
+			return fmt.Errorf("%w: endpoint %s still exposes service ports %v", aerr.ErrPortsInUse, endpointID, removed)
+		}
+		return nil
+	})
+	return err
+}
+
+// removedPorts returns the ports selected or mapped by an endpoint which are not
+// ports of the service.
+func removedPorts(servicePorts, endpointPorts []int32, mappings []*models.PortMapping) []int32 {
+	var removed []int32
+	for _, port := range endpointPorts {
+		if !slices.Contains(servicePorts, port) {
+			removed = append(removed, port)
+		}
+	}
+	for _, mapping := range mappings {
+		if !slices.Contains(servicePorts, *mapping.ServicePort) && !slices.Contains(removed, *mapping.ServicePort) {
+			removed = append(removed, *mapping.ServicePort)
+		}
+	}
+	return removed
+}
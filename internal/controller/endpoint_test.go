@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"testing"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -580,3 +581,184 @@ func (t *SuiteTest) TestEndpointPutConnectionMirroring() {
 	assert.IsType(t.T(), &endpoint.GetEndpointEndpointIDOK{}, res)
 	assert.Equal(t.T(), models.EndpointStatusPENDINGUPDATE, res.(*endpoint.GetEndpointEndpointIDOK).Payload.Status)
 }
+
+func (t *SuiteTest) TestEndpointPutLimits() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	serviceID := t.createService(testService)
+	payload := t.createEndpoint(serviceID, models.EndpointTarget{
+		Network: &network,
+	})
+	assert.Nil(t.T(), payload.ConnectionLimit)
+	assert.Nil(t.T(), payload.RateLimit)
+
+	_, err := t.c.pool.Exec(context.Background(),
+		"UPDATE endpoint SET status = $1 WHERE id = $2",
+		models.EndpointStatusAVAILABLE, payload.ID)
+	assert.NoError(t.T(), err)
+
+	// override the service limits
+	res := t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{ConnectionLimit: conv.Pointer(int32(100)), RateLimit: conv.Pointer(int32(10))}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), int32(100), *res.(*endpoint.PutEndpointEndpointIDOK).Payload.ConnectionLimit)
+	assert.Equal(t.T(), int32(10), *res.(*endpoint.PutEndpointEndpointIDOK).Payload.RateLimit)
+
+	// 0 lifts the service limit, omitted values stay untouched
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{ConnectionLimit: conv.Pointer(int32(0))}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), int32(0), *res.(*endpoint.PutEndpointEndpointIDOK).Payload.ConnectionLimit)
+	assert.Equal(t.T(), int32(10), *res.(*endpoint.PutEndpointEndpointIDOK).Payload.RateLimit)
+
+	// -1 inherits the service limits again
+	body := endpoint.PutEndpointEndpointIDBody{ConnectionLimit: conv.Pointer(int32(-1)), RateLimit: conv.Pointer(int32(-1))}
+	assert.NoError(t.T(), body.Validate(strfmt.Default))
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID, Body: body},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Nil(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.ConnectionLimit)
+	assert.Nil(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.RateLimit)
+
+	// anything below -1 is invalid
+	body = endpoint.PutEndpointEndpointIDBody{RateLimit: conv.Pointer(int32(-2))}
+	assert.Error(t.T(), body.Validate(strfmt.Default))
+}
+
+func (t *SuiteTest) TestEndpointPutAllowedCIDRs() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	serviceID := t.createService(testService)
+	payload := t.createEndpoint(serviceID, models.EndpointTarget{
+		Network: &network,
+	})
+	assert.Empty(t.T(), payload.AllowedCidrs)
+
+	res := t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{AllowedCidrs: []models.CIDR{"10.180.0.0/16", "2001:db8::/32"}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), []models.CIDR{"10.180.0.0/16", "2001:db8::/32"},
+		res.(*endpoint.PutEndpointEndpointIDOK).Payload.AllowedCidrs)
+
+	// omitted list stays untouched
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{Name: conv.Pointer("restricted")}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Len(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.AllowedCidrs, 2)
+
+	// empty list allows all sources again
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{AllowedCidrs: []models.CIDR{}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Empty(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.AllowedCidrs)
+}
+
+func (t *SuiteTest) TestEndpointPutPorts() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	svc := testService
+	svc.Ports = []int32{80, 443}
+	serviceID := t.createService(svc)
+	payload := t.createEndpoint(serviceID, models.EndpointTarget{
+		Network: &network,
+	})
+	assert.Empty(t.T(), payload.Ports)
+
+	// only ports of the service can be selected
+	res := t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{Ports: []int32{8443}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDUnprocessableEntity{}, res)
+
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{Ports: []int32{443}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), []int32{443}, res.(*endpoint.PutEndpointEndpointIDOK).Payload.Ports)
+
+	// empty list exposes all ports of the service again
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{Ports: []int32{}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Empty(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.Ports)
+}
+
+func (t *SuiteTest) TestEndpointPutPortMappings() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	svc := testService
+	svc.Ports = []int32{80, 443}
+	serviceID := t.createService(svc)
+	payload := t.createEndpoint(serviceID, models.EndpointTarget{
+		Network: &network,
+	})
+	assert.Empty(t.T(), payload.PortMappings)
+
+	for _, mappings := range [][]*models.PortMapping{
+		// only exposed ports can be mapped
+		{{ListenPort: new(int32(8080)), ServicePort: new(int32(8443))}},
+		// listen port is taken by another service port
+		{{ListenPort: new(int32(80)), ServicePort: new(int32(443))}},
+		// service port mapped twice
+		{
+			{ListenPort: new(int32(8443)), ServicePort: new(int32(443))},
+			{ListenPort: new(int32(9443)), ServicePort: new(int32(443))},
+		},
+	} {
+		res := t.c.PutEndpointEndpointIDHandler(
+			endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+				Body: endpoint.PutEndpointEndpointIDBody{PortMappings: mappings}},
+			nil)
+		assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDUnprocessableEntity{}, res)
+	}
+
+	mappings := []*models.PortMapping{{ListenPort: new(int32(8443)), ServicePort: new(int32(443))}}
+	res := t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{PortMappings: mappings}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), mappings, res.(*endpoint.PutEndpointEndpointIDOK).Payload.PortMappings)
+
+	// the mapped port can't be removed from the service
+	res2 := t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Ports: []int32{80}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDConflict{}, res2)
+
+	// the mapped port can't be dropped from the exposed ports
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{Ports: []int32{80}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDUnprocessableEntity{}, res)
+
+	// empty list listens on the service ports again
+	res = t.c.PutEndpointEndpointIDHandler(
+		endpoint.PutEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: payload.ID,
+			Body: endpoint.PutEndpointEndpointIDBody{PortMappings: []*models.PortMapping{}}},
+		nil)
+	assert.IsType(t.T(), &endpoint.PutEndpointEndpointIDOK{}, res)
+	assert.Empty(t.T(), res.(*endpoint.PutEndpointEndpointIDOK).Payload.PortMappings)
+}
+
+func TestRemovedPorts(t *testing.T) {
+	mappings := []*models.PortMapping{{ListenPort: new(int32(8443)), ServicePort: new(int32(443))}}
+	assert.Empty(t, removedPorts([]int32{80, 443}, nil, nil))
+	assert.Empty(t, removedPorts([]int32{80, 443}, []int32{443}, mappings))
+	assert.Equal(t, []int32{443}, removedPorts([]int32{80}, nil, mappings))
+	assert.Equal(t, []int32{443}, removedPorts([]int32{80}, []int32{443}, mappings), "reported once")
+	assert.Equal(t, []int32{80}, removedPorts([]int32{443}, []int32{80, 443}, nil))
+}
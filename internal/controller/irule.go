@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/sapcc/archer/v2/internal/db"
+	aerr "github.com/sapcc/archer/v2/internal/errors"
+	"github.com/sapcc/archer/v2/models"
+	"github.com/sapcc/archer/v2/restapi/operations/irule"
+)
+
+func (c *Controller) GetIrulesHandler(params irule.GetIrulesParams, _ any) middleware.Responder {
+	q := db.Select("*").From("irule")
+	pagination := db.NewPagination(params)
+
+	sql, args, err := pagination.Query(c.pool, q)
+	if err != nil {
+		panic(err)
+	}
+
+	var items = make([]*models.Irule, 0)
+	if err := pgxscan.Select(params.HTTPRequest.Context(), c.pool, &items, sql, args...); err != nil {
+		var pe *pgconn.PgError
+		if errors.As(err, &pe) && pe.Code == pgerrcode.UndefinedColumn {
+			return irule.NewGetIrulesBadRequest().WithPayload(&models.Error{
+				Code:    400,
+				Message: "Unknown sort column.",
+			})
+		}
+		panic(err)
+	}
+	links := pagination.GetLinks(items)
+	return irule.NewGetIrulesOK().WithPayload(&irule.GetIrulesOKBody{Items: items, Links: links})
+}
+
+func (c *Controller) PostIrulesHandler(params irule.PostIrulesParams, _ any) middleware.Responder {
+	var iruleResponse models.Irule
+
+	sql, args := db.Insert("irule").
+		Columns("name", "description", "content").
+		Values(params.Body.Name, params.Body.Description, params.Body.Content).
+		Suffix("RETURNING *").
+		MustSql()
+	if err := pgxscan.Get(params.HTTPRequest.Context(), c.pool, &iruleResponse, sql, args...); err != nil {
+		var pe *pgconn.PgError
+		if errors.As(err, &pe) && pe.Code == pgerrcode.UniqueViolation {
+			return irule.NewPostIrulesConflict().WithPayload(&models.Error{
+				Code:    409,
+				Message: "Duplicate iRule, name already exists",
+			})
+		}
+		panic(err)
+	}
+
+	return irule.NewPostIrulesCreated().WithXTargetID(iruleResponse.ID).WithPayload(&iruleResponse)
+}
+
+func (c *Controller) GetIrulesIruleIDHandler(params irule.GetIrulesIruleIDParams, _ any) middleware.Responder {
+	sql, args := db.Select("*").
+		From("irule").
+		Where("id = ?", params.IruleID).
+		MustSql()
+
+	var iruleResponse models.Irule
+	if err := pgxscan.Get(params.HTTPRequest.Context(), c.pool, &iruleResponse, sql, args...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return irule.NewGetIrulesIruleIDNotFound()
+		}
+		panic(err)
+	}
+
+	return irule.NewGetIrulesIruleIDOK().WithPayload(&iruleResponse)
+}
+
+func (c *Controller) PutIrulesIruleIDHandler(params irule.PutIrulesIruleIDParams, _ any) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	var iruleResponse models.Irule
+	var endpoints []struct {
+		ID   strfmt.UUID
+		Host string
+	}
+	if err := pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
+		sql, args := db.Update("irule").
+			Set("description", sq.Expr("COALESCE(?, description)", params.Body.Description)).
+			Set("content", sq.Expr("COALESCE(?, content)", params.Body.Content)).
+			Set("updated_at", sq.Expr("NOW()")).
+			Where("id = ?", params.IruleID).
+			Suffix("RETURNING *").
+			MustSql()
+		if err := pgxscan.Get(ctx, tx, &iruleResponse, sql, args...); err != nil {
+			return err
+		}
+
+		if params.Body.Content == nil {
+			return nil
+		}
+
+		// Re-process the endpoints of all services using the iRule
+		sql, args = db.Update("endpoint").
+			Set("status", models.EndpointStatusPENDINGUPDATE).
+			Set("updated_at", sq.Expr("NOW()")).
+			From("service").
+			Where("endpoint.service_id = service.id").
+			Where("? = ANY(service.irules)", iruleResponse.Name).
+			Where("endpoint.status = ?", models.EndpointStatusAVAILABLE).
+			Suffix("RETURNING endpoint.id, service.host").
+			MustSql()
+		return pgxscan.Select(ctx, tx, &endpoints, sql, args...)
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return irule.NewPutIrulesIruleIDNotFound()
+		}
+		panic(err)
+	}
+
+	for _, ep := range endpoints {
+		db.NotifyEndpoint(c.pool, ep.Host, ep.ID)
+	}
+	return irule.NewPutIrulesIruleIDOK().WithPayload(&iruleResponse)
+}
+
+func (c *Controller) DeleteIrulesIruleIDHandler(params irule.DeleteIrulesIruleIDParams, _ any) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	if err := pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
+		// Lock the iRule, services attaching it concurrently wait for us
+		var name string
+		sql, args := db.Select("name").
+			From("irule").
+			Where("id = ?", params.IruleID).
+			Suffix("FOR UPDATE").
+			MustSql()
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&name); err != nil {
+			return err
+		}
+
+		var inUse bool
+		sql, args = db.Select().
+			Column(sq.Expr("EXISTS(SELECT 1 FROM service WHERE ? = ANY(irules))", name)).
+			MustSql()
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return aerr.ErrIRuleInUse
+		}
+
+		sql, args = db.Delete("irule").Where("id = ?", params.IruleID).MustSql()
+		_, err := tx.Exec(ctx, sql, args...)
+		return err
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return irule.NewDeleteIrulesIruleIDNotFound()
+		}
+		if errors.Is(err, aerr.ErrIRuleInUse) {
+			return irule.NewDeleteIrulesIruleIDConflict().WithPayload(&models.Error{
+				Code:    http.StatusConflict,
+				Message: "iRule is in use by a service",
+			})
+		}
+		panic(err)
+	}
+
+	return irule.NewDeleteIrulesIruleIDNoContent()
+}
+
+// checkIRules ensures all names are in the iRule catalog. The iRules are locked
+// until the end of the transaction, so they can't be removed concurrently.
+func checkIRules(ctx context.Context, tx pgx.Tx, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	var found []string
+	sql, args := db.Select("name").
+		From("irule").
+		Where("name = ANY(?)", names).
+		Suffix("FOR SHARE").
+		MustSql()
+	if err := pgxscan.Select(ctx, tx, &found, sql, args...); err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, name := range names {
+		if !slices.Contains(found, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", aerr.ErrUnknownIRule, strings.Join(unknown, ", "))
+	}
+	return nil
+}
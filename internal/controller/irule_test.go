@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag/conv"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sapcc/archer/v2/models"
+	"github.com/sapcc/archer/v2/restapi/operations/irule"
+	"github.com/sapcc/archer/v2/restapi/operations/service"
+)
+
+func (t *SuiteTest) createIRule(name string) strfmt.UUID {
+	res := t.c.PostIrulesHandler(irule.PostIrulesParams{HTTPRequest: &http.Request{}, Body: &models.Irule{
+		Name:    &name,
+		Content: conv.Pointer("when HTTP_REQUEST { }"),
+	}}, nil)
+
+	assert.IsType(t.T(), &irule.PostIrulesCreated{}, res)
+	payload := res.(*irule.PostIrulesCreated).Payload
+	assert.Equal(t.T(), name, *payload.Name)
+	return payload.ID
+}
+
+func (t *SuiteTest) TestIRulePostDuplicate() {
+	t.createIRule("xff")
+
+	res := t.c.PostIrulesHandler(irule.PostIrulesParams{HTTPRequest: &http.Request{}, Body: &models.Irule{
+		Name:    conv.Pointer("xff"),
+		Content: conv.Pointer("when HTTP_REQUEST { }"),
+	}}, nil)
+	assert.IsType(t.T(), &irule.PostIrulesConflict{}, res)
+}
+
+func (t *SuiteTest) TestIRulePut() {
+	u := t.createIRule("xff")
+
+	res := t.c.PutIrulesIruleIDHandler(irule.PutIrulesIruleIDParams{
+		HTTPRequest: &http.Request{},
+		IruleID:     u,
+		Body:        &models.IruleUpdatable{Description: conv.Pointer("X-Forwarded-For")},
+	}, nil)
+	assert.IsType(t.T(), &irule.PutIrulesIruleIDOK{}, res)
+	payload := res.(*irule.PutIrulesIruleIDOK).Payload
+	assert.Equal(t.T(), "X-Forwarded-For", payload.Description)
+	assert.Equal(t.T(), "when HTTP_REQUEST { }", *payload.Content)
+}
+
+func (t *SuiteTest) TestIRuleServiceAttach() {
+	u := t.createIRule("xff")
+
+	svc := testService
+	svc.Provider = conv.Pointer(models.ServiceProviderTenant)
+	svc.Irules = []string{"unknown"}
+	t.ResetHttpServer()
+	t.setupNeutronHandlersForServiceCreate(*svc.NetworkID)
+	res := t.c.PostServiceHandler(service.PostServiceParams{HTTPRequest: &headerProject1, Body: &svc}, nil)
+	assert.IsType(t.T(), &service.PostServiceBadRequest{}, res)
+
+	svc.Irules = []string{"xff"}
+	serviceID := t.createService(svc)
+
+	// iRule in use can't be deleted
+	res = t.c.DeleteIrulesIruleIDHandler(irule.DeleteIrulesIruleIDParams{HTTPRequest: &http.Request{}, IruleID: u}, nil)
+	assert.IsType(t.T(), &irule.DeleteIrulesIruleIDConflict{}, res)
+
+	// detach and delete
+	res = t.c.PutServiceServiceIDHandler(service.PutServiceServiceIDParams{
+		HTTPRequest: &headerProject1,
+		ServiceID:   serviceID,
+		Body:        &models.ServiceUpdatable{Irules: []string{}},
+	}, nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Empty(t.T(), res.(*service.PutServiceServiceIDOK).Payload.Irules)
+
+	res = t.c.DeleteIrulesIruleIDHandler(irule.DeleteIrulesIruleIDParams{HTTPRequest: &http.Request{}, IruleID: u}, nil)
+	assert.IsType(t.T(), &irule.DeleteIrulesIruleIDNoContent{}, res)
+}
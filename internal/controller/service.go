@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/dbscan"
@@ -33,6 +34,7 @@ import (
 	"github.com/sapcc/archer/v2/internal/db"
 	aerr "github.com/sapcc/archer/v2/internal/errors"
 	"github.com/sapcc/archer/v2/internal/neutron"
+	"github.com/sapcc/archer/v2/internal/scheduler"
 	"github.com/sapcc/archer/v2/models"
 	"github.com/sapcc/archer/v2/restapi/operations/service"
 )
@@ -40,7 +42,7 @@ import (
 // CPNetworkID is the placeholder network ID used for cp provider services that don't have a real network.
 const CPNetworkID = strfmt.UUID("00000000-0000-0000-0000-000000000000")
 
-// maskCPServiceIPAddresses clears IP addresses for CP services unless the user has cloud_admin rights.
+// maskCPServiceIPAddresses clears IP addresses and members for CP services unless the user has cloud_admin rights.
 // This is a security measure to prevent exposing internal service IP addresses to regular users.
 func maskCPServiceIPAddresses(svc *models.Service, principal any) {
 	if svc == nil {
@@ -53,6 +55,7 @@ func maskCPServiceIPAddresses(svc *models.Service, principal any) {
 			}
 		}
 		svc.IPAddresses = nil
+		svc.Members = nil
 	}
 }
 
@@ -189,12 +192,29 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 			Message: err.Error(),
 		})
 	}
+	if err := validateMembers(params.Body.IPAddresses, params.Body.Members); err != nil {
+		return service.NewPostServiceBadRequest().WithPayload(&models.Error{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+	members := params.Body.Members
+	if members == nil {
+		members = make([]*models.ServiceMember, 0)
+	}
 
 	// Set default values
 	if err := c.SetModelDefaults(params.Body); err != nil {
 		panic(err)
 	}
 
+	if err := validateTraffic(*params.Body.Provider, *params.Body.Protocol, params.Body.Traffic); err != nil {
+		return service.NewPostServiceBadRequest().WithPayload(&models.Error{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
 	// Validate wildcard port constraints
 	if err := validatePorts(params.Body.Ports, *params.Body.Provider); err != nil {
 		return service.NewPostServiceBadRequest().WithPayload(&models.Error{
@@ -219,36 +239,43 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 		})
 	}
 
+	// Scheduler hints: empty strings mean "no hint"; pinning is reserved to operators.
+	params.Body.PinnedHost = nilIfEmpty(params.Body.PinnedHost)
+	params.Body.AntiAffinityGroup = nilIfEmpty(params.Body.AntiAffinityGroup)
+	if params.Body.PinnedHost != nil {
+		if t, ok := principal.(*gopherpolicy.Token); ok {
+			if !t.Check("service:create:pinned_host") {
+				return service.NewPostServiceForbidden()
+			}
+		}
+	}
+
+	// iRules are F5 specific and attaching them is reserved to operators.
+	if len(params.Body.Irules) > 0 {
+		if t, ok := principal.(*gopherpolicy.Token); ok {
+			if !t.Check("service:create:irules") {
+				return service.NewPostServiceForbidden()
+			}
+		}
+		if *params.Body.Provider != "tenant" {
+			return service.NewPostServiceUnprocessableEntity().WithPayload(&models.Error{
+				Code:    http.StatusUnprocessableEntity,
+				Message: aerr.ErrIRulesUnsupportedProvider.Error(),
+			})
+		}
+	}
+
 	var host string
+	hints := scheduler.HintsFromService(params.Body)
 	if err := pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
-		// schedule: find least-loaded healthy agent
-		q := db.Select("agents.host", "COUNT(service.id) AS usage").
-			From("agents").
-			LeftJoin("service ON service.host = agents.host").
-			Where(sq.And{
-				sq.Eq{"agents.enabled": true},
-				sq.Eq{"agents.provider": params.Body.Provider},
-				sq.Eq{"agents.availability_zone": params.Body.AvailabilityZone},
-				// Only consider agents with recent heartbeat (within stale timeout)
-				sq.Expr("agents.heartbeat_at > NOW() - INTERVAL '1 second' * ?",
-					int(config.Global.Agent.AgentStaleTimeout.Seconds())),
-			}).
-			OrderBy("usage ASC", "agents.heartbeat_at DESC").
-			GroupBy("agents.host").
-			Limit(1)
-
-		sql, args, err := q.ToSql()
+		// schedule: find least-loaded healthy agent satisfying the scheduler hints
+		var err error
+		host, err = c.scheduler().FindLeastLoadedAgent(ctx, tx, *params.Body.Provider, params.Body.AvailabilityZone, "", hints)
 		if err != nil {
 			return err
 		}
 
-		var usage int
-		if err = c.pool.QueryRow(ctx, sql, args...).Scan(&host, &usage); err != nil {
-			return err
-		}
-
-		log.Infof("Found host '%s' (usage=%d) for service request (provider=%+v)", host, usage,
-			params.Body.Provider)
+		log.Infof("Found host '%s' for service request (provider=%+v)", host, params.Body.Provider)
 		params.Body.Host = &host
 
 		// check for conflicts (only for tenant/F5 provider, not for cp/NI)
@@ -268,6 +295,10 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 			}
 		}
 
+		if err = checkIRules(ctx, tx, params.Body.Irules); err != nil {
+			return err
+		}
+
 		// When the client didn't supply snat_pool_size, bind the literal SQL
 		// DEFAULT keyword so the column's DB DEFAULT (1) applies. A nil bind
 		// would emit SQL NULL, which violates the NOT NULL constraint
@@ -277,15 +308,18 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 		if params.Body.SnatPoolSize != nil {
 			snatPoolSize = *params.Body.SnatPoolSize
 		}
-		sql, args, err = db.Insert("service").
+		sql, args, err := db.Insert("service").
 			Columns("enabled", "name", "description", "network_id", "ip_addresses", "require_approval",
 				"visibility", "availability_zone", "proxy_protocol", "project_id", "ports", "tags", "provider", "host",
-				"protocol", "snat_pool_size").
+				"protocol", "snat_pool_size", "pinned_host", "anti_affinity_group", "connection_limit", "rate_limit", "irules",
+				"members", "traffic").
 			Values(params.Body.Enabled, params.Body.Name, params.Body.Description, params.Body.NetworkID,
 				params.Body.IPAddresses, params.Body.RequireApproval, params.Body.Visibility,
 				params.Body.AvailabilityZone, params.Body.ProxyProtocol, params.Body.ProjectID,
 				params.Body.Ports, internal.Unique(params.Body.Tags), params.Body.Provider, params.Body.Host,
-				params.Body.Protocol, snatPoolSize).
+				params.Body.Protocol, snatPoolSize, params.Body.PinnedHost, params.Body.AntiAffinityGroup,
+				nilIfZero(params.Body.ConnectionLimit), nilIfZero(params.Body.RateLimit),
+				internal.Unique(params.Body.Irules), members, mergeTraffic(nil, params.Body.Traffic)).
 			Suffix("RETURNING *").ToSql()
 		if err != nil {
 			return err
@@ -296,6 +330,14 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 		}
 		return nil
 	}); err != nil {
+		if noAgent, ok := errors.AsType[*scheduler.NoAgentError](err); ok &&
+			(hints.PinnedHost != nil || hints.AntiAffinityGroup != nil || len(hints.Capabilities) > 0) {
+			return service.NewPostServiceConflict().WithPayload(&models.Error{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("No available host agent found: %s.", noAgent.Reason),
+			})
+		}
+
 		if errors.Is(err, pgx.ErrNoRows) {
 			return service.NewPostServiceConflict().WithPayload(&models.Error{
 				Code:    http.StatusConflict,
@@ -310,6 +352,13 @@ func (c *Controller) PostServiceHandler(params service.PostServiceParams, princi
 			})
 		}
 
+		if errors.Is(err, aerr.ErrUnknownIRule) {
+			return service.NewPostServiceBadRequest().WithPayload(&models.Error{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
+
 		if pe, ok := errors.AsType[*pgconn.PgError](err); ok && pgerrcode.IsIntegrityConstraintViolation(pe.Code) {
 			return service.NewPostServiceConflict().WithPayload(&models.Error{
 				Code:    http.StatusConflict,
@@ -355,10 +404,26 @@ func (c *Controller) GetServiceServiceIDHandler(params service.GetServiceService
 	return service.NewGetServiceServiceIDOK().WithPayload(&servicesResponse)
 }
 
-func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceServiceIDParams, _ any) middleware.Responder {
+func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceServiceIDParams, principal any) middleware.Responder {
 	upd := db.Update("service")
 	ctx := params.HTTPRequest.Context()
 
+	if params.Body.PinnedHost != nil {
+		if t, ok := principal.(*gopherpolicy.Token); ok {
+			if !t.Check("service:update:pinned_host") {
+				return service.NewPutServiceServiceIDForbidden()
+			}
+		}
+	}
+
+	if params.Body.Irules != nil {
+		if t, ok := principal.(*gopherpolicy.Token); ok {
+			if !t.Check("service:update:irules") {
+				return service.NewPutServiceServiceIDForbidden()
+			}
+		}
+	}
+
 	if projectId := auth.GetProjectID(params.HTTPRequest); projectId != "" {
 		upd = upd.Where("project_id = ?", projectId)
 	}
@@ -374,26 +439,69 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 	}
 
 	var serviceResponse models.Service
+	var previousHost string
+	var endpointIDsToNotify []strfmt.UUID
 	if err := pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
 		// Check for conflicts only for tenant/F5 provider when IP or ports change
 		var existingProvider string
 		var existingHost string
 		var existingNetworkID *strfmt.UUID
 		var existingIPAddresses []models.InetAddress
+		var existingMembers []*models.ServiceMember
 		var existingPorts []int32
-		q := db.Select("provider", "host", "network_id", "ip_addresses", "ports").
+		var existingAZ *string
+		var existingStatus string
+		var existingSnatPoolSize *int32
+		var existingProtocol string
+		var existingTraffic *models.ServiceTraffic
+		hints := scheduler.Hints{ServiceID: params.ServiceID}
+		q := db.Select("provider", "host", "network_id", "ip_addresses", "members", "ports", "availability_zone",
+			"status", "project_id", "pinned_host", "anti_affinity_group", "snat_pool_size", "protocol", "traffic").
 			From("service").
-			Where("id = ?", params.ServiceID)
+			Where("id = ?", params.ServiceID).
+			Suffix("FOR UPDATE") // Serialize with endpoint updates validating against the service ports
 		sql, args := q.MustSql()
-		if err := tx.QueryRow(ctx, sql, args...).Scan(&existingProvider, &existingHost, &existingNetworkID, &existingIPAddresses, &existingPorts); err != nil {
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&existingProvider, &existingHost, &existingNetworkID,
+			&existingIPAddresses, &existingMembers, &existingPorts, &existingAZ, &existingStatus, &hints.ProjectID, &hints.PinnedHost,
+			&hints.AntiAffinityGroup, &existingSnatPoolSize, &existingProtocol, &existingTraffic); err != nil {
 			return err
 		}
 
+		// Resulting traffic settings must be supported by the provider and the resulting protocol
+		if params.Body.Traffic != nil || params.Body.Protocol != nil {
+			protocol := existingProtocol
+			if params.Body.Protocol != nil {
+				protocol = *params.Body.Protocol
+			}
+			traffic := mergeTraffic(existingTraffic, params.Body.Traffic)
+			if err := validateTraffic(existingProvider, protocol, traffic); err != nil {
+				return err
+			}
+			upd = upd.Set("traffic", traffic)
+		}
+
+		// Members must remain options of the service IP addresses
+		if params.Body.IPAddresses != nil || params.Body.Members != nil {
+			ipAddresses, members := existingIPAddresses, existingMembers
+			if params.Body.IPAddresses != nil {
+				ipAddresses = params.Body.IPAddresses
+			}
+			if params.Body.Members != nil {
+				members = params.Body.Members
+			}
+			if err := validateMembers(ipAddresses, members); err != nil {
+				return err
+			}
+		}
+
 		// Validate wildcard port constraints on update
 		if params.Body.Ports != nil {
 			if err := validatePorts(params.Body.Ports, existingProvider); err != nil {
 				return err
 			}
+			if err := checkEndpointPorts(ctx, tx, params.ServiceID, params.Body.Ports); err != nil {
+				return err
+			}
 		}
 
 		if params.Body.IPAddresses != nil || params.Body.Ports != nil {
@@ -432,6 +540,60 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 			return aerr.ErrSnatPoolSizeUnsupportedProvider
 		}
 
+		if len(params.Body.Irules) > 0 {
+			if existingProvider != "tenant" {
+				return aerr.ErrIRulesUnsupportedProvider
+			}
+			if err := checkIRules(ctx, tx, params.Body.Irules); err != nil {
+				return err
+			}
+		}
+
+		// Changed scheduler hints or required agent capabilities may no longer be
+		// satisfied by the current host, in which case the service is moved as
+		// part of the update.
+		if params.Body.PinnedHost != nil || params.Body.AntiAffinityGroup != nil ||
+			params.Body.Ports != nil || params.Body.SnatPoolSize != nil {
+			if params.Body.PinnedHost != nil {
+				hints.PinnedHost = nilIfEmpty(params.Body.PinnedHost)
+			}
+			if params.Body.AntiAffinityGroup != nil {
+				hints.AntiAffinityGroup = nilIfEmpty(params.Body.AntiAffinityGroup)
+			}
+			ports, snatPoolSize := existingPorts, existingSnatPoolSize
+			if params.Body.Ports != nil {
+				ports = params.Body.Ports
+			}
+			if params.Body.SnatPoolSize != nil {
+				snatPoolSize = params.Body.SnatPoolSize
+			}
+			hints.Capabilities = internal.RequiredCapabilities(ports, snatPoolSize)
+
+			host, err := c.placeWithHints(ctx, tx, existingProvider, existingAZ, existingHost, hints)
+			if err != nil {
+				return err
+			}
+			if host != existingHost {
+				if existingStatus == string(models.ServiceStatusPENDINGUPDATE) {
+					return aerr.ErrMigrationInProgress
+				}
+				if err = moveServiceEndpoints(ctx, tx, params.ServiceID); err != nil {
+					return err
+				}
+				log.WithFields(log.Fields{
+					"service": params.ServiceID,
+					"from":    existingHost,
+					"to":      host,
+				}).Info("Moving service to satisfy updated scheduler constraints")
+				if err = scheduler.RecordMigration(ctx, tx, params.ServiceID, existingHost, host,
+					scheduler.MigrationReasonHints); err != nil {
+					return err
+				}
+				upd = upd.Set("host", host)
+				previousHost = existingHost
+			}
+		}
+
 		upd = upd.Set("enabled", sq.Expr("COALESCE(?, enabled)", params.Body.Enabled)).
 			Set("name", sq.Expr("COALESCE(?, name)", params.Body.Name)).
 			Set("description", sq.Expr("COALESCE(?, description)", params.Body.Description)).
@@ -439,6 +601,7 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 			Set("proxy_protocol", sq.Expr("COALESCE(?, proxy_protocol)", params.Body.ProxyProtocol)).
 			Set("ports", sq.Expr("COALESCE(?, ports)", params.Body.Ports)).
 			Set("ip_addresses", sq.Expr("COALESCE(?, ip_addresses)", params.Body.IPAddresses)).
+			Set("members", sq.Expr("COALESCE(?, members)", params.Body.Members)).
 			Set("visibility", sq.Expr("COALESCE(?, visibility)", params.Body.Visibility)).
 			Set("tags", sq.Expr("COALESCE(?, tags)", internal.UniqueOrNil(params.Body.Tags))).
 			Set("protocol", sq.Expr("COALESCE(?, protocol)", params.Body.Protocol)).
@@ -446,6 +609,14 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 			// PUT body). Resetting to NULL is not currently expressible through this endpoint
 			// because go-swagger's omitempty collapses absent and explicit null.
 			Set("snat_pool_size", sq.Expr("COALESCE(?, snat_pool_size)", params.Body.SnatPoolSize)).
+			// Scheduler hints: an empty string removes the hint.
+			Set("pinned_host", sq.Expr("NULLIF(COALESCE(?, pinned_host), '')", params.Body.PinnedHost)).
+			Set("anti_affinity_group", sq.Expr("NULLIF(COALESCE(?, anti_affinity_group), '')",
+				params.Body.AntiAffinityGroup)).
+			// Limits: 0 removes the limit.
+			Set("connection_limit", sq.Expr("NULLIF(COALESCE(?, connection_limit), 0)", params.Body.ConnectionLimit)).
+			Set("rate_limit", sq.Expr("NULLIF(COALESCE(?, rate_limit), 0)", params.Body.RateLimit)).
+			Set("irules", sq.Expr("COALESCE(?, irules)", internal.UniqueOrNil(params.Body.Irules))).
 			Set("status", models.ServiceStatusPENDINGUPDATE).
 			Set("updated_at", sq.Expr("NOW()")).
 			Where("id = ?", params.ServiceID).
@@ -455,20 +626,50 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 		if err := pgxscan.Get(ctx, tx, &serviceResponse, sql, args...); err != nil {
 			return err
 		}
+
+		// Limits, iRules and traffic settings are applied per endpoint, re-process the endpoints
+		if params.Body.ConnectionLimit != nil || params.Body.RateLimit != nil || params.Body.Irules != nil ||
+			params.Body.Traffic != nil {
+			sql, args = db.Update("endpoint").
+				Set("status", models.EndpointStatusPENDINGUPDATE).
+				Set("updated_at", sq.Expr("NOW()")).
+				Where("service_id = ?", params.ServiceID).
+				Where("status = ?", models.EndpointStatusAVAILABLE).
+				Suffix("RETURNING id").
+				MustSql()
+			if err := pgxscan.Select(ctx, tx, &endpointIDsToNotify, sql, args...); err != nil {
+				return err
+			}
+		}
 		return nil
 	}); err != nil {
+		if noAgent, ok := errors.AsType[*scheduler.NoAgentError](err); ok {
+			return service.NewPutServiceServiceIDConflict().WithPayload(&models.Error{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("No available host agent found: %s.", noAgent.Reason),
+			})
+		}
+
+		if errors.Is(err, aerr.ErrMigrationInProgress) {
+			return service.NewPutServiceServiceIDConflict().WithPayload(&models.Error{
+				Code:    http.StatusConflict,
+				Message: "Migration already in progress; wait for the service to become AVAILABLE before changing its placement",
+			})
+		}
+
 		if errors.Is(err, pgx.ErrNoRows) {
 			return service.NewPutServiceServiceIDNotFound()
 		}
 
-		if errors.Is(err, aerr.ErrSnatIPConflict) {
+		if errors.Is(err, aerr.ErrSnatIPConflict) || errors.Is(err, aerr.ErrPortsInUse) {
 			return service.NewPutServiceServiceIDConflict().WithPayload(&models.Error{
 				Code:    http.StatusConflict,
 				Message: err.Error(),
 			})
 		}
 
-		if errors.Is(err, aerr.ErrInvalidPorts) {
+		if errors.Is(err, aerr.ErrInvalidPorts) || errors.Is(err, aerr.ErrInvalidMembers) ||
+			errors.Is(err, aerr.ErrInvalidTraffic) {
 			return service.NewPutServiceServiceIDBadRequest().WithPayload(&models.Error{
 				Code:    http.StatusBadRequest,
 				Message: err.Error(),
@@ -482,6 +683,20 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 			})
 		}
 
+		if errors.Is(err, aerr.ErrIRulesUnsupportedProvider) {
+			return service.NewPutServiceServiceIDUnprocessableEntity().WithPayload(&models.Error{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, aerr.ErrUnknownIRule) {
+			return service.NewPutServiceServiceIDBadRequest().WithPayload(&models.Error{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
+
 		if pe, ok := errors.AsType[*pgconn.PgError](err); ok && pgerrcode.IsIntegrityConstraintViolation(pe.Code) {
 			return service.NewPutServiceServiceIDConflict().WithPayload(&models.Error{
 				Code:    http.StatusConflict,
@@ -491,7 +706,13 @@ func (c *Controller) PutServiceServiceIDHandler(params service.PutServiceService
 		panic(err)
 	}
 
+	if previousHost != "" {
+		db.NotifyService(c.pool, previousHost)
+	}
 	db.NotifyService(c.pool, *serviceResponse.Host)
+	for _, id := range endpointIDsToNotify {
+		db.NotifyEndpoint(c.pool, *serviceResponse.Host, id)
+	}
 	return service.NewPutServiceServiceIDOK().WithPayload(&serviceResponse)
 }
 
@@ -827,6 +1048,76 @@ func validateIPAddresses(ipAddresses []models.InetAddress) error {
 	return nil
 }
 
+// validateMembers ensures the load balancing options apply to distinct IP addresses of the service.
+func validateMembers(ipAddresses []models.InetAddress, members []*models.ServiceMember) error {
+	for i, member := range members {
+		if !slices.ContainsFunc(ipAddresses, func(ip models.InetAddress) bool {
+			return internal.SameAddress(ip, *member.IPAddress)
+		}) {
+			return fmt.Errorf("%w: %s is not an IP address of the service", aerr.ErrInvalidMembers, *member.IPAddress)
+		}
+		if slices.ContainsFunc(members[:i], func(m *models.ServiceMember) bool {
+			return internal.SameAddress(*m.IPAddress, *member.IPAddress)
+		}) {
+			return fmt.Errorf("%w: options for %s are given more than once", aerr.ErrInvalidMembers, *member.IPAddress)
+		}
+	}
+	return nil
+}
+
+// validateTraffic ensures the given traffic settings are supported by the provider and protocol
+// of the service. Only the HAProxy based cp provider can tune timeouts beyond idling.
+func validateTraffic(provider, protocol string, traffic *models.ServiceTraffic) error {
+	if traffic == nil {
+		return nil
+	}
+	if provider != "cp" && (traffic.ConnectTimeout != nil || traffic.TunnelTimeout != nil || traffic.HTTPKeepAlive != nil) {
+		return fmt.Errorf("%w: connect_timeout, tunnel_timeout and http_keep_alive are only supported for provider=cp",
+			aerr.ErrInvalidTraffic)
+	}
+	if traffic.HTTPKeepAlive != nil && protocol != models.ServiceProtocolHTTP {
+		return fmt.Errorf("%w: http_keep_alive is only supported for protocol=HTTP", aerr.ErrInvalidTraffic)
+	}
+	return nil
+}
+
+// mergeTraffic applies the given traffic settings to the current ones, 0 and http_keep_alive
+// client reset a setting to its default. Returns nil if all settings are at their defaults.
+func mergeTraffic(current, update *models.ServiceTraffic) *models.ServiceTraffic {
+	var traffic models.ServiceTraffic
+	if current != nil {
+		traffic = *current
+	}
+	if update != nil {
+		if update.MaxConnections != nil {
+			traffic.MaxConnections = update.MaxConnections
+		}
+		if update.ConnectTimeout != nil {
+			traffic.ConnectTimeout = update.ConnectTimeout
+		}
+		if update.IdleTimeout != nil {
+			traffic.IdleTimeout = update.IdleTimeout
+		}
+		if update.TunnelTimeout != nil {
+			traffic.TunnelTimeout = update.TunnelTimeout
+		}
+		if update.HTTPKeepAlive != nil {
+			traffic.HTTPKeepAlive = update.HTTPKeepAlive
+		}
+	}
+	traffic.MaxConnections = nilIfZero(traffic.MaxConnections)
+	traffic.ConnectTimeout = nilIfZero(traffic.ConnectTimeout)
+	traffic.IdleTimeout = nilIfZero(traffic.IdleTimeout)
+	traffic.TunnelTimeout = nilIfZero(traffic.TunnelTimeout)
+	if traffic.HTTPKeepAlive != nil && *traffic.HTTPKeepAlive == models.ServiceTrafficHTTPKeepAliveClient {
+		traffic.HTTPKeepAlive = nil
+	}
+	if traffic == (models.ServiceTraffic{}) {
+		return nil
+	}
+	return &traffic
+}
+
 // checkSnatIPConflict verifies that the proposed service IP addresses do not collide with
 // SNAT ports already allocated on the same host/network. SNAT ports are allocated by the F5
 // agent from the same subnet and tracked as Neutron ports with device_owner "network:f5snat".
@@ -879,6 +1170,7 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 	var provider string
 	var az *string
 	var currentStatus string
+	hints := scheduler.Hints{ServiceID: params.ServiceID}
 
 	if err := pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
 		if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL lock_timeout = %d", c.lockTimeout.Milliseconds())); err != nil {
@@ -886,15 +1178,20 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 		}
 
 		// Get current service details
-		sql, args := db.Select("host", "provider", "availability_zone", "status").
+		var ports []int32
+		var snatPoolSize *int32
+		sql, args := db.Select("host", "provider", "availability_zone", "status", "project_id", "pinned_host",
+			"anti_affinity_group", "ports", "snat_pool_size").
 			From("service").
 			Where("id = ?", params.ServiceID).
 			Suffix("FOR UPDATE").
 			MustSql()
 
-		if err := tx.QueryRow(ctx, sql, args...).Scan(&currentHost, &provider, &az, &currentStatus); err != nil {
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&currentHost, &provider, &az, &currentStatus,
+			&hints.ProjectID, &hints.PinnedHost, &hints.AntiAffinityGroup, &ports, &snatPoolSize); err != nil {
 			return err
 		}
+		hints.Capabilities = internal.RequiredCapabilities(ports, snatPoolSize)
 
 		// Reject re-migration while in flight; resetting PENDING_UPDATE would prevent convergence.
 		if currentStatus == string(models.ServiceStatusPENDINGUPDATE) {
@@ -905,16 +1202,19 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 		if params.Body.TargetHost != "" {
 			targetHost = params.Body.TargetHost
 
-			// Validate target host exists and is healthy
-			sql, args = db.Select("1").
+			// Validate target host exists, is healthy and capable
+			q := db.Select("1").
 				From("agents").
 				Where("host = ?", targetHost).
 				Where("enabled = true").
 				Where("provider = ?", provider).
 				Where(sq.Eq{"availability_zone": az}).
 				Where("heartbeat_at > NOW() - INTERVAL '1 second' * ?",
-					int(config.Global.Agent.AgentStaleTimeout.Seconds())).
-				MustSql()
+					int(config.Global.Agent.AgentStaleTimeout.Seconds()))
+			if len(hints.Capabilities) > 0 {
+				q = q.Where(scheduler.CapableAgents("capabilities", hints.Capabilities))
+			}
+			sql, args = q.MustSql()
 
 			var exists int
 			if err := tx.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
@@ -923,30 +1223,24 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 				}
 				return err
 			}
-		} else {
-			// Find least-loaded agent (exclude current host)
-			q := db.Select("agents.host", "COUNT(service.id) AS usage").
-				From("agents").
-				LeftJoin("service ON service.host = agents.host").
-				Where(sq.And{
-					sq.Eq{"agents.enabled": true},
-					sq.Eq{"agents.provider": provider},
-					sq.Eq{"agents.availability_zone": az},
-					sq.NotEq{"agents.host": currentHost},
-					sq.Expr("agents.heartbeat_at > NOW() - INTERVAL '1 second' * ?",
-						int(config.Global.Agent.AgentStaleTimeout.Seconds())),
-				}).
-				GroupBy("agents.host").
-				OrderBy("usage ASC", "agents.heartbeat_at DESC").
-				Limit(1)
-
-			sql, args, err := q.ToSql()
+
+			// An explicit target must still satisfy the service's scheduler hints.
+			if hints.PinnedHost != nil && *hints.PinnedHost != targetHost {
+				return &scheduler.NoAgentError{Reason: fmt.Sprintf("service is pinned to host %q", *hints.PinnedHost)}
+			}
+			conflict, err := c.scheduler().HasAntiAffinityConflict(ctx, tx, targetHost, hints)
 			if err != nil {
 				return err
 			}
-
-			var usage int
-			if err = tx.QueryRow(ctx, sql, args...).Scan(&targetHost, &usage); err != nil {
+			if conflict {
+				return &scheduler.NoAgentError{Reason: fmt.Sprintf(
+					"target host %q already hosts a service of anti-affinity group %q", targetHost, *hints.AntiAffinityGroup)}
+			}
+		} else {
+			// Find least-loaded agent satisfying the scheduler hints (exclude current host)
+			var err error
+			targetHost, err = c.scheduler().FindLeastLoadedAgent(ctx, tx, provider, az, currentHost, hints)
+			if err != nil {
 				return err
 			}
 		}
@@ -974,20 +1268,14 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 			return err
 		}
 
-		// Update all AVAILABLE endpoints to PENDING_UPDATE
-		sql, args = db.Update("endpoint").
-			Set("status", models.EndpointStatusPENDINGUPDATE).
-			Set("updated_at", sq.Expr("NOW()")).
-			Where("service_id = ?", params.ServiceID).
-			Where("status = ?", models.EndpointStatusAVAILABLE).
-			MustSql()
-
-		if _, err := tx.Exec(ctx, sql, args...); err != nil {
-			return err
-		}
-
-		return nil
+		return moveServiceEndpoints(ctx, tx, params.ServiceID)
 	}); err != nil {
+		if noAgent, ok := errors.AsType[*scheduler.NoAgentError](err); ok {
+			return service.NewPostServiceServiceIDMigrateBadRequest().WithPayload(&models.Error{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("Service cannot be migrated: %s", noAgent.Reason),
+			})
+		}
 		if errors.Is(err, pgx.ErrNoRows) {
 			return service.NewPostServiceServiceIDMigrateNotFound().WithPayload(&models.Error{
 				Code:    http.StatusNotFound,
@@ -1024,3 +1312,60 @@ func (c *Controller) PostServiceServiceIDMigrateHandler(params service.PostServi
 
 	return service.NewPostServiceServiceIDMigrateOK().WithPayload(&serviceResponse)
 }
+
+// moveServiceEndpoints sets all AVAILABLE endpoints of a service that changes
+// its host to PENDING_UPDATE, so that the new host picks them up.
+func moveServiceEndpoints(ctx context.Context, tx pgx.Tx, serviceID strfmt.UUID) error {
+	sql, args := db.Update("endpoint").
+		Set("status", models.EndpointStatusPENDINGUPDATE).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where("service_id = ?", serviceID).
+		Where("status = ?", models.EndpointStatusAVAILABLE).
+		MustSql()
+
+	_, err := tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// placeWithHints returns the host a service should run on given its (updated)
+// scheduler hints: the current host if it still satisfies them, otherwise the
+// least-loaded qualifying agent.
+func (c *Controller) placeWithHints(ctx context.Context, tx pgx.Tx, provider string, az *string, currentHost string,
+	hints scheduler.Hints) (string, error) {
+	sched := c.scheduler()
+	if hints.PinnedHost != nil && *hints.PinnedHost != currentHost {
+		return sched.FindLeastLoadedAgent(ctx, tx, provider, az, "", hints)
+	}
+
+	capable, err := sched.HasCapabilities(ctx, tx, currentHost, hints.Capabilities)
+	if err != nil {
+		return "", err
+	}
+	if capable {
+		conflict, err := sched.HasAntiAffinityConflict(ctx, tx, currentHost, hints)
+		if err != nil || !conflict {
+			return currentHost, err
+		}
+	}
+	if hints.PinnedHost != nil {
+		// pinned to the current host, which cannot take the service as updated
+		return sched.FindLeastLoadedAgent(ctx, tx, provider, az, "", hints)
+	}
+	return sched.FindLeastLoadedAgent(ctx, tx, provider, az, currentHost, hints)
+}
+
+// nilIfEmpty maps an empty scheduler hint to "no hint".
+func nilIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// nilIfZero maps a zero limit to nil, which means no limit.
+func nilIfZero(i *int32) *int32 {
+	if i == nil || *i == 0 {
+		return nil
+	}
+	return i
+}
@@ -1219,6 +1219,29 @@ func (t *SuiteTest) TestServiceMigrateAutoSelectsHost() {
 	assert.NotEqual(t.T(), "test-host", *payload.Host)
 }
 
+func (t *SuiteTest) TestServicePutPinnedHostRecordsMigration() {
+	serviceId := t.createService(testService)
+	t.addAgentWithHost("pinned-target-host", nil)
+
+	pinnedHost := "pinned-target-host"
+	res := t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{},
+			ServiceID: serviceId, Body: &models.ServiceUpdatable{PinnedHost: &pinnedHost}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), pinnedHost, *res.(*service.PutServiceServiceIDOK).Payload.Host)
+
+	// the move counts against the hourly migration budget
+	var toHost, reason string
+	sql, args := db.Select("to_host", "reason").
+		From("service_migration").
+		Where("service_id = ?", serviceId).
+		MustSql()
+	assert.NoError(t.T(), t.c.pool.QueryRow(context.Background(), sql, args...).Scan(&toHost, &reason))
+	assert.Equal(t.T(), pinnedHost, toHost)
+	assert.Equal(t.T(), "hints", reason)
+}
+
 func (t *SuiteTest) TestServiceMigrateServiceNotFound() {
 	unknown := strfmt.UUID("00000000-1111-2222-3333-444444444444")
 	res := t.c.PostServiceServiceIDMigrateHandler(
@@ -1289,9 +1312,11 @@ func (t *SuiteTest) TestMaskCPServiceIPAddresses() {
 	svc := &models.Service{
 		Provider:    &cpProvider,
 		IPAddresses: []models.InetAddress{"1.2.3.4"},
+		Members:     []*models.ServiceMember{{IPAddress: new(models.InetAddress("1.2.3.4")), Weight: 2}},
 	}
 	maskCPServiceIPAddresses(svc, nil)
 	assert.Nil(t.T(), svc.IPAddresses)
+	assert.Nil(t.T(), svc.Members)
 
 	// Test with non-CP service - IPs should not be masked
 	tenantProvider := "tenant"
@@ -1589,3 +1614,208 @@ func (t *SuiteTest) TestServicePostSnatPoolSizeCPDefault() {
 		assert.Equal(t.T(), int32(1), *payload.SnatPoolSize)
 	}
 }
+
+func (t *SuiteTest) TestServicePutLimits() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	serviceID := t.createService(testService)
+	ep := t.createEndpoint(serviceID, models.EndpointTarget{Network: &network})
+	_, err := t.c.pool.Exec(context.Background(),
+		"UPDATE endpoint SET status = $1 WHERE id = $2", models.EndpointStatusAVAILABLE, ep.ID)
+	assert.NoError(t.T(), err)
+
+	res := t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{ConnectionLimit: conv.Pointer(int32(500))}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), int32(500), *res.(*service.PutServiceServiceIDOK).Payload.ConnectionLimit)
+	assert.Nil(t.T(), res.(*service.PutServiceServiceIDOK).Payload.RateLimit)
+
+	// endpoints inheriting the limit are re-processed
+	res = t.c.GetEndpointEndpointIDHandler(
+		endpoint.GetEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: ep.ID},
+		nil)
+	assert.IsType(t.T(), &endpoint.GetEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), models.EndpointStatusPENDINGUPDATE, res.(*endpoint.GetEndpointEndpointIDOK).Payload.Status)
+
+	// 0 removes the limit
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{ConnectionLimit: conv.Pointer(int32(0))}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Nil(t.T(), res.(*service.PutServiceServiceIDOK).Payload.ConnectionLimit)
+}
+
+func (t *SuiteTest) TestServicePutMembers() {
+	serviceID := t.createService(testService)
+
+	// options must apply to an IP address of the service
+	res := t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Members: []*models.ServiceMember{
+				{IPAddress: new(models.InetAddress("1.2.3.5")), Weight: 2},
+			}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDBadRequest{}, res)
+
+	members := []*models.ServiceMember{{IPAddress: new(models.InetAddress("1.2.3.4")), Weight: 2, PriorityGroup: 1}}
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Members: members}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), members, res.(*service.PutServiceServiceIDOK).Payload.Members)
+
+	// empty list removes the options
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Members: []*models.ServiceMember{}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Empty(t.T(), res.(*service.PutServiceServiceIDOK).Payload.Members)
+}
+
+func (t *SuiteTest) TestServicePutTraffic() {
+	network := strfmt.UUID("d714f65e-bffd-494f-8219-8eb0a85d7a2d")
+	serviceID := t.createService(testService)
+	ep := t.createEndpoint(serviceID, models.EndpointTarget{Network: &network})
+	_, err := t.c.pool.Exec(context.Background(),
+		"UPDATE endpoint SET status = $1 WHERE id = $2", models.EndpointStatusAVAILABLE, ep.ID)
+	assert.NoError(t.T(), err)
+
+	// timeouts beyond idling are only supported by provider=cp
+	res := t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Traffic: &models.ServiceTraffic{TunnelTimeout: conv.Pointer(int32(86400))}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDBadRequest{}, res)
+
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Traffic: &models.ServiceTraffic{
+				MaxConnections: conv.Pointer(int32(100)),
+				IdleTimeout:    conv.Pointer(int32(300)),
+			}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), &models.ServiceTraffic{MaxConnections: conv.Pointer(int32(100)), IdleTimeout: conv.Pointer(int32(300))},
+		res.(*service.PutServiceServiceIDOK).Payload.Traffic)
+
+	// endpoints are re-processed
+	res = t.c.GetEndpointEndpointIDHandler(
+		endpoint.GetEndpointEndpointIDParams{HTTPRequest: &http.Request{}, EndpointID: ep.ID},
+		nil)
+	assert.IsType(t.T(), &endpoint.GetEndpointEndpointIDOK{}, res)
+	assert.Equal(t.T(), models.EndpointStatusPENDINGUPDATE, res.(*endpoint.GetEndpointEndpointIDOK).Payload.Status)
+
+	// omitted settings are kept, 0 resets a setting
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Traffic: &models.ServiceTraffic{MaxConnections: conv.Pointer(int32(0))}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(300))},
+		res.(*service.PutServiceServiceIDOK).Payload.Traffic)
+
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Traffic: &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(0))}}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Nil(t.T(), res.(*service.PutServiceServiceIDOK).Payload.Traffic)
+}
+
+func (t *SuiteTest) TestServicePostTrafficCP() {
+	t.addCPAgent("cp-traffic-host", nil)
+
+	cpProvider := "cp"
+	svc := models.Service{
+		Name:        "cp-traffic",
+		Provider:    &cpProvider,
+		Protocol:    conv.Pointer(models.ServiceProtocolTCP),
+		IPAddresses: []models.InetAddress{"192.168.1.103"},
+		Ports:       []int32{5432},
+		ProjectID:   testProject1,
+		Traffic: &models.ServiceTraffic{
+			HTTPKeepAlive: conv.Pointer(models.ServiceTrafficHTTPKeepAliveBoth),
+		},
+	}
+
+	// HTTP keep-alive requires protocol HTTP
+	res := t.c.PostServiceHandler(service.PostServiceParams{HTTPRequest: &headerProject1, Body: &svc},
+		&gopherpolicy.Token{Enforcer: &TestEnforcerAllowProvider{}})
+	assert.IsType(t.T(), &service.PostServiceBadRequest{}, res)
+
+	svc.Traffic = &models.ServiceTraffic{
+		ConnectTimeout: conv.Pointer(int32(5)),
+		TunnelTimeout:  conv.Pointer(int32(86400)),
+		MaxConnections: conv.Pointer(int32(0)),
+	}
+	res = t.c.PostServiceHandler(service.PostServiceParams{HTTPRequest: &headerProject1, Body: &svc},
+		&gopherpolicy.Token{Enforcer: &TestEnforcerAllowProvider{}})
+	assert.IsType(t.T(), &service.PostServiceCreated{}, res)
+	assert.Equal(t.T(), &models.ServiceTraffic{ConnectTimeout: conv.Pointer(int32(5)), TunnelTimeout: conv.Pointer(int32(86400))},
+		res.(*service.PostServiceCreated).Payload.Traffic)
+}
+
+func (t *SuiteTest) TestServicePutProtocolTrafficCP() {
+	t.addCPAgent("cp-protocol-host", nil)
+
+	cpProvider := "cp"
+	svc := models.Service{
+		Name:        "cp-protocol",
+		Provider:    &cpProvider,
+		Protocol:    conv.Pointer(models.ServiceProtocolHTTP),
+		IPAddresses: []models.InetAddress{"192.168.1.104"},
+		Ports:       []int32{80},
+		ProjectID:   testProject1,
+		Traffic: &models.ServiceTraffic{
+			HTTPKeepAlive: conv.Pointer(models.ServiceTrafficHTTPKeepAliveBoth),
+		},
+	}
+	res := t.c.PostServiceHandler(service.PostServiceParams{HTTPRequest: &headerProject1, Body: &svc},
+		&gopherpolicy.Token{Enforcer: &TestEnforcerAllowProvider{}})
+	assert.IsType(t.T(), &service.PostServiceCreated{}, res)
+	serviceID := res.(*service.PostServiceCreated).Payload.ID
+
+	// the stored HTTP keep-alive is validated against the new protocol
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{Protocol: conv.Pointer(models.ServiceProtocolTCP)}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDBadRequest{}, res)
+
+	// resetting it to the default allows the change
+	res = t.c.PutServiceServiceIDHandler(
+		service.PutServiceServiceIDParams{HTTPRequest: &http.Request{}, ServiceID: serviceID,
+			Body: &models.ServiceUpdatable{
+				Protocol: conv.Pointer(models.ServiceProtocolTCP),
+				Traffic:  &models.ServiceTraffic{HTTPKeepAlive: conv.Pointer(models.ServiceTrafficHTTPKeepAliveClient)},
+			}},
+		nil)
+	assert.IsType(t.T(), &service.PutServiceServiceIDOK{}, res)
+	assert.Equal(t.T(), models.ServiceProtocolTCP, *res.(*service.PutServiceServiceIDOK).Payload.Protocol)
+	assert.Nil(t.T(), res.(*service.PutServiceServiceIDOK).Payload.Traffic)
+}
+
+func TestMergeTraffic(t *testing.T) {
+	current := &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(300)), MaxConnections: conv.Pointer(int32(10))}
+
+	assert.Equal(t, current, mergeTraffic(current, &models.ServiceTraffic{}))
+	assert.Equal(t, &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(60)), MaxConnections: conv.Pointer(int32(10))},
+		mergeTraffic(current, &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(60))}))
+	assert.Equal(t, &models.ServiceTraffic{MaxConnections: conv.Pointer(int32(10))},
+		mergeTraffic(current, &models.ServiceTraffic{IdleTimeout: conv.Pointer(int32(0))}))
+	assert.Nil(t, mergeTraffic(current, &models.ServiceTraffic{
+		IdleTimeout: conv.Pointer(int32(0)), MaxConnections: conv.Pointer(int32(0)),
+	}))
+	assert.Nil(t, mergeTraffic(nil, nil))
+
+	// client is the default HTTP keep-alive
+	keepAlive := &models.ServiceTraffic{HTTPKeepAlive: conv.Pointer(models.ServiceTrafficHTTPKeepAliveBoth)}
+	assert.Equal(t, keepAlive, mergeTraffic(keepAlive, nil))
+	assert.Nil(t, mergeTraffic(keepAlive, &models.ServiceTraffic{
+		HTTPKeepAlive: conv.Pointer(models.ServiceTrafficHTTPKeepAliveClient),
+	}))
+}
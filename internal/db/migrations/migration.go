@@ -360,4 +360,139 @@ var Migrations = mgx.Migrations(
 		`)
 		return err
 	}),
+	// Log of scheduler-initiated service migrations, used for the hourly migration budget
+	mgx.NewMigration("add_service_migration", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			CREATE TABLE service_migration
+			(
+				id          UUID         DEFAULT gen_random_uuid() PRIMARY KEY,
+				service_id  UUID         NOT NULL,
+				from_host   VARCHAR(64)  NOT NULL,
+				to_host     VARCHAR(64)  NOT NULL,
+				reason      VARCHAR(16)  NOT NULL
+					CONSTRAINT reason CHECK (reason IN ('reschedule', 'rebalance')),
+				created_at  TIMESTAMP    NOT NULL DEFAULT now(),
+				CONSTRAINT fk_service FOREIGN KEY(service_id) REFERENCES service(id) ON DELETE CASCADE
+			);
+			CREATE INDEX idx_service_migration_created_at ON service_migration (created_at);
+		`)
+		return err
+	}),
+	// Per-service scheduler hints: pinning to a host and project-scoped anti-affinity
+	mgx.NewMigration("add_service_scheduler_hints", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE service ADD COLUMN pinned_host VARCHAR(64) NULL;
+			ALTER TABLE service ADD COLUMN anti_affinity_group VARCHAR(64) NULL;
+			CREATE INDEX idx_service_anti_affinity_group ON service (project_id, anti_affinity_group)
+				WHERE anti_affinity_group IS NOT NULL;
+		`)
+		return err
+	}),
+	// Agent self-reported status: version, device, failover state and capabilities
+	mgx.NewMigration("add_agents_status", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE agents ADD COLUMN version VARCHAR(64) NULL;
+			ALTER TABLE agents ADD COLUMN device_type VARCHAR(64) NULL;
+			ALTER TABLE agents ADD COLUMN failover_state VARCHAR(64) NULL;
+			ALTER TABLE agents ADD COLUMN capabilities VARCHAR(64)[] NOT NULL DEFAULT '{}';
+		`)
+		return err
+	}),
+	// Agents connected to several physical networks
+	mgx.NewMigration("add_agents_physnets", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE agents ADD COLUMN physnets VARCHAR(64)[] NOT NULL DEFAULT '{}';
+			ALTER TABLE agents ADD COLUMN bridge_mappings JSONB NOT NULL DEFAULT '{}';
+			UPDATE agents SET physnets = ARRAY[physnet] WHERE physnet IS NOT NULL;
+			ALTER TABLE endpoint_port ADD COLUMN physnet VARCHAR(64) NULL;
+		`)
+		return err
+	}),
+	// Structured agent health reported with every heartbeat
+	mgx.NewMigration("add_agents_health", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE agents ADD COLUMN health JSONB NULL;
+		`)
+		return err
+	}),
+	// Per-endpoint connection and rate limits, endpoints override the service default
+	mgx.NewMigration("add_connection_rate_limits", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE service ADD COLUMN connection_limit INTEGER NULL CHECK (connection_limit > 0);
+			ALTER TABLE service ADD COLUMN rate_limit INTEGER NULL CHECK (rate_limit > 0);
+			ALTER TABLE endpoint ADD COLUMN connection_limit INTEGER NULL CHECK (connection_limit > 0);
+			ALTER TABLE endpoint ADD COLUMN rate_limit INTEGER NULL CHECK (rate_limit > 0);
+		`)
+		return err
+	}),
+	// Admin-managed catalog of iRules, attached to services by name
+	mgx.NewMigration("add_irules", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			CREATE TABLE irule
+			(
+				id          UUID          DEFAULT gen_random_uuid() PRIMARY KEY,
+				name        VARCHAR(64)   NOT NULL UNIQUE,
+				description VARCHAR(255)  NOT NULL DEFAULT '',
+				content     TEXT          NOT NULL,
+				created_at  TIMESTAMP     NOT NULL DEFAULT now(),
+				updated_at  TIMESTAMP     NOT NULL DEFAULT now()
+			);
+			ALTER TABLE service ADD COLUMN irules VARCHAR(64)[] NOT NULL DEFAULT '{}';
+		`)
+		return err
+	}),
+	// Source networks allowed to connect to an endpoint, empty allows all
+	mgx.NewMigration("add_endpoint_allowed_cidrs", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE endpoint ADD COLUMN allowed_cidrs CIDR[] NOT NULL DEFAULT '{}';
+		`)
+		return err
+	}),
+	// Subset of the service ports exposed by an endpoint, empty exposes all
+	mgx.NewMigration("add_endpoint_ports", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE endpoint ADD COLUMN ports INTEGER[] NOT NULL DEFAULT '{}';
+		`)
+		return err
+	}),
+	// Listen ports of an endpoint differing from the service port
+	mgx.NewMigration("add_endpoint_port_mappings", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE endpoint ADD COLUMN port_mappings JSONB NOT NULL DEFAULT '[]';
+		`)
+		return err
+	}),
+	// Load balancing options of the service IP addresses
+	mgx.NewMigration("add_service_members", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE service ADD COLUMN members JSONB NOT NULL DEFAULT '[]';
+		`)
+		return err
+	}),
+	// Traffic settings of the connections of every endpoint to the service, NULL uses the defaults
+	mgx.NewMigration("add_service_traffic", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE service ADD COLUMN traffic JSONB NULL;
+		`)
+		return err
+	}),
+	// Moves of services required by updated scheduler hints count against the migration budget
+	mgx.NewMigration("add_service_migration_reason_hints", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE service_migration DROP CONSTRAINT reason;
+			ALTER TABLE service_migration ADD CONSTRAINT reason
+				CHECK (reason IN ('reschedule', 'rebalance', 'hints'));
+		`)
+		return err
+	}),
+	// Endpoint limits of 0 lift the limit of the service, NULL inherits it
+	mgx.NewMigration("allow_unlimited_endpoint_limits", func(ctx context.Context, commands mgx.Commands) error {
+		_, err := commands.Exec(ctx, `
+			ALTER TABLE endpoint DROP CONSTRAINT endpoint_connection_limit_check;
+			ALTER TABLE endpoint ADD CONSTRAINT endpoint_connection_limit_check CHECK (connection_limit >= 0);
+			ALTER TABLE endpoint DROP CONSTRAINT endpoint_rate_limit_check;
+			ALTER TABLE endpoint ADD CONSTRAINT endpoint_rate_limit_check CHECK (rate_limit >= 0);
+		`)
+		return err
+	}),
 )
@@ -26,4 +26,10 @@ var (
 	ErrSnatPoolSizeUnsupportedProvider = errors.New("snat_pool_size is only supported for provider=f5")
 	ErrSnatIPConflict                  = errors.New("SNAT IP conflict")
 	ErrInvalidPorts                    = errors.New("invalid ports")
+	ErrIRulesUnsupportedProvider       = errors.New("irules are only supported for provider=f5")
+	ErrUnknownIRule                    = errors.New("unknown iRule")
+	ErrIRuleInUse                      = errors.New("iRule in use")
+	ErrInvalidMembers                  = errors.New("invalid members")
+	ErrInvalidTraffic                  = errors.New("invalid traffic settings")
+	ErrPortsInUse                      = errors.New("ports in use by endpoints")
 )
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2026 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"slices"
+
+	"github.com/sapcc/archer/v2/models"
+)
+
+// Listener is a port an endpoint listens on, forwarding to a port of the service.
+type Listener struct {
+	Port        int32
+	ServicePort int32
+}
+
+// Listeners returns the listeners of an endpoint: the service ports it exposes,
+// all unless it selects a subset, on the listen port they are mapped to. A mapped
+// listener takes precedence over a service port listened on as is.
+func Listeners(servicePorts, endpointPorts []int32, mappings []*models.PortMapping) []Listener {
+	mapped := make(map[int32]int32, len(mappings))
+	for _, mapping := range mappings {
+		mapped[*mapping.ServicePort] = *mapping.ListenPort
+	}
+
+	listeners := make([]Listener, 0, len(servicePorts))
+	mappedPorts := make(map[int32]bool, len(mappings))
+	for _, servicePort := range servicePorts {
+		if len(endpointPorts) > 0 && !slices.Contains(endpointPorts, servicePort) {
+			continue
+		}
+		port, ok := mapped[servicePort]
+		if ok {
+			mappedPorts[port] = true
+		} else {
+			port = servicePort
+		}
+		listeners = append(listeners, Listener{Port: port, ServicePort: servicePort})
+	}
+
+	return slices.DeleteFunc(listeners, func(l Listener) bool {
+		_, isMapped := mapped[l.ServicePort]
+		return !isMapped && mappedPorts[l.Port]
+	})
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2026 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sapcc/archer/v2/models"
+)
+
+func mapping(listenPort, servicePort int32) *models.PortMapping {
+	return &models.PortMapping{ListenPort: &listenPort, ServicePort: &servicePort}
+}
+
+func TestListeners(t *testing.T) {
+	tests := []struct {
+		name          string
+		servicePorts  []int32
+		endpointPorts []int32
+		mappings      []*models.PortMapping
+		expected      []Listener
+	}{
+		{"all service ports", []int32{80, 443}, nil, nil,
+			[]Listener{{80, 80}, {443, 443}}},
+		{"port subset", []int32{80, 443}, []int32{443}, nil,
+			[]Listener{{443, 443}}},
+		{"mapped port", []int32{80, 443}, nil, []*models.PortMapping{mapping(8443, 443)},
+			[]Listener{{80, 80}, {8443, 443}}},
+		{"mapping of a port not exposed", []int32{80, 443}, []int32{80}, []*models.PortMapping{mapping(8443, 443)},
+			[]Listener{{80, 80}}},
+		{"mapped listener wins", []int32{80, 443, 8443}, nil, []*models.PortMapping{mapping(8443, 443)},
+			[]Listener{{80, 80}, {8443, 443}}},
+		{"swapped ports", []int32{80, 443}, nil, []*models.PortMapping{mapping(443, 80), mapping(80, 443)},
+			[]Listener{{443, 80}, {80, 443}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Listeners(tt.servicePorts, tt.endpointPorts, tt.mappings))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2026 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"net/netip"
+
+	"github.com/sapcc/archer/v2/models"
+)
+
+// Members returns the members of a service: one per IP address, with the load
+// balancing options given for the address. Weights default to 1.
+func Members(ipAddresses []models.InetAddress, options []*models.ServiceMember) []*models.ServiceMember {
+	members := make([]*models.ServiceMember, 0, len(ipAddresses))
+	for _, ipAddress := range ipAddresses {
+		member := &models.ServiceMember{IPAddress: &ipAddress, Weight: 1}
+		for _, option := range options {
+			if SameAddress(*option.IPAddress, ipAddress) {
+				member.PriorityGroup = option.PriorityGroup
+				member.Backup = option.Backup
+				if option.Weight > 0 {
+					member.Weight = option.Weight
+				}
+				break
+			}
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// SameAddress reports whether both IP addresses are equal, regardless of their notation.
+func SameAddress(a, b models.InetAddress) bool {
+	addrA, errA := netip.ParseAddr(string(a))
+	addrB, errB := netip.ParseAddr(string(b))
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return addrA == addrB
+}
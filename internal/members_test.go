@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2026 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sapcc/archer/v2/models"
+)
+
+func TestMembers(t *testing.T) {
+	ipAddresses := []models.InetAddress{"10.0.0.1", "2001:db8::1", "10.0.0.3"}
+	members := Members(ipAddresses, []*models.ServiceMember{
+		{IPAddress: new(models.InetAddress("2001:DB8:0::1")), Weight: 3, PriorityGroup: 2},
+		{IPAddress: new(models.InetAddress("10.0.0.3")), Backup: true},
+		{IPAddress: new(models.InetAddress("10.0.0.4")), Weight: 5},
+	})
+
+	if assert.Len(t, members, 3) {
+		assert.Equal(t, models.InetAddress("10.0.0.1"), *members[0].IPAddress)
+		assert.Equal(t, int32(1), members[0].Weight)
+		assert.Zero(t, members[0].PriorityGroup)
+		assert.False(t, members[0].Backup)
+
+		assert.Equal(t, models.InetAddress("2001:db8::1"), *members[1].IPAddress)
+		assert.Equal(t, int32(3), members[1].Weight)
+		assert.Equal(t, int32(2), members[1].PriorityGroup)
+
+		assert.Equal(t, int32(1), members[2].Weight)
+		assert.True(t, members[2].Backup)
+	}
+}
+
+func TestSameAddress(t *testing.T) {
+	assert.True(t, SameAddress("10.0.0.1", "10.0.0.1"))
+	assert.True(t, SameAddress("2001:db8::1", "2001:DB8:0:0::1"))
+	assert.False(t, SameAddress("10.0.0.1", "10.0.0.2"))
+	assert.False(t, SameAddress("invalid", "10.0.0.1"))
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -67,28 +68,40 @@ func ConnectToNeutron(providerClient *gophercloud.ProviderClient) (*NeutronClien
 	return &NeutronClient{ServiceClient: serviceClient}, nil
 }
 
-// GetNetworkSegment return the segmentation ID for the given network
-// throws ErrNoPhysNetFound if the physical network is not found
-func (n *NeutronClient) GetNetworkSegment(ctx context.Context, networkID, physnet string) (int, error) {
+// GetNetworkSegment return the segmentation ID for the given network on the
+// first of the physical networks it has a segment on
+// throws ErrNoPhysNetFound if none of the physical networks is found
+func (n *NeutronClient) GetNetworkSegment(ctx context.Context, networkID string, physnets ...string) (int, error) {
+	segmentID, _, err := n.FindNetworkSegment(ctx, networkID, physnets)
+	return segmentID, err
+}
+
+// FindNetworkSegment tries each physical network in order and returns the
+// segmentation ID of the first one the network has a segment on, together
+// with the matching physical network.
+// throws ErrNoPhysNetFound if none of the physical networks is found
+func (n *NeutronClient) FindNetworkSegment(ctx context.Context, networkID string, physnets []string) (int, string, error) {
 	network, err := n.GetNetwork(ctx, networkID)
 	if err != nil {
 		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
-			return 0, fmt.Errorf("%w, network not found, %s", aErrors.ErrNoPhysNetFound, err.Error())
+			return 0, "", fmt.Errorf("%w, network not found, %s", aErrors.ErrNoPhysNetFound, err.Error())
 		}
-		return 0, err
+		return 0, "", err
 	}
 
-	for _, segment := range network.Segments {
-		if segment.PhysicalNetwork == physnet {
-			return segment.SegmentationID, nil
+	for _, physnet := range physnets {
+		for _, segment := range network.Segments {
+			if segment.PhysicalNetwork == physnet {
+				return segment.SegmentationID, physnet, nil
+			}
 		}
 	}
 
-	return 0, fmt.Errorf("%w, physnet '%s' not found for network '%s'",
-		aErrors.ErrNoPhysNetFound, physnet, networkID)
+	return 0, "", fmt.Errorf("%w, physnet '%s' not found for network '%s'",
+		aErrors.ErrNoPhysNetFound, strings.Join(physnets, "', '"), networkID)
 }
 
-func (n *NeutronClient) GetSubnetSegment(ctx context.Context, subnetID, physnet string) (int, error) {
+func (n *NeutronClient) GetSubnetSegment(ctx context.Context, subnetID string, physnets ...string) (int, error) {
 	subnet, err := n.GetSubnet(ctx, subnetID)
 	if err != nil {
 		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
@@ -97,7 +110,7 @@ func (n *NeutronClient) GetSubnetSegment(ctx context.Context, subnetID, physnet
 		return 0, err
 	}
 
-	return n.GetNetworkSegment(ctx, subnet.NetworkID, physnet)
+	return n.GetNetworkSegment(ctx, subnet.NetworkID, physnets...)
 }
 
 // GetNetworkMTU returns the MTU of the network
@@ -53,6 +53,26 @@ func TestNeutronClient_GetNetworkSegment(t *testing.T) {
 	assert.Equal(t, 100, segID)
 }
 
+func TestNeutronClient_FindNetworkSegment(t *testing.T) {
+	fakeServer := th.SetupPersistentPortHTTP(t, 8931)
+	defer fakeServer.Teardown()
+	fixture.SetupHandler(t, fakeServer, "/v2.0/networks/"+NetworkIDFixture, "GET",
+		"", GetNetworkResponseFixture, http.StatusOK)
+
+	n := &NeutronClient{
+		ServiceClient: fake.ServiceClient(fakeServer),
+	}
+	n.InitCache()
+	segID, physnet, err := n.FindNetworkSegment(t.Context(), NetworkIDFixture, []string{"physnet2", "physnet1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 100, segID)
+	assert.Equal(t, "physnet1", physnet)
+
+	_, _, err = n.FindNetworkSegment(t.Context(), NetworkIDFixture, []string{"physnet2", "physnet3"})
+	assert.True(t, errors.Is(err, aErrors.ErrNoPhysNetFound))
+	assert.ErrorContains(t, err, "physnet 'physnet2', 'physnet3' not found for network")
+}
+
 func TestNeutronClient_GetNetworkSegment404(t *testing.T) {
 	fakeServer := th.SetupPersistentPortHTTP(t, 8931)
 	defer fakeServer.Teardown()
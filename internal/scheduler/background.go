@@ -101,6 +101,10 @@ func (b *BackgroundScheduler) runCycle(ctx context.Context) {
 		// 3. Rebalance if needed (only for agents that have been recovered for rebalanceDelay)
 		b.checkRebalance(ctx, provider)
 	}
+
+	if err := b.scheduler.PruneMigrationLog(ctx); err != nil {
+		log.WithError(err).Error("Failed to prune service migration log")
+	}
 }
 
 func (b *BackgroundScheduler) checkRecoveredAgents(ctx context.Context, provider string) {
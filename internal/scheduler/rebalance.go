@@ -6,14 +6,20 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/go-openapi/strfmt"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/internal/db"
 )
 
+// migrationLogRetention is how long service_migration rows are kept.
+const migrationLogRetention = 7 * 24 * time.Hour
+
 // AgentLoad represents an agent and its current service count.
 type AgentLoad struct {
 	Host         string
@@ -64,7 +70,30 @@ func (s *ServiceScheduler) ShouldRebalance(ctx context.Context, provider string,
 }
 
 // RebalanceServices redistributes services across agents when imbalance exceeds threshold.
+// Migrations only happen inside a configured maintenance window and are capped
+// by the remaining hourly migration budget.
 func (s *ServiceScheduler) RebalanceServices(ctx context.Context, provider string, az *string) error {
+	if !s.inMaintenanceWindow(s.now()) {
+		log.WithFields(log.Fields{
+			"provider": provider,
+			"az":       az,
+		}).Debug("Outside of maintenance windows, skipping rebalance")
+		return nil
+	}
+
+	maxMigrations, err := s.remainingMigrationBudget(ctx)
+	if err != nil {
+		return err
+	}
+	if maxMigrations <= 0 {
+		log.WithFields(log.Fields{
+			"provider":            provider,
+			"az":                  az,
+			"migrations_per_hour": s.config.MigrationsPerHour,
+		}).Info("Hourly migration budget exhausted, skipping rebalance")
+		return nil
+	}
+
 	shouldRebalance, err := s.ShouldRebalance(ctx, provider, az)
 	if err != nil {
 		return err
@@ -107,12 +136,12 @@ func (s *ServiceScheduler) RebalanceServices(ctx context.Context, provider strin
 
 	migrations := 0
 	for _, over := range overloaded {
-		if migrations >= s.config.RebalanceMaxMigrations {
+		if migrations >= maxMigrations {
 			break
 		}
 
 		excess := over.ServiceCount - target
-		for i := 0; i < excess && migrations < s.config.RebalanceMaxMigrations; i++ {
+		for i := 0; i < excess && migrations < maxMigrations; i++ {
 			if len(underloaded) == 0 {
 				break
 			}
@@ -125,14 +154,14 @@ func (s *ServiceScheduler) RebalanceServices(ctx context.Context, provider strin
 				}
 			}
 
-			// Find a service to migrate
-			serviceID, err := s.getRandomServiceFromHost(ctx, over.Host, provider)
+			// Find a service that may move to the chosen agent
+			serviceID, err := s.getMigratableServiceFromHost(ctx, over.Host, underloaded[underIdx].Host, provider)
 			if err != nil {
 				log.WithError(err).WithField("host", over.Host).Warning("Failed to get service for migration")
 				break
 			}
 
-			if err := s.MigrateService(ctx, serviceID, over.Host, underloaded[underIdx].Host); err != nil {
+			if err := s.MigrateService(ctx, serviceID, over.Host, underloaded[underIdx].Host, MigrationReasonRebalance); err != nil {
 				log.WithError(err).Warning("Failed to migrate service during rebalance")
 				continue
 			}
@@ -157,6 +186,36 @@ func (s *ServiceScheduler) RebalanceServices(ctx context.Context, provider strin
 	return nil
 }
 
+// remainingMigrationBudget returns how many services a rebalance run may still
+// migrate, taking all migrations recorded within the last hour into account.
+func (s *ServiceScheduler) remainingMigrationBudget(ctx context.Context) (int, error) {
+	if s.config.MigrationsPerHour <= 0 {
+		return s.config.RebalanceMaxMigrations, nil
+	}
+
+	sql, args := db.Select("COUNT(*)").
+		From("service_migration").
+		Where("created_at > NOW() - INTERVAL '1 hour'").
+		MustSql()
+
+	var recent int
+	if err := s.pool.QueryRow(ctx, sql, args...).Scan(&recent); err != nil {
+		return 0, err
+	}
+
+	return min(s.config.MigrationsPerHour-recent, s.config.RebalanceMaxMigrations), nil
+}
+
+// PruneMigrationLog removes migration records older than the retention period.
+func (s *ServiceScheduler) PruneMigrationLog(ctx context.Context) error {
+	sql, args := db.Delete("service_migration").
+		Where("created_at < NOW() - INTERVAL '1 second' * ?", int(migrationLogRetention.Seconds())).
+		MustSql()
+
+	_, err := s.pool.Exec(ctx, sql, args...)
+	return err
+}
+
 func (s *ServiceScheduler) getAgentLoads(ctx context.Context, provider string, az *string) ([]AgentLoad, error) {
 	sql, args := db.Select("agents.host", "COUNT(service.id) AS service_count").
 		From("agents").
@@ -177,12 +236,37 @@ func (s *ServiceScheduler) getAgentLoads(ctx context.Context, provider string, a
 	return agents, nil
 }
 
-func (s *ServiceScheduler) getRandomServiceFromHost(ctx context.Context, host, provider string) (strfmt.UUID, error) {
+// getMigratableServiceFromHost picks an AVAILABLE service on host that is
+// neither pinned nor conflicting with an anti-affinity group on targetHost, and
+// whose required capabilities targetHost reports.
+func (s *ServiceScheduler) getMigratableServiceFromHost(ctx context.Context, host, targetHost, provider string) (strfmt.UUID, error) {
 	sql, args := db.Select("id").
 		From("service").
 		Where("host = ?", host).
 		Where("provider = ?", provider).
 		Where("status = 'AVAILABLE'").
+		Where("pinned_host IS NULL").
+		Where(sq.Or{
+			sq.Expr("anti_affinity_group IS NULL"),
+			db.Select("1").
+				Prefix("NOT EXISTS(").
+				From("service aa").
+				Where("aa.host = ?", targetHost).
+				Where("aa.anti_affinity_group = service.anti_affinity_group").
+				Where("aa.project_id = service.project_id").
+				Suffix(")"),
+		}).
+		// skip services requiring a capability the target lacks, see RequiredCapabilities
+		Where(sq.Or{
+			sq.Expr("NOT 0 = ANY(ports)"),
+			sq.Expr("EXISTS(SELECT 1 FROM agents ta WHERE ta.host = ? AND (? = ANY(ta.capabilities) OR cardinality(ta.capabilities) = 0))",
+				targetHost, internal.CapabilityWildcardPort),
+		}).
+		Where(sq.Or{
+			sq.Expr("snat_pool_size <= 1"),
+			sq.Expr("EXISTS(SELECT 1 FROM agents ta WHERE ta.host = ? AND (? = ANY(ta.capabilities) OR cardinality(ta.capabilities) = 0))",
+				targetHost, internal.CapabilitySnatPool),
+		}).
 		Limit(1).
 		MustSql()
 
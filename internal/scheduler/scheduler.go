@@ -6,6 +6,9 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -14,6 +17,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/sapcc/archer/v2/internal"
 	"github.com/sapcc/archer/v2/internal/db"
 	"github.com/sapcc/archer/v2/models"
 )
@@ -25,13 +29,30 @@ type Config struct {
 	RebalanceDelay         time.Duration
 	RebalanceThreshold     float64
 	RebalanceMaxMigrations int
+	// MaintenanceWindows restricts rebalance migrations to these windows.
+	// Stale-agent rescheduling is never restricted. Empty means always.
+	MaintenanceWindows []MaintenanceWindow
+	// MigrationsPerHour caps the number of migrations (of any reason) after
+	// which rebalancing stops for the rest of the hour. Zero means unlimited.
+	MigrationsPerHour int
 }
 
+// MigrationReason records why a service was moved to another agent.
+type MigrationReason string
+
+const (
+	MigrationReasonReschedule MigrationReason = "reschedule"
+	MigrationReasonRebalance  MigrationReason = "rebalance"
+	// MigrationReasonHints is a move required by updated scheduler hints or capabilities of a service.
+	MigrationReasonHints MigrationReason = "hints"
+)
+
 // ServiceScheduler handles service scheduling, rescheduling, and rebalancing.
 type ServiceScheduler struct {
 	pool   db.PgxIface
 	config Config
 	notify func(host string) // callback to notify agents
+	now    func() time.Time
 }
 
 // NewServiceScheduler creates a new service scheduler.
@@ -40,6 +61,7 @@ func NewServiceScheduler(pool db.PgxIface, cfg Config, notifyFunc func(host stri
 		pool:   pool,
 		config: cfg,
 		notify: notifyFunc,
+		now:    time.Now,
 	}
 }
 
@@ -51,9 +73,87 @@ type AgentInfo struct {
 	HeartbeatAt      time.Time
 }
 
-// FindLeastLoadedAgent returns the least-loaded healthy agent for a provider/AZ.
+// Hints are per-service scheduler constraints.
+type Hints struct {
+	// PinnedHost restricts placement to this agent.
+	PinnedHost *string
+	// AntiAffinityGroup excludes agents already hosting another service of
+	// the same project with the same group.
+	AntiAffinityGroup *string
+	ProjectID         string
+	// ServiceID is the service being placed; it never conflicts with itself.
+	ServiceID strfmt.UUID
+	// Capabilities the agent must report, see RequiredCapabilities.
+	Capabilities []string
+}
+
+// HintsFromService returns the scheduler hints stored on a service.
+func HintsFromService(svc *models.Service) Hints {
+	return Hints{
+		PinnedHost:        svc.PinnedHost,
+		AntiAffinityGroup: svc.AntiAffinityGroup,
+		ProjectID:         string(svc.ProjectID),
+		ServiceID:         svc.ID,
+		Capabilities:      internal.RequiredCapabilities(svc.Ports, svc.SnatPoolSize),
+	}
+}
+
+// antiAffinityConflict is an EXISTS expression matching when host already runs
+// another member of the hinted anti-affinity group. host is a value or a column
+// expression. It uses plain placeholders so it can be nested into db builders.
+func (h Hints) antiAffinityConflict(host any) sq.Sqlizer {
+	sub := sq.Select("1").
+		From("service aa").
+		Where(sq.Expr("aa.host = ?", host)).
+		Where("aa.anti_affinity_group = ?", *h.AntiAffinityGroup).
+		Where("aa.project_id = ?", h.ProjectID)
+	if h.ServiceID != "" {
+		sub = sub.Where("aa.id <> ?", h.ServiceID)
+	}
+	return sub.Prefix("EXISTS(").Suffix(")")
+}
+
+// advisoryLockAntiAffinity is the class of the two-key advisory locks serializing the placement
+// of anti-affinity group members; the second key hashes project and group. Two-key locks do not
+// overlap with the single-key ones such as advisoryLockID.
+const advisoryLockAntiAffinity int32 = 8675310
+
+// lockAntiAffinityGroup serializes the placement of the hinted anti-affinity group until tx
+// ends, so concurrent placements see each other's choice of host.
+func (h Hints) lockAntiAffinityGroup(ctx context.Context, tx pgx.Tx) error {
+	if h.AntiAffinityGroup == nil {
+		return nil
+	}
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))",
+		advisoryLockAntiAffinity, h.ProjectID+"/"+*h.AntiAffinityGroup)
+	return err
+}
+
+// NoAgentError is returned when no agent qualifies for placing a service.
+type NoAgentError struct {
+	Reason string
+}
+
+func (e *NoAgentError) Error() string {
+	return "no agent qualifies: " + e.Reason
+}
+
+// Unwrap keeps errors.Is(err, pgx.ErrNoRows) working for callers.
+func (e *NoAgentError) Unwrap() error {
+	return pgx.ErrNoRows
+}
+
+// FindLeastLoadedAgent returns the least-loaded healthy agent for a provider/AZ
+// that satisfies the given scheduler hints.
 // excludeHost can be set to exclude a specific host (e.g., during migration).
-func (s *ServiceScheduler) FindLeastLoadedAgent(ctx context.Context, provider string, az *string, excludeHost string) (string, error) {
+// The placement of anti-affinity group members is serialized until tx ends, tx
+// must therefore also store the chosen host.
+func (s *ServiceScheduler) FindLeastLoadedAgent(ctx context.Context, tx pgx.Tx, provider string, az *string, excludeHost string,
+	hints Hints) (string, error) {
+	if err := hints.lockAntiAffinityGroup(ctx, tx); err != nil {
+		return "", err
+	}
+
 	q := db.Select("agents.host", "COUNT(service.id) AS usage").
 		From("agents").
 		LeftJoin("service ON service.host = agents.host").
@@ -70,6 +170,15 @@ func (s *ServiceScheduler) FindLeastLoadedAgent(ctx context.Context, provider st
 	if excludeHost != "" {
 		q = q.Where(sq.NotEq{"agents.host": excludeHost})
 	}
+	if hints.PinnedHost != nil {
+		q = q.Where(sq.Eq{"agents.host": *hints.PinnedHost})
+	}
+	if hints.AntiAffinityGroup != nil {
+		q = q.Where(sq.Expr("NOT ?", hints.antiAffinityConflict(sq.Expr("agents.host"))))
+	}
+	if len(hints.Capabilities) > 0 {
+		q = q.Where(CapableAgents("agents.capabilities", hints.Capabilities))
+	}
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -78,13 +187,131 @@ func (s *ServiceScheduler) FindLeastLoadedAgent(ctx context.Context, provider st
 
 	var host string
 	var usage int
-	if err = s.pool.QueryRow(ctx, sql, args...).Scan(&host, &usage); err != nil {
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&host, &usage); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", s.explainNoAgent(ctx, tx, provider, az, excludeHost, hints)
+		}
 		return "", err
 	}
 
 	return host, nil
 }
 
+// explainNoAgent figures out which constraint left no agent to choose from.
+func (s *ServiceScheduler) explainNoAgent(ctx context.Context, tx pgx.Tx, provider string, az *string, excludeHost string, hints Hints) error {
+	zone := "<cross-AZ>"
+	if az != nil {
+		zone = *az
+	}
+
+	if hints.PinnedHost != nil {
+		if *hints.PinnedHost == excludeHost {
+			return &NoAgentError{Reason: fmt.Sprintf("service is pinned to host %q, which is being evacuated", excludeHost)}
+		}
+		if len(hints.Capabilities) > 0 {
+			return &NoAgentError{Reason: fmt.Sprintf("pinned host %q is not an enabled, healthy %s agent in availability zone %s supporting %s",
+				*hints.PinnedHost, provider, zone, strings.Join(hints.Capabilities, ", "))}
+		}
+		return &NoAgentError{Reason: fmt.Sprintf("pinned host %q is not an enabled, healthy %s agent in availability zone %s",
+			*hints.PinnedHost, provider, zone)}
+	}
+
+	q := db.Select("COUNT(*)").
+		From("agents").
+		Where(sq.And{
+			sq.Eq{"agents.enabled": true},
+			sq.Eq{"agents.provider": provider},
+			sq.Eq{"agents.availability_zone": az},
+			sq.Expr("agents.heartbeat_at > NOW() - INTERVAL '1 second' * ?", int(s.config.StaleTimeout.Seconds())),
+		})
+	if excludeHost != "" {
+		q = q.Where(sq.NotEq{"agents.host": excludeHost})
+	}
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	var candidates int
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&candidates); err != nil {
+		return err
+	}
+
+	if len(hints.Capabilities) > 0 && candidates > 0 {
+		sql, args, err = q.Where(CapableAgents("agents.capabilities", hints.Capabilities)).ToSql()
+		if err != nil {
+			return err
+		}
+		var capable int
+		if err = tx.QueryRow(ctx, sql, args...).Scan(&capable); err != nil {
+			return err
+		}
+		if capable == 0 {
+			return &NoAgentError{Reason: fmt.Sprintf("none of the %d healthy %s agents in availability zone %s supports %s",
+				candidates, provider, zone, strings.Join(hints.Capabilities, ", "))}
+		}
+		candidates = capable
+	}
+
+	if candidates > 0 && hints.AntiAffinityGroup != nil {
+		return &NoAgentError{Reason: fmt.Sprintf("all %d healthy %s agents in availability zone %s already host a service of anti-affinity group %q",
+			candidates, provider, zone, *hints.AntiAffinityGroup)}
+	}
+	return &NoAgentError{Reason: fmt.Sprintf("no enabled, healthy %s agent in availability zone %s", provider, zone)}
+}
+
+// HasAntiAffinityConflict reports whether host already runs another service of
+// the hinted anti-affinity group. Like FindLeastLoadedAgent, it serializes the
+// placement of the group until tx ends.
+func (s *ServiceScheduler) HasAntiAffinityConflict(ctx context.Context, tx pgx.Tx, host string, hints Hints) (bool, error) {
+	if hints.AntiAffinityGroup == nil {
+		return false, nil
+	}
+	if err := hints.lockAntiAffinityGroup(ctx, tx); err != nil {
+		return false, err
+	}
+
+	sql, args, err := db.Select().Column(hints.antiAffinityConflict(host)).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var conflict bool
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&conflict); err != nil {
+		return false, err
+	}
+	return conflict, nil
+}
+
+// CapableAgents matches agents whose capabilities column reports all given capabilities. Agents
+// reporting none predate capability reporting and are assumed capable, so their services stay
+// schedulable during rolling upgrades.
+func CapableAgents(column string, capabilities []string) sq.Sqlizer {
+	return sq.Or{
+		sq.Expr(column+" @> ?::varchar[]", capabilities),
+		sq.Expr("cardinality(" + column + ") = 0"),
+	}
+}
+
+// HasCapabilities reports whether the agent on host reports all given capabilities.
+func (s *ServiceScheduler) HasCapabilities(ctx context.Context, tx pgx.Tx, host string, capabilities []string) (bool, error) {
+	if len(capabilities) == 0 {
+		return true, nil
+	}
+
+	sql, args := db.Select("COUNT(*) > 0").
+		From("agents").
+		Where("host = ?", host).
+		Where(CapableAgents("capabilities", capabilities)).
+		MustSql()
+
+	var capable bool
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&capable); err != nil {
+		return false, err
+	}
+	return capable, nil
+}
+
 // GetStaleAgents returns agents that haven't sent a heartbeat within the stale timeout.
 func (s *ServiceScheduler) GetStaleAgents(ctx context.Context, provider string) ([]AgentInfo, error) {
 	sql, args := db.Select("host", "availability_zone").
@@ -140,28 +367,38 @@ func (s *ServiceScheduler) RescheduleStaleAgentServices(ctx context.Context, pro
 }
 
 // rescheduleAgentServices migrates all services from a stale agent to healthy ones.
-// Returns true if all services were successfully migrated, false otherwise.
+// Services pinned to the stale agent stay where they are.
+// Returns true if all movable services were successfully migrated, false otherwise.
 func (s *ServiceScheduler) rescheduleAgentServices(ctx context.Context, provider string, agent AgentInfo) (bool, error) {
 	// Find services on this agent
-	sql, args := db.Select("id").
+	sql, args := db.Select("id", "pinned_host").
 		From("service").
 		Where("host = ?", agent.Host).
 		Where("provider = ?", provider).
 		MustSql()
 
-	var serviceIDs []strfmt.UUID
-	if err := pgxscan.Select(ctx, s.pool, &serviceIDs, sql, args...); err != nil {
+	var services []struct {
+		ID         strfmt.UUID
+		PinnedHost *string
+	}
+	if err := pgxscan.Select(ctx, s.pool, &services, sql, args...); err != nil {
 		return false, err
 	}
 
-	if len(serviceIDs) == 0 {
+	if len(services) == 0 {
 		return true, nil // No services to migrate
 	}
 
 	failedCount := 0
-	for _, serviceID := range serviceIDs {
-		if err := s.MigrateService(ctx, serviceID, agent.Host, ""); err != nil {
-			log.WithError(err).WithField("service", serviceID).Warning("Failed to reschedule service")
+	for _, svc := range services {
+		if svc.PinnedHost != nil && *svc.PinnedHost == agent.Host {
+			log.WithFields(log.Fields{"service": svc.ID, "host": agent.Host}).
+				Warning("Service is pinned to stale agent, not rescheduling")
+			continue
+		}
+
+		if err := s.MigrateService(ctx, svc.ID, agent.Host, "", MigrationReasonReschedule); err != nil {
+			log.WithError(err).WithField("service", svc.ID).Warning("Failed to reschedule service")
 			failedCount++
 			continue
 		}
@@ -172,21 +409,28 @@ func (s *ServiceScheduler) rescheduleAgentServices(ctx context.Context, provider
 
 // MigrateService moves a service from one agent to another.
 // If targetHost is empty, the least-loaded agent is selected.
-func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.UUID, currentHost, targetHost string) error {
+// Every migration is recorded in service_migration for the hourly budget.
+func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.UUID, currentHost, targetHost string, reason MigrationReason) error {
 	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
 		// Get service details
 		var provider string
 		var az *string
 		var status string
-		sql, args := db.Select("provider", "availability_zone", "status").
+		var ports []int32
+		var snatPoolSize *int32
+		hints := Hints{ServiceID: serviceID}
+		sql, args := db.Select("provider", "availability_zone", "status", "project_id", "pinned_host", "anti_affinity_group",
+			"ports", "snat_pool_size").
 			From("service").
 			Where("id = ?", serviceID).
 			Suffix("FOR UPDATE").
 			MustSql()
 
-		if err := tx.QueryRow(ctx, sql, args...).Scan(&provider, &az, &status); err != nil {
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&provider, &az, &status, &hints.ProjectID,
+			&hints.PinnedHost, &hints.AntiAffinityGroup, &ports, &snatPoolSize); err != nil {
 			return err
 		}
+		hints.Capabilities = internal.RequiredCapabilities(ports, snatPoolSize)
 
 		// Skip re-migration while in flight; rebalance/stale retries pick it up once settled.
 		if status == string(models.ServiceStatusPENDINGUPDATE) {
@@ -200,23 +444,39 @@ func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.
 		var err error
 		if targetHost != "" {
 			newHost = targetHost
-			// Validate target host exists and is healthy
-			sql, args = db.Select("1").
+			if hints.PinnedHost != nil && *hints.PinnedHost != targetHost {
+				return &NoAgentError{Reason: fmt.Sprintf("service is pinned to host %q", *hints.PinnedHost)}
+			}
+
+			// Validate target host exists, is healthy and capable
+			q := db.Select("1").
 				From("agents").
 				Where("host = ?", targetHost).
 				Where("enabled = true").
 				Where("provider = ?", provider).
 				Where("availability_zone = ?", az).
-				Where("heartbeat_at > NOW() - INTERVAL '1 second' * ?", int(s.config.StaleTimeout.Seconds())).
-				MustSql()
+				Where("heartbeat_at > NOW() - INTERVAL '1 second' * ?", int(s.config.StaleTimeout.Seconds()))
+			if len(hints.Capabilities) > 0 {
+				q = q.Where(CapableAgents("capabilities", hints.Capabilities))
+			}
+			sql, args = q.MustSql()
 
 			var exists int
 			if err = tx.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
 				return err
 			}
+
+			conflict, err := s.HasAntiAffinityConflict(ctx, tx, targetHost, hints)
+			if err != nil {
+				return err
+			}
+			if conflict {
+				return &NoAgentError{Reason: fmt.Sprintf("host %q already runs a service of anti-affinity group %q",
+					targetHost, *hints.AntiAffinityGroup)}
+			}
 		} else {
 			// Find least-loaded agent
-			newHost, err = s.FindLeastLoadedAgent(ctx, provider, az, currentHost)
+			newHost, err = s.FindLeastLoadedAgent(ctx, tx, provider, az, currentHost, hints)
 			if err != nil {
 				return err
 			}
@@ -230,6 +490,7 @@ func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.
 			"service": serviceID,
 			"from":    currentHost,
 			"to":      newHost,
+			"reason":  reason,
 		}).Info("Migrating service")
 
 		// Update service host
@@ -256,6 +517,10 @@ func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.
 			return err
 		}
 
+		if err = RecordMigration(ctx, tx, serviceID, currentHost, newHost, reason); err != nil {
+			return err
+		}
+
 		// Notify both agents
 		if s.notify != nil {
 			s.notify(currentHost)
@@ -265,3 +530,16 @@ func (s *ServiceScheduler) MigrateService(ctx context.Context, serviceID strfmt.
 		return nil
 	})
 }
+
+// RecordMigration logs a service migration in service_migration, where it counts against the
+// hourly migration budget.
+func RecordMigration(ctx context.Context, tx pgx.Tx, serviceID strfmt.UUID, fromHost, toHost string,
+	reason MigrationReason) error {
+	sql, args := db.Insert("service_migration").
+		Columns("service_id", "from_host", "to_host", "reason").
+		Values(serviceID, fromHost, toHost, reason).
+		MustSql()
+
+	_, err := tx.Exec(ctx, sql, args...)
+	return err
+}
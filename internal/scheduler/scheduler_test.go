@@ -10,9 +10,12 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/sapcc/archer/v2/internal"
 )
 
 func defaultConfig() Config {
@@ -56,12 +59,15 @@ func TestServiceScheduler_FindLeastLoadedAgent(t *testing.T) {
 		defer mock.Close()
 
 		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
 
 		mock.ExpectQuery("SELECT agents.host, COUNT").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}).AddRow("agent-1", 2))
 
-		host, err := scheduler.FindLeastLoadedAgent(ctx, "cp", &az, "")
+		host, err := scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "", Hints{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, "agent-1", host)
@@ -74,12 +80,15 @@ func TestServiceScheduler_FindLeastLoadedAgent(t *testing.T) {
 		defer mock.Close()
 
 		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
 
 		mock.ExpectQuery("SELECT agents.host, COUNT").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}).AddRow("agent-2", 3))
 
-		host, err := scheduler.FindLeastLoadedAgent(ctx, "cp", &az, "agent-1")
+		host, err := scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "agent-1", Hints{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, "agent-2", host)
@@ -92,18 +101,173 @@ func TestServiceScheduler_FindLeastLoadedAgent(t *testing.T) {
 		defer mock.Close()
 
 		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
 
 		mock.ExpectQuery("SELECT agents.host, COUNT").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM agents").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+
+		_, err = scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "", Hints{})
+
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.EqualError(t, err, "no agent qualifies: no enabled, healthy cp agent in availability zone az1")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("restricts to pinned host", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
+		pinned := "agent-3"
+
+		mock.ExpectQuery("SELECT agents.host, COUNT.+agents.host = \\$5").
+			WithArgs(true, "cp", az, pgxmock.AnyArg(), pinned).
+			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}).AddRow("agent-3", 7))
+
+		host, err := scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "", Hints{PinnedHost: &pinned})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "agent-3", host)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("explains unavailable pinned host", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
+		pinned := "agent-3"
+
+		mock.ExpectQuery("SELECT agents.host, COUNT").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}))
+
+		_, err = scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "", Hints{PinnedHost: &pinned})
+
+		var noAgent *NoAgentError
+		require.ErrorAs(t, err, &noAgent)
+		assert.Equal(t, `pinned host "agent-3" is not an enabled, healthy cp agent in availability zone az1`, noAgent.Reason)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("excludes agents hosting anti-affinity group members", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
+		group := "db-replicas"
+		serviceID := strfmt.UUID("46ca20cf-84c3-4210-a360-3f79875f6b9b")
+
+		mock.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1, hashtext\\(\\$2\\)\\)").
+			WithArgs(advisoryLockAntiAffinity, "project-1/db-replicas").
+			WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		mock.ExpectQuery("NOT EXISTS\\( SELECT 1 FROM service aa WHERE aa.host = agents.host AND aa.anti_affinity_group = \\$5 AND aa.project_id = \\$6 AND aa.id <> \\$7 \\)").
+			WithArgs(true, "cp", az, pgxmock.AnyArg(), group, "project-1", serviceID).
+			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM agents").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+
+		_, err = scheduler.FindLeastLoadedAgent(ctx, tx, "cp", &az, "", Hints{
+			AntiAffinityGroup: &group,
+			ProjectID:         "project-1",
+			ServiceID:         serviceID,
+		})
+
+		assert.EqualError(t, err, `no agent qualifies: all 2 healthy cp agents in availability zone az1 already host a service of anti-affinity group "db-replicas"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("requires capabilities", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+		mock.ExpectBegin()
+		tx, err := mock.Begin(ctx)
+		require.NoError(t, err)
+		capabilities := []string{internal.CapabilityWildcardPort}
+
+		mock.ExpectQuery("agents.capabilities @> \\$5::varchar\\[\\] OR cardinality\\(agents.capabilities\\) = 0").
+			WithArgs(true, "tenant", az, pgxmock.AnyArg(), capabilities).
+			WillReturnRows(pgxmock.NewRows([]string{"host", "usage"}))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM agents").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM agents .* AND \\(agents.capabilities @> \\$5::varchar\\[\\] OR cardinality\\(agents.capabilities\\) = 0\\)").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), capabilities).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
 
-		_, err = scheduler.FindLeastLoadedAgent(ctx, "cp", &az, "")
+		_, err = scheduler.FindLeastLoadedAgent(ctx, tx, "tenant", &az, "", Hints{Capabilities: capabilities})
 
-		assert.Error(t, err)
+		assert.EqualError(t, err, "no agent qualifies: none of the 3 healthy tenant agents in availability zone az1 supports wildcard_port")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
+func TestServiceScheduler_HasAntiAffinityConflict(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mock.Close()
+
+	scheduler := NewServiceScheduler(mock, defaultConfig(), nil)
+	group := "db-replicas"
+	serviceID := strfmt.UUID("46ca20cf-84c3-4210-a360-3f79875f6b9b")
+	mock.ExpectBegin()
+	tx, err := mock.Begin(ctx)
+	require.NoError(t, err)
+
+	// placements of the same group are serialized before looking for members
+	mock.ExpectExec("SELECT pg_advisory_xact_lock($1, hashtext($2))").
+		WithArgs(advisoryLockAntiAffinity, "project-1/db-replicas").
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectQuery("SELECT EXISTS( SELECT 1 FROM service aa WHERE aa.host = $1 AND aa.anti_affinity_group = $2 AND aa.project_id = $3 AND aa.id <> $4 )").
+		WithArgs("agent-1", group, "project-1", serviceID).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	conflict, err := scheduler.HasAntiAffinityConflict(ctx, tx, "agent-1", Hints{
+		AntiAffinityGroup: &group,
+		ProjectID:         "project-1",
+		ServiceID:         serviceID,
+	})
+	require.NoError(t, err)
+	assert.True(t, conflict)
+
+	// no group, no lock
+	conflict, err = scheduler.HasAntiAffinityConflict(ctx, tx, "agent-1", Hints{ProjectID: "project-1"})
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCapableAgents(t *testing.T) {
+	sql, args, err := CapableAgents("capabilities", []string{internal.CapabilitySnatPool}).ToSql()
+	require.NoError(t, err)
+	// agents reporting no capabilities predate capability reporting
+	assert.Equal(t, "(capabilities @> ?::varchar[] OR cardinality(capabilities) = 0)", sql)
+	assert.Equal(t, []any{[]string{internal.CapabilitySnatPool}}, args)
+}
+
 func TestServiceScheduler_ConfigValues(t *testing.T) {
 	cfg := Config{
 		StaleTimeout:           10 * time.Minute,
@@ -159,6 +323,9 @@ func TestServiceScheduler_NotifyCallback(t *testing.T) {
 // already PENDING_UPDATE is not re-migrated: MigrateService reads the status
 // under FOR UPDATE and returns without issuing any host UPDATE or notify, so
 // rebalance/stale retries cannot reset an in-flight migration.
+var migrateServiceColumns = []string{"provider", "availability_zone", "status", "project_id", "pinned_host",
+	"anti_affinity_group", "ports", "snat_pool_size"}
+
 func TestServiceScheduler_MigrateService_SkipsInProgress(t *testing.T) {
 	ctx := context.Background()
 	cfg := defaultConfig()
@@ -175,16 +342,49 @@ func TestServiceScheduler_MigrateService_SkipsInProgress(t *testing.T) {
 
 	mock.ExpectBegin()
 	// FOR UPDATE select now also returns status; PENDING_UPDATE means in flight.
-	mock.ExpectQuery("SELECT provider, availability_zone, status FROM service").
+	mock.ExpectQuery("SELECT provider, availability_zone, status, project_id, pinned_host, anti_affinity_group, ports, snat_pool_size FROM service").
 		WithArgs(serviceID).
-		WillReturnRows(pgxmock.NewRows([]string{"provider", "availability_zone", "status"}).
-			AddRow("tenant", &az, "PENDING_UPDATE"))
+		WillReturnRows(pgxmock.NewRows(migrateServiceColumns).
+			AddRow("tenant", &az, "PENDING_UPDATE", "project-1", (*string)(nil), (*string)(nil), []int32{443}, (*int32)(nil)))
 	// No UPDATE expected — the guard returns before any write.
 	mock.ExpectCommit()
 	mock.ExpectRollback() // BeginFunc's deferred rollback (no-op after commit)
 
-	err = scheduler.MigrateService(ctx, serviceID, "lb011-01", "lb017-archer")
+	err = scheduler.MigrateService(ctx, serviceID, "lb011-01", "lb017-archer", MigrationReasonRebalance)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, notified, "must not notify agents when skipping in-flight migration")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+// TestServiceScheduler_MigrateService_RespectsPin verifies that an explicit
+// target host different from the service's pinned host is refused before any
+// write happens.
+func TestServiceScheduler_MigrateService_RespectsPin(t *testing.T) {
+	ctx := context.Background()
+	cfg := defaultConfig()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	scheduler := NewServiceScheduler(mock, cfg, nil)
+
+	serviceID := strfmt.UUID("46ca20cf-84c3-4210-a360-3f79875f6b9b")
+	az := "az1"
+	pinned := "lb011-01"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT provider, availability_zone, status, project_id, pinned_host, anti_affinity_group, ports, snat_pool_size FROM service").
+		WithArgs(serviceID).
+		WillReturnRows(pgxmock.NewRows(migrateServiceColumns).
+			AddRow("tenant", &az, "AVAILABLE", "project-1", &pinned, (*string)(nil), []int32{443}, (*int32)(nil)))
+	mock.ExpectRollback()
+	mock.ExpectRollback() // BeginFunc's deferred rollback
+
+	err = scheduler.MigrateService(ctx, serviceID, "lb011-01", "lb017-archer", MigrationReasonRebalance)
+
+	var noAgent *NoAgentError
+	require.ErrorAs(t, err, &noAgent)
+	assert.Equal(t, `service is pinned to host "lb011-01"`, noAgent.Reason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/sapcc/archer/v2/internal/db"
+)
+
+// stalenessQueryTimeout bounds the agents query run on every scrape.
+const stalenessQueryTimeout = 10 * time.Second
+
+// StalenessCollector exports the heartbeat age of every registered agent, so
+// alerts can fire before the background scheduler reschedules its services.
+type StalenessCollector struct {
+	pool         db.PgxIface
+	staleTimeout time.Duration
+	age          *prometheus.Desc
+	stale        *prometheus.Desc
+}
+
+// NewStalenessCollector creates a collector reading the agents table on scrape.
+func NewStalenessCollector(pool db.PgxIface, staleTimeout time.Duration) *StalenessCollector {
+	labels := []string{"host", "provider", "availability_zone"}
+	return &StalenessCollector{
+		pool:         pool,
+		staleTimeout: staleTimeout,
+		age: prometheus.NewDesc("archer_agent_heartbeat_age_seconds",
+			"Seconds since the last heartbeat of the agent", labels, nil),
+		stale: prometheus.NewDesc("archer_agent_stale",
+			"Whether the agent missed heartbeats for longer than the stale timeout", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *StalenessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.age
+	ch <- c.stale
+}
+
+// Collect implements prometheus.Collector.
+func (c *StalenessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), stalenessQueryTimeout)
+	defer cancel()
+
+	sql, args := db.Select("host", "provider", "availability_zone",
+		"EXTRACT(EPOCH FROM NOW() - heartbeat_at)::float8 AS age").
+		From("agents").
+		MustSql()
+
+	var agents []struct {
+		Host             string
+		Provider         string
+		AvailabilityZone *string
+		Age              float64
+	}
+	if err := pgxscan.Select(ctx, c.pool, &agents, sql, args...); err != nil {
+		log.WithError(err).Error("Failed to query agent heartbeats")
+		ch <- prometheus.NewInvalidMetric(c.age, err)
+		return
+	}
+
+	for _, agent := range agents {
+		az := ""
+		if agent.AvailabilityZone != nil {
+			az = *agent.AvailabilityZone
+		}
+		stale := 0.0
+		if agent.Age > c.staleTimeout.Seconds() {
+			stale = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.age, prometheus.GaugeValue, agent.Age, agent.Host, agent.Provider, az)
+		ch <- prometheus.MustNewConstMetric(c.stale, prometheus.GaugeValue, stale, agent.Host, agent.Provider, az)
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStalenessCollector(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT host, provider, availability_zone, EXTRACT\\(EPOCH FROM NOW\\(\\) - heartbeat_at\\)::float8 AS age FROM agents").
+		WillReturnRows(pgxmock.NewRows([]string{"host", "provider", "availability_zone", "age"}).
+			AddRow("agent-1", "cp", new("az1"), 12.5).
+			AddRow("agent-2", "tenant", nil, 600.0))
+
+	expected := `
+# HELP archer_agent_heartbeat_age_seconds Seconds since the last heartbeat of the agent
+# TYPE archer_agent_heartbeat_age_seconds gauge
+archer_agent_heartbeat_age_seconds{availability_zone="az1",host="agent-1",provider="cp"} 12.5
+archer_agent_heartbeat_age_seconds{availability_zone="",host="agent-2",provider="tenant"} 600
+# HELP archer_agent_stale Whether the agent missed heartbeats for longer than the stale timeout
+# TYPE archer_agent_stale gauge
+archer_agent_stale{availability_zone="az1",host="agent-1",provider="cp"} 0
+archer_agent_stale{availability_zone="",host="agent-2",provider="tenant"} 1
+`
+	collector := NewStalenessCollector(mock, 5*time.Minute)
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MaintenanceWindow is a recurring time window in which rebalancing may
+// migrate services. Each window opens at every activation of its cron
+// schedule and stays open for Duration.
+type MaintenanceWindow struct {
+	Spec     string
+	Duration time.Duration
+	schedule cron.Schedule
+}
+
+// ParseMaintenanceWindows parses standard 5-field cron expressions (optionally
+// prefixed with CRON_TZ=<zone>) into maintenance windows of the given duration.
+func ParseMaintenanceWindows(specs []string, duration time.Duration) ([]MaintenanceWindow, error) {
+	if len(specs) > 0 && duration <= 0 {
+		return nil, fmt.Errorf("maintenance window duration must be positive, got %v", duration)
+	}
+
+	windows := make([]MaintenanceWindow, 0, len(specs))
+	for _, spec := range specs {
+		schedule, err := cron.ParseStandard(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %w", spec, err)
+		}
+		windows = append(windows, MaintenanceWindow{
+			Spec:     spec,
+			Duration: duration,
+			schedule: schedule,
+		})
+	}
+	return windows, nil
+}
+
+// Contains reports whether t falls into an open window, i.e. the schedule
+// activated within the last Duration.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	return !w.schedule.Next(t.Add(-w.Duration)).After(t)
+}
+
+// inMaintenanceWindow reports whether rebalancing may migrate services at t.
+// Without configured windows, rebalancing is not time-restricted.
+func (s *ServiceScheduler) inMaintenanceWindow(t time.Time) bool {
+	if len(s.config.MaintenanceWindows) == 0 {
+		return true
+	}
+	for _, w := range s.config.MaintenanceWindows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceWindows(t *testing.T) {
+	t.Run("parses standard cron expressions", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows([]string{"0 22 * * 1-5", "CRON_TZ=Europe/Berlin 0 3 * * 6"}, 2*time.Hour)
+		require.NoError(t, err)
+		assert.Len(t, windows, 2)
+		assert.Equal(t, 2*time.Hour, windows[0].Duration)
+	})
+
+	t.Run("rejects invalid expression", func(t *testing.T) {
+		_, err := ParseMaintenanceWindows([]string{"not a cron"}, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects non-positive duration", func(t *testing.T) {
+		_, err := ParseMaintenanceWindows([]string{"0 22 * * *"}, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		windows, err := ParseMaintenanceWindows(nil, 0)
+		require.NoError(t, err)
+		assert.Empty(t, windows)
+	})
+}
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	windows, err := ParseMaintenanceWindows([]string{"0 22 * * *"}, 3*time.Hour)
+	require.NoError(t, err)
+	w := windows[0]
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	assert.False(t, w.Contains(day.Add(21*time.Hour+59*time.Minute)), "before window opens")
+	assert.True(t, w.Contains(day.Add(22*time.Hour)), "window opening")
+	assert.True(t, w.Contains(day.Add(23*time.Hour+30*time.Minute)), "inside window")
+	assert.True(t, w.Contains(day.Add(24*time.Hour+59*time.Minute)), "past midnight, still inside")
+	assert.False(t, w.Contains(day.Add(25*time.Hour)), "window closed")
+	assert.False(t, w.Contains(day.Add(12*time.Hour)), "business hours")
+}
+
+func TestServiceScheduler_InMaintenanceWindow(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	t.Run("unrestricted without windows", func(t *testing.T) {
+		scheduler := NewServiceScheduler(mock, defaultConfig(), nil)
+		assert.True(t, scheduler.inMaintenanceWindow(time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)))
+	})
+
+	t.Run("restricted to configured windows", func(t *testing.T) {
+		cfg := defaultConfig()
+		cfg.MaintenanceWindows, err = ParseMaintenanceWindows([]string{"0 22 * * *", "0 4 * * *"}, time.Hour)
+		require.NoError(t, err)
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+
+		assert.False(t, scheduler.inMaintenanceWindow(time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)))
+		assert.True(t, scheduler.inMaintenanceWindow(time.Date(2025, 3, 10, 22, 30, 0, 0, time.Local)))
+		assert.True(t, scheduler.inMaintenanceWindow(time.Date(2025, 3, 10, 4, 15, 0, 0, time.Local)))
+	})
+}
+
+func TestServiceScheduler_RebalanceServices_Restrictions(t *testing.T) {
+	ctx := context.Background()
+	az := "az1"
+
+	t.Run("skips outside maintenance window without touching the database", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		cfg := defaultConfig()
+		cfg.MaintenanceWindows, err = ParseMaintenanceWindows([]string{"0 22 * * *"}, time.Hour)
+		require.NoError(t, err)
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+		scheduler.now = func() time.Time { return time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local) }
+
+		assert.NoError(t, scheduler.RebalanceServices(ctx, "cp", &az))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips when hourly budget is exhausted", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		cfg := defaultConfig()
+		cfg.MigrationsPerHour = 10
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM service_migration").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(10))
+
+		assert.NoError(t, scheduler.RebalanceServices(ctx, "cp", &az))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestServiceScheduler_RemainingMigrationBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unlimited falls back to max migrations per cycle", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		scheduler := NewServiceScheduler(mock, defaultConfig(), nil)
+		budget, err := scheduler.remainingMigrationBudget(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, budget)
+	})
+
+	t.Run("subtracts recent migrations", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		cfg := defaultConfig()
+		cfg.MigrationsPerHour = 8
+		scheduler := NewServiceScheduler(mock, cfg, nil)
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM service_migration").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(6))
+
+		budget, err := scheduler.remainingMigrationBudget(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, budget)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
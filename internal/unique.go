@@ -4,16 +4,16 @@
 
 package internal
 
-func UniqueOrNil(s []string) []string {
+func UniqueOrNil[T comparable](s []T) []T {
 	if s == nil {
 		return nil
 	}
 	return Unique(s)
 }
 
-func Unique(s []string) []string {
-	in := make(map[string]struct{})
-	result := make([]string, 0)
+func Unique[T comparable](s []T) []T {
+	in := make(map[T]struct{})
+	result := make([]T, 0)
 	for _, str := range s {
 		if _, ok := in[str]; !ok {
 			in[str] = struct{}{}
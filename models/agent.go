@@ -24,6 +24,7 @@ package models
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"time"
 
 	"github.com/go-openapi/errors"
@@ -41,12 +42,38 @@ type Agent struct {
 	// Example: AZ-A
 	AvailabilityZone *string `json:"availability_zone"`
 
+	// Local interface carrying each physical network of the agent.
+	// Example: {"physnet1":"portchannel1","physnet2":"portchannel2"}
+	// Read Only: true
+	BridgeMappings map[string]string `json:"bridge_mappings"`
+
+	// Optional features supported by the agent. Services requiring a
+	// capability (`wildcard_port` for `ports: [0]`, `snat_pool` for
+	// `snat_pool_size` > 1) are only scheduled to agents reporting it.
+	//
+	// Example: ["snat_pool","wildcard_port"]
+	// Read Only: true
+	Capabilities []string `json:"capabilities"`
+
 	// created at
 	CreatedAt time.Time `json:"created_at,omitempty"`
 
+	// Type of the device driven by the agent, if any.
+	// Example: bigip
+	// Read Only: true
+	DeviceType *string `json:"device_type"`
+
 	// Whether the agent is enabled.
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// Failover state of the active device driven by the agent, if any.
+	// Example: active
+	// Read Only: true
+	FailoverState *string `json:"failover_state"`
+
+	// health
+	Health *AgentHealth `json:"health,omitempty"`
+
 	// heartbeat at
 	HeartbeatAt time.Time `json:"heartbeat_at,omitempty"`
 
@@ -55,9 +82,17 @@ type Agent struct {
 	// Read Only: true
 	Host string `json:"host,omitempty"`
 
-	// Physical network the agent is connected to.
+	// Preferred physical network the agent is connected to.
 	Physnet *string `json:"physnet"`
 
+	// Physical networks the agent is connected to, in order of preference.
+	// Endpoint segments are resolved on the first physical network the
+	// target network has a segment on.
+	//
+	// Example: ["physnet1","physnet2"]
+	// Read Only: true
+	Physnets []string `json:"physnets"`
+
 	// Provider type of the agent.
 	// Example: tenant
 	// Enum: ["tenant","cp"]
@@ -69,6 +104,11 @@ type Agent struct {
 
 	// updated at
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+
+	// Software version reported by the agent.
+	// Example: 2.7.0
+	// Read Only: true
+	Version *string `json:"version"`
 }
 
 // Validate validates this agent
@@ -79,6 +119,10 @@ func (m *Agent) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealth(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHeartbeatAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -105,6 +149,29 @@ func (m *Agent) validateCreatedAt(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Agent) validateHealth(formats strfmt.Registry) error {
+	if swag.IsZero(m.Health) { // not required
+		return nil
+	}
+
+	if m.Health != nil {
+		if err := m.Health.Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("health")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("health")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Agent) validateHeartbeatAt(formats strfmt.Registry) error {
 	if swag.IsZero(m.HeartbeatAt) { // not required
 		return nil
@@ -167,20 +234,96 @@ func (m *Agent) validateUpdatedAt(formats strfmt.Registry) error {
 func (m *Agent) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateCapabilities(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateDeviceType(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateFailoverState(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateHealth(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateHost(ctx, formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.contextValidatePhysnets(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateServices(ctx, formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateVersion(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *Agent) contextValidateCapabilities(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "capabilities", "body", []string(m.Capabilities)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Agent) contextValidateDeviceType(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "device_type", "body", m.DeviceType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Agent) contextValidateFailoverState(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "failover_state", "body", m.FailoverState); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Agent) contextValidateHealth(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Health != nil {
+
+		if swag.IsZero(m.Health) { // not required
+			return nil
+		}
+
+		if err := m.Health.ContextValidate(ctx, formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("health")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("health")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Agent) contextValidateHost(ctx context.Context, formats strfmt.Registry) error {
 
 	if err := validate.ReadOnly(ctx, "host", "body", m.Host); err != nil {
@@ -190,6 +333,15 @@ func (m *Agent) contextValidateHost(ctx context.Context, formats strfmt.Registry
 	return nil
 }
 
+func (m *Agent) contextValidatePhysnets(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "physnets", "body", []string(m.Physnets)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Agent) contextValidateServices(ctx context.Context, formats strfmt.Registry) error {
 
 	if err := validate.ReadOnly(ctx, "services", "body", m.Services); err != nil {
@@ -199,6 +351,15 @@ func (m *Agent) contextValidateServices(ctx context.Context, formats strfmt.Regi
 	return nil
 }
 
+func (m *Agent) contextValidateVersion(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "version", "body", m.Version); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Agent) MarshalBinary() ([]byte, error) {
 	if m == nil {
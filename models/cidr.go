@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// CIDR represents an IP network (IPv4 or IPv6) in CIDR notation that can be scanned from PostgreSQL cidr type.
+type CIDR string
+
+// Validate implements runtime.Validatable for go-swagger validation.
+func (c CIDR) Validate(_ strfmt.Registry) error {
+	prefix, err := netip.ParsePrefix(string(c))
+	if err != nil {
+		return fmt.Errorf("invalid CIDR: %s", c)
+	}
+	// PostgreSQL cidr rejects host bits set to the right of the mask
+	if prefix != prefix.Masked() {
+		return fmt.Errorf("invalid CIDR: %s has host bits set, did you mean %s", c, prefix.Masked())
+	}
+	return nil
+}
+
+// ContextValidate implements runtime.ContextValidatable for go-swagger.
+func (c CIDR) ContextValidate(_ context.Context, _ strfmt.Registry) error {
+	return nil
+}
+
+// ScanNetipPrefix implements pgtype.NetipPrefixScanner for pgx cidr scanning.
+func (c *CIDR) ScanNetipPrefix(v netip.Prefix) error {
+	if !v.IsValid() {
+		*c = ""
+		return nil
+	}
+	*c = CIDR(v.String())
+	return nil
+}
+
+// NetipPrefixValue implements pgtype.NetipPrefixValuer for pgx cidr encoding.
+func (c CIDR) NetipPrefixValue() (netip.Prefix, error) {
+	if c == "" {
+		return netip.Prefix{}, nil
+	}
+	prefix, err := netip.ParsePrefix(string(c))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("parsing cidr %q: %w", c, err)
+	}
+	return prefix, nil
+}
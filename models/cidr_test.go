@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright 2025 SAP SE or an SAP affiliate company
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCIDR_ScanNetipPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   netip.Prefix
+		expected CIDR
+	}{
+		{"IPv4 network", netip.MustParsePrefix("10.0.0.0/24"), "10.0.0.0/24"},
+		{"IPv4 host", netip.MustParsePrefix("1.2.3.4/32"), "1.2.3.4/32"},
+		{"IPv6 network", netip.MustParsePrefix("2001:db8::/32"), "2001:db8::/32"},
+		{"zero prefix", netip.Prefix{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cidr CIDR
+			err := cidr.ScanNetipPrefix(tt.prefix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cidr)
+		})
+	}
+}
+
+func TestCIDR_NetipPrefixValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidr     CIDR
+		expected netip.Prefix
+		wantErr  bool
+	}{
+		{"IPv4", "10.0.0.0/8", netip.MustParsePrefix("10.0.0.0/8"), false},
+		{"IPv6", "2001:db8::/32", netip.MustParsePrefix("2001:db8::/32"), false},
+		{"empty", "", netip.Prefix{}, false},
+		{"invalid", "not-a-cidr", netip.Prefix{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.cidr.NetipPrefixValue()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCIDR_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidr    CIDR
+		wantErr bool
+	}{
+		{"valid IPv4", "10.180.0.0/16", false},
+		{"valid IPv4 host", "10.180.1.2/32", false},
+		{"valid IPv6", "2001:db8::/32", false},
+		{"empty", "", true},
+		{"invalid garbage", "not-a-cidr", true},
+		{"missing prefix length", "10.180.0.0", true},
+		{"host bits set", "10.180.1.2/16", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cidr.Validate(nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -38,6 +38,17 @@ import (
 // swagger:model Endpoint
 type Endpoint struct {
 
+	// Source networks (CIDR notation) of the consumer network allowed to connect to the endpoint, connections from other sources are rejected. Omit or leave empty to allow all sources.
+	//
+	// Max Items: 64
+	// Unique: true
+	AllowedCidrs []CIDR `json:"allowed_cidrs"`
+
+	// Maximum number of concurrent connections of this endpoint, overrides the `connection_limit` of the service. Omit to inherit the limit of the service, set to 0 for no limit.
+	//
+	// Minimum: 0
+	ConnectionLimit *int32 `json:"connection_limit,omitempty"`
+
 	// **Note: This option currently only affects endpoints for services with provider type `tenant`.**
 	//
 	// Enable BIG-IP connection mirroring for high availability failover.
@@ -70,9 +81,25 @@ type Endpoint struct {
 	// Max Length: 64
 	Name string `json:"name"`
 
+	// Listen ports of the endpoint differing from the service port, e.g. to expose service port 443 on 8443 or to avoid a port already used in the consumer network. Exposed service ports without mapping are listened on as is.
+	//
+	// Max Items: 8
+	PortMappings []*PortMapping `json:"port_mappings"`
+
+	// Subset of the service ports exposed by the endpoint, all ports of the service are exposed if omitted or empty. Ports selected or mapped by an endpoint cannot be removed from the service.
+	//
+	// Max Items: 8
+	// Unique: true
+	Ports []int32 `json:"ports"`
+
 	// project id
 	ProjectID Project `json:"project_id"`
 
+	// Maximum number of new connections per second of this endpoint, overrides the `rate_limit` of the service. Omit to inherit the limit of the service, set to 0 for no limit.
+	//
+	// Minimum: 0
+	RateLimit *int32 `json:"rate_limit,omitempty"`
+
 	// The ID of the service.
 	// Format: uuid
 	ServiceID strfmt.UUID `json:"service_id,omitempty"`
@@ -94,6 +121,14 @@ type Endpoint struct {
 func (m *Endpoint) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAllowedCidrs(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateConnectionLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreatedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -110,10 +145,22 @@ func (m *Endpoint) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePortMappings(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePorts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateProjectID(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateRateLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateServiceID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -140,6 +187,53 @@ func (m *Endpoint) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Endpoint) validateAllowedCidrs(formats strfmt.Registry) error {
+	if swag.IsZero(m.AllowedCidrs) { // not required
+		return nil
+	}
+
+	iAllowedCidrsSize := int64(len(m.AllowedCidrs))
+
+	if err := validate.MaxItems("allowed_cidrs", "body", iAllowedCidrsSize, 64); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("allowed_cidrs", "body", m.AllowedCidrs); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.AllowedCidrs); i++ {
+
+		if err := m.AllowedCidrs[i].Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("allowed_cidrs" + "." + strconv.Itoa(i))
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("allowed_cidrs" + "." + strconv.Itoa(i))
+			}
+
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Endpoint) validateConnectionLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.ConnectionLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("connection_limit", "body", int64(*m.ConnectionLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Endpoint) validateCreatedAt(formats strfmt.Registry) error {
 	if swag.IsZero(m.CreatedAt) { // not required
 		return nil
@@ -184,6 +278,72 @@ func (m *Endpoint) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Endpoint) validatePortMappings(formats strfmt.Registry) error {
+	if swag.IsZero(m.PortMappings) { // not required
+		return nil
+	}
+
+	iPortMappingsSize := int64(len(m.PortMappings))
+
+	if err := validate.MaxItems("port_mappings", "body", iPortMappingsSize, 8); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.PortMappings); i++ {
+		if swag.IsZero(m.PortMappings[i]) { // not required
+			continue
+		}
+
+		if m.PortMappings[i] != nil {
+			if err := m.PortMappings[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("port_mappings" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("port_mappings" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Endpoint) validatePorts(formats strfmt.Registry) error {
+	if swag.IsZero(m.Ports) { // not required
+		return nil
+	}
+
+	iPortsSize := int64(len(m.Ports))
+
+	if err := validate.MaxItems("ports", "body", iPortsSize, 8); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("ports", "body", m.Ports); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Ports); i++ {
+
+		if err := validate.MinimumInt("ports"+"."+strconv.Itoa(i), "body", int64(m.Ports[i]), 0, false); err != nil {
+			return err
+		}
+
+		if err := validate.MaximumInt("ports"+"."+strconv.Itoa(i), "body", int64(m.Ports[i]), 65535, false); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Endpoint) validateProjectID(formats strfmt.Registry) error {
 	if swag.IsZero(m.ProjectID) { // not required
 		return nil
@@ -205,6 +365,18 @@ func (m *Endpoint) validateProjectID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Endpoint) validateRateLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.RateLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("rate_limit", "body", int64(*m.RateLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Endpoint) validateServiceID(formats strfmt.Registry) error {
 	if swag.IsZero(m.ServiceID) { // not required
 		return nil
@@ -295,6 +467,10 @@ func (m *Endpoint) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidatePortMappings(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateProjectID(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -331,6 +507,35 @@ func (m *Endpoint) contextValidateIPAddress(ctx context.Context, formats strfmt.
 	return nil
 }
 
+func (m *Endpoint) contextValidatePortMappings(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.PortMappings); i++ {
+
+		if m.PortMappings[i] != nil {
+
+			if swag.IsZero(m.PortMappings[i]) { // not required
+				return nil
+			}
+
+			if err := m.PortMappings[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("port_mappings" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("port_mappings" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Endpoint) contextValidateProjectID(ctx context.Context, formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ProjectID) { // not required
@@ -39,11 +39,21 @@ import (
 // swagger:model Service
 type Service struct {
 
+	// Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.
+	// Example: db-replicas
+	// Max Length: 64
+	AntiAffinityGroup *string `json:"anti_affinity_group,omitempty"`
+
 	// Availability zone of this service. If set to null, the service will be configured as a cross-AZ (cross-availability-zone) service, providing redundancy across all availability zones. Cross-AZ services are only supported in specific regions and providers that have cross-AZ agents deployed. If no cross-AZ agent is available for the requested region/provider, service creation will fail with a "No available host agent found" error.
 	// Example: AZ-A
 	// Max Length: 64
 	AvailabilityZone *string `json:"availability_zone"`
 
+	// Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit or set to 0 for no limit.
+	//
+	// Minimum: 0
+	ConnectionLimit *int32 `json:"connection_limit,omitempty"`
+
 	// created at
 	CreatedAt time.Time `json:"created_at,omitempty"`
 
@@ -88,6 +98,15 @@ type Service struct {
 	// Min Items: 1
 	IPAddresses []InetAddress `json:"ip_addresses"`
 
+	// Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions.
+	// Max Items: 8
+	// Unique: true
+	Irules []string `json:"irules"`
+
+	// Load balancing options of the IP addresses of the service. Addresses without options are members of weight 1 in priority group 0.
+	// Max Items: 16
+	Members []*ServiceMember `json:"members"`
+
 	// Name of the service.
 	// Example: ExampleService
 	// Max Length: 64
@@ -97,6 +116,11 @@ type Service struct {
 	// Format: uuid
 	NetworkID *strfmt.UUID `json:"network_id,omitempty"`
 
+	// Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.
+	// Example: lb-agent-01
+	// Max Length: 64
+	PinnedHost *string `json:"pinned_host,omitempty"`
+
 	// Ports exposed by the service. Port 0 is a wildcard meaning "all TCP ports". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.
 	// Required: true
 	// Max Items: 8
@@ -125,6 +149,11 @@ type Service struct {
 	// Proxy protocol v2 enabled for this service.
 	ProxyProtocol *bool `json:"proxy_protocol,omitempty"`
 
+	// Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit or set to 0 for no limit.
+	//
+	// Minimum: 0
+	RateLimit *int32 `json:"rate_limit,omitempty"`
+
 	// Require explicit project approval for the service owner.
 	RequireApproval *bool `json:"require_approval,omitempty"`
 
@@ -140,6 +169,9 @@ type Service struct {
 	// The list of tags on the resource.
 	Tags []string `json:"tags"`
 
+	// traffic
+	Traffic *ServiceTraffic `json:"traffic,omitempty"`
+
 	// updated at
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 
@@ -152,10 +184,18 @@ type Service struct {
 func (m *Service) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAntiAffinityGroup(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateAvailabilityZone(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateConnectionLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreatedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -180,6 +220,14 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateIrules(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMembers(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
@@ -188,6 +236,10 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePinnedHost(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePorts(formats); err != nil {
 		res = append(res, err)
 	}
@@ -204,6 +256,10 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRateLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSnatPoolSize(formats); err != nil {
 		res = append(res, err)
 	}
@@ -216,6 +272,10 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTraffic(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUpdatedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -230,6 +290,18 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateAntiAffinityGroup(formats strfmt.Registry) error {
+	if swag.IsZero(m.AntiAffinityGroup) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("anti_affinity_group", "body", *m.AntiAffinityGroup, 64); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Service) validateAvailabilityZone(formats strfmt.Registry) error {
 	if swag.IsZero(m.AvailabilityZone) { // not required
 		return nil
@@ -242,6 +314,18 @@ func (m *Service) validateAvailabilityZone(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateConnectionLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.ConnectionLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("connection_limit", "body", int64(*m.ConnectionLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Service) validateCreatedAt(formats strfmt.Registry) error {
 	if swag.IsZero(m.CreatedAt) { // not required
 		return nil
@@ -366,6 +450,68 @@ func (m *Service) validateIPAddresses(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateIrules(formats strfmt.Registry) error {
+	if swag.IsZero(m.Irules) { // not required
+		return nil
+	}
+
+	iIrulesSize := int64(len(m.Irules))
+
+	if err := validate.MaxItems("irules", "body", iIrulesSize, 8); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("irules", "body", m.Irules); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Irules); i++ {
+
+		if err := validate.MaxLength("irules"+"."+strconv.Itoa(i), "body", m.Irules[i], 64); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Service) validateMembers(formats strfmt.Registry) error {
+	if swag.IsZero(m.Members) { // not required
+		return nil
+	}
+
+	iMembersSize := int64(len(m.Members))
+
+	if err := validate.MaxItems("members", "body", iMembersSize, 16); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Members); i++ {
+		if swag.IsZero(m.Members[i]) { // not required
+			continue
+		}
+
+		if m.Members[i] != nil {
+			if err := m.Members[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Service) validateName(formats strfmt.Registry) error {
 	if swag.IsZero(m.Name) { // not required
 		return nil
@@ -390,6 +536,18 @@ func (m *Service) validateNetworkID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validatePinnedHost(formats strfmt.Registry) error {
+	if swag.IsZero(m.PinnedHost) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("pinned_host", "body", *m.PinnedHost, 64); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Service) validatePorts(formats strfmt.Registry) error {
 
 	if err := validate.Required("ports", "body", m.Ports); err != nil {
@@ -530,6 +688,18 @@ func (m *Service) validateProvider(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateRateLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.RateLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("rate_limit", "body", int64(*m.RateLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Service) validateSnatPoolSize(formats strfmt.Registry) error {
 	if swag.IsZero(m.SnatPoolSize) { // not required
 		return nil
@@ -583,6 +753,29 @@ func (m *Service) validateTags(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Service) validateTraffic(formats strfmt.Registry) error {
+	if swag.IsZero(m.Traffic) { // not required
+		return nil
+	}
+
+	if m.Traffic != nil {
+		if err := m.Traffic.Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("traffic")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("traffic")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Service) validateUpdatedAt(formats strfmt.Registry) error {
 	if swag.IsZero(m.UpdatedAt) { // not required
 		return nil
@@ -653,6 +846,10 @@ func (m *Service) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateMembers(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateProjectID(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -661,6 +858,10 @@ func (m *Service) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateTraffic(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -703,6 +904,35 @@ func (m *Service) contextValidateInUse(ctx context.Context, formats strfmt.Regis
 	return nil
 }
 
+func (m *Service) contextValidateMembers(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Members); i++ {
+
+		if m.Members[i] != nil {
+
+			if swag.IsZero(m.Members[i]) { // not required
+				return nil
+			}
+
+			if err := m.Members[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Service) contextValidateProjectID(ctx context.Context, formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ProjectID) { // not required
@@ -747,6 +977,31 @@ func (m *Service) contextValidateStatus(ctx context.Context, formats strfmt.Regi
 	return nil
 }
 
+func (m *Service) contextValidateTraffic(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Traffic != nil {
+
+		if swag.IsZero(m.Traffic) { // not required
+			return nil
+		}
+
+		if err := m.Traffic.ContextValidate(ctx, formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("traffic")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("traffic")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Service) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -38,6 +38,16 @@ import (
 // swagger:model ServiceUpdatable
 type ServiceUpdatable struct {
 
+	// Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.
+	// Example: db-replicas
+	// Max Length: 64
+	AntiAffinityGroup *string `json:"anti_affinity_group,omitempty"`
+
+	// Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.
+	//
+	// Minimum: 0
+	ConnectionLimit *int32 `json:"connection_limit,omitempty"`
+
 	// Description of the service.
 	// Example: An example of an Service.
 	// Max Length: 255
@@ -50,11 +60,25 @@ type ServiceUpdatable struct {
 	// Min Items: 1
 	IPAddresses []InetAddress `json:"ip_addresses"`
 
+	// Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions. Omit to leave unchanged, set to an empty list to detach all iRules.
+	// Max Items: 8
+	// Unique: true
+	Irules []string `json:"irules"`
+
+	// Load balancing options of the IP addresses of the service, replaces the current options. Set to an empty list to balance all addresses round robin.
+	// Max Items: 16
+	Members []*ServiceMember `json:"members"`
+
 	// Name of the service.
 	// Example: ExampleService
 	// Max Length: 64
 	Name *string `json:"name,omitempty"`
 
+	// Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.
+	// Example: lb-agent-01
+	// Max Length: 64
+	PinnedHost *string `json:"pinned_host,omitempty"`
+
 	// Ports exposed by the service. Port 0 is a wildcard meaning "all TCP ports". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.
 	// Max Items: 8
 	// Min Items: 0
@@ -75,6 +99,11 @@ type ServiceUpdatable struct {
 	// Proxy protocol v2 enabled for this service.
 	ProxyProtocol *bool `json:"proxy_protocol,omitempty"`
 
+	// Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.
+	//
+	// Minimum: 0
+	RateLimit *int32 `json:"rate_limit,omitempty"`
+
 	// Require explicit project approval for the service owner.
 	RequireApproval *bool `json:"require_approval,omitempty"`
 
@@ -87,6 +116,9 @@ type ServiceUpdatable struct {
 	// The list of tags on the resource.
 	Tags []string `json:"tags"`
 
+	// traffic
+	Traffic *ServiceTraffic `json:"traffic,omitempty"`
+
 	// Set global visibility of the service. For `private` visibility, RBAC policies can extend the visibility to specific projects.
 	// Enum: ["private","public"]
 	Visibility *string `json:"visibility,omitempty"`
@@ -96,6 +128,14 @@ type ServiceUpdatable struct {
 func (m *ServiceUpdatable) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAntiAffinityGroup(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateConnectionLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
@@ -104,10 +144,22 @@ func (m *ServiceUpdatable) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateIrules(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMembers(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validatePinnedHost(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePorts(formats); err != nil {
 		res = append(res, err)
 	}
@@ -116,6 +168,10 @@ func (m *ServiceUpdatable) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRateLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSnatPoolSize(formats); err != nil {
 		res = append(res, err)
 	}
@@ -124,6 +180,10 @@ func (m *ServiceUpdatable) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTraffic(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateVisibility(formats); err != nil {
 		res = append(res, err)
 	}
@@ -134,6 +194,30 @@ func (m *ServiceUpdatable) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceUpdatable) validateAntiAffinityGroup(formats strfmt.Registry) error {
+	if swag.IsZero(m.AntiAffinityGroup) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("anti_affinity_group", "body", *m.AntiAffinityGroup, 64); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ServiceUpdatable) validateConnectionLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.ConnectionLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("connection_limit", "body", int64(*m.ConnectionLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *ServiceUpdatable) validateDescription(formats strfmt.Registry) error {
 	if swag.IsZero(m.Description) { // not required
 		return nil
@@ -177,6 +261,68 @@ func (m *ServiceUpdatable) validateIPAddresses(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceUpdatable) validateIrules(formats strfmt.Registry) error {
+	if swag.IsZero(m.Irules) { // not required
+		return nil
+	}
+
+	iIrulesSize := int64(len(m.Irules))
+
+	if err := validate.MaxItems("irules", "body", iIrulesSize, 8); err != nil {
+		return err
+	}
+
+	if err := validate.UniqueItems("irules", "body", m.Irules); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Irules); i++ {
+
+		if err := validate.MaxLength("irules"+"."+strconv.Itoa(i), "body", m.Irules[i], 64); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ServiceUpdatable) validateMembers(formats strfmt.Registry) error {
+	if swag.IsZero(m.Members) { // not required
+		return nil
+	}
+
+	iMembersSize := int64(len(m.Members))
+
+	if err := validate.MaxItems("members", "body", iMembersSize, 16); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Members); i++ {
+		if swag.IsZero(m.Members[i]) { // not required
+			continue
+		}
+
+		if m.Members[i] != nil {
+			if err := m.Members[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *ServiceUpdatable) validateName(formats strfmt.Registry) error {
 	if swag.IsZero(m.Name) { // not required
 		return nil
@@ -189,6 +335,18 @@ func (m *ServiceUpdatable) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceUpdatable) validatePinnedHost(formats strfmt.Registry) error {
+	if swag.IsZero(m.PinnedHost) { // not required
+		return nil
+	}
+
+	if err := validate.MaxLength("pinned_host", "body", *m.PinnedHost, 64); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *ServiceUpdatable) validatePorts(formats strfmt.Registry) error {
 	if swag.IsZero(m.Ports) { // not required
 		return nil
@@ -265,6 +423,18 @@ func (m *ServiceUpdatable) validateProtocol(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceUpdatable) validateRateLimit(formats strfmt.Registry) error {
+	if swag.IsZero(m.RateLimit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("rate_limit", "body", int64(*m.RateLimit), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *ServiceUpdatable) validateSnatPoolSize(formats strfmt.Registry) error {
 	if swag.IsZero(m.SnatPoolSize) { // not required
 		return nil
@@ -297,6 +467,29 @@ func (m *ServiceUpdatable) validateTags(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ServiceUpdatable) validateTraffic(formats strfmt.Registry) error {
+	if swag.IsZero(m.Traffic) { // not required
+		return nil
+	}
+
+	if m.Traffic != nil {
+		if err := m.Traffic.Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("traffic")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("traffic")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 var serviceUpdatableTypeVisibilityPropEnum []any
 
 func init() {
@@ -339,8 +532,75 @@ func (m *ServiceUpdatable) validateVisibility(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this service updatable based on context it is used
+// ContextValidate validate this service updatable based on the context it is used
 func (m *ServiceUpdatable) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateMembers(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateTraffic(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ServiceUpdatable) contextValidateMembers(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Members); i++ {
+
+		if m.Members[i] != nil {
+
+			if swag.IsZero(m.Members[i]) { // not required
+				return nil
+			}
+
+			if err := m.Members[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("members" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ServiceUpdatable) contextValidateTraffic(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Traffic != nil {
+
+		if swag.IsZero(m.Traffic) { // not required
+			return nil
+		}
+
+		if err := m.Traffic.ContextValidate(ctx, formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("traffic")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("traffic")
+			}
+
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -41,6 +41,7 @@ import (
 	"github.com/sapcc/archer/v2/restapi/operations"
 	"github.com/sapcc/archer/v2/restapi/operations/agent"
 	"github.com/sapcc/archer/v2/restapi/operations/endpoint"
+	"github.com/sapcc/archer/v2/restapi/operations/irule"
 	"github.com/sapcc/archer/v2/restapi/operations/quota"
 	"github.com/sapcc/archer/v2/restapi/operations/rbac"
 	"github.com/sapcc/archer/v2/restapi/operations/service"
@@ -109,6 +110,7 @@ func configureAPI(api *operations.ArcherAPI) http.Handler {
 		collector := pgxpoolprometheus.NewCollector(pool, map[string]string{"db_name": connConfig.ConnConfig.Database})
 		prometheus.MustRegister(collector)
 		prometheus.MustRegister(httpRequestDuration, httpRequestsTotal, httpResponseSize)
+		prometheus.MustRegister(scheduler.NewStalenessCollector(pool, config.Global.Agent.AgentStaleTimeout))
 	}
 
 	profiling.Start()
@@ -207,17 +209,30 @@ func configureAPI(api *operations.ArcherAPI) http.Handler {
 	api.RbacPutRbacPoliciesRbacPolicyIDHandler = rbac.PutRbacPoliciesRbacPolicyIDHandlerFunc(c.PutRbacPoliciesRbacPolicyIDHandler)
 	api.RbacDeleteRbacPoliciesRbacPolicyIDHandler = rbac.DeleteRbacPoliciesRbacPolicyIDHandlerFunc(c.DeleteRbacPoliciesRbacPolicyIDHandler)
 
+	api.IruleGetIrulesHandler = irule.GetIrulesHandlerFunc(c.GetIrulesHandler)
+	api.IrulePostIrulesHandler = irule.PostIrulesHandlerFunc(c.PostIrulesHandler)
+	api.IruleGetIrulesIruleIDHandler = irule.GetIrulesIruleIDHandlerFunc(c.GetIrulesIruleIDHandler)
+	api.IrulePutIrulesIruleIDHandler = irule.PutIrulesIruleIDHandlerFunc(c.PutIrulesIruleIDHandler)
+	api.IruleDeleteIrulesIruleIDHandler = irule.DeleteIrulesIruleIDHandlerFunc(c.DeleteIrulesIruleIDHandler)
+
 	api.AgentGetAgentsHandler = agent.GetAgentsHandlerFunc(c.GetAgentsHandler)
 	api.AgentGetAgentsAgentHostHandler = agent.GetAgentsAgentHostHandlerFunc(c.GetAgentsAgentHostHandler)
 
 	// Start background scheduler for agent rescheduling and rebalancing
 	// Uses PostgreSQL advisory locks for distributed leader election across multiple API instances
+	maintenanceWindows, err := scheduler.ParseMaintenanceWindows(config.Global.Agent.MaintenanceWindows,
+		config.Global.Agent.MaintenanceWindowLength)
+	if err != nil {
+		log.Fatalf("Invalid maintenance window configuration: %v", err)
+	}
 	schedulerCfg := scheduler.Config{
 		StaleTimeout:           config.Global.Agent.AgentStaleTimeout,
 		CheckInterval:          config.Global.Agent.RescheduleCheckInterval,
 		RebalanceDelay:         config.Global.Agent.RebalanceDelay,
 		RebalanceThreshold:     config.Global.Agent.RebalanceThreshold,
 		RebalanceMaxMigrations: config.Global.Agent.RebalanceMaxMigrations,
+		MaintenanceWindows:     maintenanceWindows,
+		MigrationsPerHour:      config.Global.Agent.MigrationsPerHour,
 	}
 	notifyFunc := func(host string) { db.NotifyService(pool, host) }
 	serviceScheduler := scheduler.NewServiceScheduler(pool, schedulerCfg, notifyFunc)
@@ -386,6 +386,30 @@ func init() {
             "schema": {
               "type": "object",
               "properties": {
+                "allowed_cidrs": {
+                  "description": "Source networks (CIDR notation) allowed to connect to the endpoint. Omit to leave unchanged, set to an empty list to allow all sources.\n",
+                  "type": "array",
+                  "maxItems": 64,
+                  "uniqueItems": true,
+                  "items": {
+                    "type": "string",
+                    "x-go-type": {
+                      "import": {
+                        "package": "github.com/sapcc/archer/v2/models"
+                      },
+                      "type": "CIDR"
+                    },
+                    "example": "10.180.0.0/16"
+                  }
+                },
+                "connection_limit": {
+                  "description": "Maximum number of concurrent connections of this endpoint, overrides the ` + "`" + `connection_limit` + "`" + ` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.\n",
+                  "type": "integer",
+                  "format": "int32",
+                  "minimum": -1,
+                  "x-nullable": true,
+                  "x-omitempty": true
+                },
                 "connection_mirroring": {
                   "description": "Enable BIG-IP connection mirroring for high availability failover.\n**Note: This option currently only affects endpoints for services with provider type ` + "`" + `tenant` + "`" + `.**\n",
                   "type": "boolean",
@@ -405,6 +429,35 @@ func init() {
                   "x-nullable": true,
                   "example": "Example endpoint."
                 },
+                "port_mappings": {
+                  "description": "Listen ports of the endpoint differing from the service port. Omit to leave unchanged, set to an empty list to listen on the service ports.\n",
+                  "type": "array",
+                  "maxItems": 8,
+                  "items": {
+                    "$ref": "#/definitions/PortMapping"
+                  }
+                },
+                "ports": {
+                  "description": "Subset of the service ports exposed by the endpoint. Omit to leave unchanged, set to an empty list to expose all ports of the service.\n",
+                  "type": "array",
+                  "maxItems": 8,
+                  "uniqueItems": true,
+                  "items": {
+                    "type": "integer",
+                    "format": "int32",
+                    "maximum": 65535,
+                    "x-nullable": false,
+                    "example": 443
+                  }
+                },
+                "rate_limit": {
+                  "description": "Maximum number of new connections per second of this endpoint, overrides the ` + "`" + `rate_limit` + "`" + ` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.\n",
+                  "type": "integer",
+                  "format": "int32",
+                  "minimum": -1,
+                  "x-nullable": true,
+                  "x-omitempty": true
+                },
                 "tags": {
                   "description": "The list of tags on the resource.",
                   "type": "array",
@@ -496,6 +549,262 @@ func init() {
         }
       ]
     },
+    "/irules": {
+      "get": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "List iRules",
+        "parameters": [
+          {
+            "$ref": "#/parameters/marker"
+          },
+          {
+            "$ref": "#/parameters/limit"
+          },
+          {
+            "$ref": "#/parameters/sort"
+          },
+          {
+            "$ref": "#/parameters/page_reverse"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A JSON array of iRules",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "items": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/IRule"
+                  }
+                },
+                "links": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Link"
+                  },
+                  "x-omitempty": true
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:read"
+      },
+      "post": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Add iRule to the catalog",
+        "parameters": [
+          {
+            "description": "iRule",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            },
+            "headers": {
+              "X-Target-Id": {
+                "type": "string",
+                "format": "uuid",
+                "description": "The UUID of the created resource"
+              }
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "409": {
+            "description": "Duplicate iRule name",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:create"
+      }
+    },
+    "/irules/{irule_id}": {
+      "get": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Show details of an iRule",
+        "responses": {
+          "200": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:read"
+      },
+      "put": {
+        "description": "Updates the iRule, endpoints of services using it are updated with the new content.\n",
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Update an existing iRule",
+        "parameters": [
+          {
+            "description": "iRule resource that needs to be updated",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IRuleUpdatable"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:update"
+      },
+      "delete": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Remove iRule from the catalog",
+        "responses": {
+          "204": {
+            "description": "iRule successfully deleted."
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "iRule is in use by a service",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:delete"
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "format": "uuid",
+          "description": "The UUID of the iRule.",
+          "name": "irule_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/quotas": {
       "get": {
         "tags": [
@@ -1622,16 +1931,61 @@ func init() {
           "x-omitempty": false,
           "example": "AZ-A"
         },
-        "created_at": {
-          "$ref": "#/definitions/Timestamp"
-        },
-        "enabled": {
-          "description": "Whether the agent is enabled.",
-          "type": "boolean",
-          "default": true
+        "bridge_mappings": {
+          "description": "Local interface carrying each physical network of the agent.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": {
+            "physnet1": "portchannel1",
+            "physnet2": "portchannel2"
+          }
         },
-        "heartbeat_at": {
-          "$ref": "#/definitions/Timestamp"
+        "capabilities": {
+          "description": "Optional features supported by the agent. Services requiring a\ncapability (` + "`" + `wildcard_port` + "`" + ` for ` + "`" + `ports: [0]` + "`" + `, ` + "`" + `snat_pool` + "`" + ` for\n` + "`" + `snat_pool_size` + "`" + ` \u003e 1) are only scheduled to agents reporting it.\n",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": [
+            "snat_pool",
+            "wildcard_port"
+          ]
+        },
+        "created_at": {
+          "$ref": "#/definitions/Timestamp"
+        },
+        "device_type": {
+          "description": "Type of the device driven by the agent, if any.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "bigip"
+        },
+        "enabled": {
+          "description": "Whether the agent is enabled.",
+          "type": "boolean",
+          "default": true
+        },
+        "failover_state": {
+          "description": "Failover state of the active device driven by the agent, if any.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "active"
+        },
+        "health": {
+          "$ref": "#/definitions/AgentHealth"
+        },
+        "heartbeat_at": {
+          "$ref": "#/definitions/Timestamp"
         },
         "host": {
           "description": "The hostname of the agent.",
@@ -1640,11 +1994,24 @@ func init() {
           "example": "agent-host-01"
         },
         "physnet": {
-          "description": "Physical network the agent is connected to.",
+          "description": "Preferred physical network the agent is connected to.",
           "type": "string",
           "x-nullable": true,
           "x-omitempty": false
         },
+        "physnets": {
+          "description": "Physical networks the agent is connected to, in order of preference.\nEndpoint segments are resolved on the first physical network the\ntarget network has a segment on.\n",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": [
+            "physnet1",
+            "physnet2"
+          ]
+        },
         "provider": {
           "description": "Provider type of the agent.",
           "type": "string",
@@ -1663,12 +2030,96 @@ func init() {
         },
         "updated_at": {
           "$ref": "#/definitions/Timestamp"
+        },
+        "version": {
+          "description": "Software version reported by the agent.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "2.7.0"
+        }
+      }
+    },
+    "AgentHealth": {
+      "description": "Health details reported by the agent with every heartbeat.",
+      "type": "object",
+      "properties": {
+        "active_device": {
+          "description": "Hostname of the device AS3 declarations are posted to, F5 agents only.",
+          "type": "string",
+          "example": "bigip-1.example.com"
+        },
+        "devices": {
+          "description": "Reachability of each device driven by the agent, by hostname.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "boolean"
+          },
+          "example": {
+            "bigip-1.example.com": true,
+            "bigip-2.example.com": false
+          }
+        },
+        "last_as3_post_at": {
+          "description": "Time of the last AS3 declaration post, F5 agents only.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "last_as3_post_error": {
+          "description": "Error of the last AS3 declaration post, empty if it succeeded.",
+          "type": "string"
+        },
+        "last_as3_post_tenant": {
+          "description": "Tenant of the last AS3 declaration post.",
+          "type": "string",
+          "example": "Common"
+        },
+        "last_failover_at": {
+          "description": "Time the agent last switched to another active device.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "last_process_services_at": {
+          "description": "Time of the last successful service sync.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "queue_depth": {
+          "description": "Number of services and endpoints of the agent pending processing.",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
         }
       }
     },
     "Endpoint": {
       "type": "object",
       "properties": {
+        "allowed_cidrs": {
+          "description": "Source networks (CIDR notation) of the consumer network allowed to connect to the endpoint, connections from other sources are rejected. Omit or leave empty to allow all sources.\n",
+          "type": "array",
+          "maxItems": 64,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "x-go-type": {
+              "type": "CIDR"
+            },
+            "example": "10.180.0.0/16"
+          },
+          "x-omitempty": false
+        },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of this endpoint, overrides the ` + "`" + `connection_limit` + "`" + ` of the service. Omit to inherit the limit of the service, set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "connection_mirroring": {
           "description": "**Note: This option currently only affects endpoints for services with provider type ` + "`" + `tenant` + "`" + `.**\n\nEnable BIG-IP connection mirroring for high availability failover.\nWhen enabled, connection and persistence information is mirrored to\nthe standby device in a DSC configuration.\nEnabling mirroring can increase latency of the endpoint.\n",
           "type": "boolean",
@@ -1703,9 +2154,39 @@ func init() {
           "x-omitempty": false,
           "example": "Example endpoint."
         },
+        "port_mappings": {
+          "description": "Listen ports of the endpoint differing from the service port, e.g. to expose service port 443 on 8443 or to avoid a port already used in the consumer network. Exposed service ports without mapping are listened on as is.\n",
+          "type": "array",
+          "maxItems": 8,
+          "items": {
+            "$ref": "#/definitions/PortMapping"
+          },
+          "x-omitempty": false
+        },
+        "ports": {
+          "description": "Subset of the service ports exposed by the endpoint, all ports of the service are exposed if omitted or empty. Ports selected or mapped by an endpoint cannot be removed from the service.\n",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "integer",
+            "format": "int32",
+            "maximum": 65535,
+            "x-nullable": false,
+            "example": 443
+          },
+          "x-omitempty": false
+        },
         "project_id": {
           "$ref": "#/definitions/Project"
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of this endpoint, overrides the ` + "`" + `rate_limit` + "`" + ` of the service. Omit to inherit the limit of the service, set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "service_id": {
           "description": "The ID of the service.",
           "type": "string",
@@ -1821,6 +2302,65 @@ func init() {
         }
       }
     },
+    "IRule": {
+      "type": "object",
+      "required": [
+        "name",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "TCL source of the iRule.",
+          "type": "string",
+          "example": "when HTTP_REQUEST { HTTP::header insert X-Forwarded-For [IP::remote_addr] }"
+        },
+        "created_at": {
+          "$ref": "#/definitions/Timestamp"
+        },
+        "description": {
+          "description": "Description of the iRule.",
+          "type": "string",
+          "maxLength": 255,
+          "example": "Inserts the X-Forwarded-For header."
+        },
+        "id": {
+          "description": "The ID of the resource.",
+          "type": "string",
+          "format": "uuid",
+          "readOnly": true
+        },
+        "name": {
+          "description": "Unique name of the iRule, referenced by services.",
+          "type": "string",
+          "maxLength": 64,
+          "pattern": "^[A-Za-z0-9_.-]+$",
+          "example": "x-forwarded-for"
+        },
+        "updated_at": {
+          "$ref": "#/definitions/Timestamp"
+        }
+      },
+      "x-go-name": "irule"
+    },
+    "IRuleUpdatable": {
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "TCL source of the iRule.",
+          "type": "string",
+          "x-nullable": true,
+          "example": "when HTTP_REQUEST { HTTP::header insert X-Forwarded-For [IP::remote_addr] }"
+        },
+        "description": {
+          "description": "Description of the iRule.",
+          "type": "string",
+          "maxLength": 255,
+          "x-nullable": true,
+          "example": "Inserts the X-Forwarded-For header."
+        }
+      },
+      "x-go-name": "irule_updatable"
+    },
     "Link": {
       "type": "object",
       "properties": {
@@ -1834,6 +2374,32 @@ func init() {
         }
       }
     },
+    "PortMapping": {
+      "description": "Maps a port the endpoint listens on to a port of the service.",
+      "type": "object",
+      "required": [
+        "listen_port",
+        "service_port"
+      ],
+      "properties": {
+        "listen_port": {
+          "description": "Port the endpoint listens on.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65535,
+          "minimum": 1,
+          "example": 8443
+        },
+        "service_port": {
+          "description": "Port of the service the connections are forwarded to.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65535,
+          "minimum": 1,
+          "example": 443
+        }
+      }
+    },
     "Project": {
       "description": "The ID of the project owning this resource.",
       "type": "string",
@@ -1959,6 +2525,13 @@ func init() {
         "ip_addresses"
       ],
       "properties": {
+        "anti_affinity_group": {
+          "description": "Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "db-replicas"
+        },
         "availability_zone": {
           "description": "Availability zone of this service. If set to null, the service will be configured as a cross-AZ (cross-availability-zone) service, providing redundancy across all availability zones. Cross-AZ services are only supported in specific regions and providers that have cross-AZ agents deployed. If no cross-AZ agent is available for the requested region/provider, service creation will fail with a \"No available host agent found\" error.",
           "type": "string",
@@ -1967,6 +2540,13 @@ func init() {
           "x-omitempty": false,
           "example": "AZ-A"
         },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit or set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "created_at": {
           "$ref": "#/definitions/Timestamp"
         },
@@ -2027,6 +2607,25 @@ func init() {
             "example": "1.2.3.4"
           }
         },
+        "irules": {
+          "description": "Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions.",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "maxLength": 64
+          }
+        },
+        "members": {
+          "description": "Load balancing options of the IP addresses of the service. Addresses without options are members of weight 1 in priority group 0.",
+          "type": "array",
+          "maxItems": 16,
+          "items": {
+            "$ref": "#/definitions/ServiceMember"
+          },
+          "x-omitempty": false
+        },
         "name": {
           "description": "Name of the service.",
           "type": "string",
@@ -2040,6 +2639,13 @@ func init() {
           "format": "uuid",
           "x-nullable": true
         },
+        "pinned_host": {
+          "description": "Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "lb-agent-01"
+        },
         "ports": {
           "description": "Ports exposed by the service. Port 0 is a wildcard meaning \"all TCP ports\". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.",
           "type": "array",
@@ -2083,6 +2689,13 @@ func init() {
           "type": "boolean",
           "default": true
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit or set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "require_approval": {
           "description": "Require explicit project approval for the service owner.",
           "type": "boolean",
@@ -2109,6 +2722,9 @@ func init() {
           },
           "x-nullable": true
         },
+        "traffic": {
+          "$ref": "#/definitions/ServiceTraffic"
+        },
         "updated_at": {
           "$ref": "#/definitions/Timestamp"
         },
@@ -2123,6 +2739,42 @@ func init() {
         }
       }
     },
+    "ServiceMember": {
+      "description": "Load balancing options of an IP address of the service.",
+      "type": "object",
+      "required": [
+        "ip_address"
+      ],
+      "properties": {
+        "backup": {
+          "description": "Member only receives connections while no other member is available.",
+          "type": "boolean"
+        },
+        "ip_address": {
+          "description": "IP Address of the service the options apply to.",
+          "type": "string",
+          "x-go-type": {
+            "type": "InetAddress"
+          },
+          "example": "1.2.3.4"
+        },
+        "priority_group": {
+          "description": "Members of the highest priority group receive all connections, members of lower groups only while no member of a higher group is available.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65534,
+          "example": 1
+        },
+        "weight": {
+          "description": "Relative share of the connections the member receives, defaults to 1.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 256,
+          "minimum": 1,
+          "example": 2
+        }
+      }
+    },
     "ServiceStatus": {
       "description": "Status of the service.\n\n### Status can be one of\n| Status           | Description                            |\n| ---------------- | -------------------------------------- |\n| AVAILABLE        | Service is ready for consumption.      |\n| PENDING_CREATE   | Service is being set up                |\n| PENDING_UPDATE   | Service is being updated               |\n| PENDING_DELETE   | Service is being deleted               |\n| UNAVAILABLE      | Service is unavailable (e.g. disabled) |\n| ERROR_QUOTA      | Service has not enough port quota      |\n",
       "type": "string",
@@ -2136,9 +2788,72 @@ func init() {
       ],
       "readOnly": true
     },
+    "ServiceTraffic": {
+      "description": "Traffic settings of the connections of every endpoint to the service, unset settings use the defaults of the provider. On update, given settings replace the current ones and 0 resets a setting to its default.",
+      "type": "object",
+      "properties": {
+        "connect_timeout": {
+          "description": "Seconds to wait for a connection to the service to be established. Only supported by provider=cp, defaults to 30.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 3600,
+          "x-nullable": true,
+          "example": 5
+        },
+        "http_keep_alive": {
+          "description": "HTTP keep-alive of services with protocol HTTP: ` + "`" + `client` + "`" + ` keeps client connections open between requests and closes the connection to the service after every response, ` + "`" + `both` + "`" + ` also reuses the connections to the service, ` + "`" + `none` + "`" + ` closes both after every response. Only supported by provider=cp, defaults to ` + "`" + `client` + "`" + `. Set it to ` + "`" + `client` + "`" + ` to reset it, e.g. before changing the protocol of the service.",
+          "type": "string",
+          "enum": [
+            "both",
+            "client",
+            "none"
+          ],
+          "x-nullable": true,
+          "example": "both"
+        },
+        "idle_timeout": {
+          "description": "Seconds a connection may be inactive while waiting for the client or the service to send data. Defaults to 32 for provider=cp and to the idle timeout of the agent's TCP profile for provider=tenant.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 604800,
+          "x-nullable": true,
+          "example": 300
+        },
+        "max_connections": {
+          "description": "Maximum number of concurrent connections to each IP address of the service, per endpoint for provider=cp and of all endpoints together for provider=tenant. Connections exceeding the limit of all addresses are queued (provider=cp) or rejected (provider=tenant).",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 1000000,
+          "x-nullable": true,
+          "example": 100
+        },
+        "tunnel_timeout": {
+          "description": "Seconds an established TCP connection, or an HTTP connection upgraded e.g. to WebSocket, may be inactive, superseding idle_timeout once both sides are connected. Only supported by provider=cp, defaults to 3600.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 604800,
+          "x-nullable": true,
+          "example": 86400
+        }
+      }
+    },
     "ServiceUpdatable": {
       "type": "object",
       "properties": {
+        "anti_affinity_group": {
+          "description": "Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "db-replicas"
+        },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "description": {
           "description": "Description of the service.",
           "type": "string",
@@ -2164,6 +2879,24 @@ func init() {
             "example": "1.2.3.4"
           }
         },
+        "irules": {
+          "description": "Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions. Omit to leave unchanged, set to an empty list to detach all iRules.",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "maxLength": 64
+          }
+        },
+        "members": {
+          "description": "Load balancing options of the IP addresses of the service, replaces the current options. Set to an empty list to balance all addresses round robin.",
+          "type": "array",
+          "maxItems": 16,
+          "items": {
+            "$ref": "#/definitions/ServiceMember"
+          }
+        },
         "name": {
           "description": "Name of the service.",
           "type": "string",
@@ -2171,6 +2904,13 @@ func init() {
           "x-nullable": true,
           "example": "ExampleService"
         },
+        "pinned_host": {
+          "description": "Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "lb-agent-01"
+        },
         "ports": {
           "description": "Ports exposed by the service. Port 0 is a wildcard meaning \"all TCP ports\". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.",
           "type": "array",
@@ -2198,6 +2938,13 @@ func init() {
           "type": "boolean",
           "x-nullable": true
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "require_approval": {
           "description": "Require explicit project approval for the service owner.",
           "type": "boolean",
@@ -2220,6 +2967,9 @@ func init() {
             "maxLength": 128
           }
         },
+        "traffic": {
+          "$ref": "#/definitions/ServiceTraffic"
+        },
         "visibility": {
           "description": "Set global visibility of the service. For ` + "`" + `private` + "`" + ` visibility, RBAC policies can extend the visibility to specific projects.",
           "type": "string",
@@ -2533,6 +3283,11 @@ func init() {
       "name": "RBAC",
       "x-go-name": "rbac"
     },
+    {
+      "description": "### iRule Catalog\nAdministrative API for managing the catalog of vetted iRules that can be attached to services.\n",
+      "name": "IRule",
+      "x-go-name": "irule"
+    },
     {
       "description": "### Quota Operations\nAdministrative API for listing and setting quotas for services and endpoints.\n",
       "name": "Quota"
@@ -2971,6 +3726,30 @@ func init() {
             "schema": {
               "type": "object",
               "properties": {
+                "allowed_cidrs": {
+                  "description": "Source networks (CIDR notation) allowed to connect to the endpoint. Omit to leave unchanged, set to an empty list to allow all sources.\n",
+                  "type": "array",
+                  "maxItems": 64,
+                  "uniqueItems": true,
+                  "items": {
+                    "type": "string",
+                    "x-go-type": {
+                      "import": {
+                        "package": "github.com/sapcc/archer/v2/models"
+                      },
+                      "type": "CIDR"
+                    },
+                    "example": "10.180.0.0/16"
+                  }
+                },
+                "connection_limit": {
+                  "description": "Maximum number of concurrent connections of this endpoint, overrides the ` + "`" + `connection_limit` + "`" + ` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.\n",
+                  "type": "integer",
+                  "format": "int32",
+                  "minimum": -1,
+                  "x-nullable": true,
+                  "x-omitempty": true
+                },
                 "connection_mirroring": {
                   "description": "Enable BIG-IP connection mirroring for high availability failover.\n**Note: This option currently only affects endpoints for services with provider type ` + "`" + `tenant` + "`" + `.**\n",
                   "type": "boolean",
@@ -2990,6 +3769,35 @@ func init() {
                   "x-nullable": true,
                   "example": "Example endpoint."
                 },
+                "port_mappings": {
+                  "description": "Listen ports of the endpoint differing from the service port. Omit to leave unchanged, set to an empty list to listen on the service ports.\n",
+                  "type": "array",
+                  "maxItems": 8,
+                  "items": {
+                    "$ref": "#/definitions/PortMapping"
+                  }
+                },
+                "ports": {
+                  "description": "Subset of the service ports exposed by the endpoint. Omit to leave unchanged, set to an empty list to expose all ports of the service.\n",
+                  "type": "array",
+                  "maxItems": 8,
+                  "uniqueItems": true,
+                  "items": {
+                    "type": "integer",
+                    "format": "int32",
+                    "maximum": 65535,
+                    "x-nullable": false,
+                    "example": 443
+                  }
+                },
+                "rate_limit": {
+                  "description": "Maximum number of new connections per second of this endpoint, overrides the ` + "`" + `rate_limit` + "`" + ` of the service. Omit to leave the current value unchanged, set to 0 for no limit or to -1 to inherit the limit of the service again.\n",
+                  "type": "integer",
+                  "format": "int32",
+                  "minimum": -1,
+                  "x-nullable": true,
+                  "x-omitempty": true
+                },
                 "tags": {
                   "description": "The list of tags on the resource.",
                   "type": "array",
@@ -3081,6 +3889,262 @@ func init() {
         }
       ]
     },
+    "/irules": {
+      "get": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "List iRules",
+        "parameters": [
+          {
+            "$ref": "#/parameters/marker"
+          },
+          {
+            "$ref": "#/parameters/limit"
+          },
+          {
+            "$ref": "#/parameters/sort"
+          },
+          {
+            "$ref": "#/parameters/page_reverse"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A JSON array of iRules",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "items": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/IRule"
+                  }
+                },
+                "links": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/Link"
+                  },
+                  "x-omitempty": true
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:read"
+      },
+      "post": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Add iRule to the catalog",
+        "parameters": [
+          {
+            "description": "iRule",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            },
+            "headers": {
+              "X-Target-Id": {
+                "type": "string",
+                "format": "uuid",
+                "description": "The UUID of the created resource"
+              }
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "409": {
+            "description": "Duplicate iRule name",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:create"
+      }
+    },
+    "/irules/{irule_id}": {
+      "get": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Show details of an iRule",
+        "responses": {
+          "200": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:read"
+      },
+      "put": {
+        "description": "Updates the iRule, endpoints of services using it are updated with the new content.\n",
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Update an existing iRule",
+        "parameters": [
+          {
+            "description": "iRule resource that needs to be updated",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IRuleUpdatable"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "iRule",
+            "schema": {
+              "$ref": "#/definitions/IRule"
+            }
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:update"
+      },
+      "delete": {
+        "tags": [
+          "IRule"
+        ],
+        "summary": "Remove iRule from the catalog",
+        "responses": {
+          "204": {
+            "description": "iRule successfully deleted."
+          },
+          "401": {
+            "description": "Unauthorized",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "iRule is in use by a service",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "422": {
+            "description": "Unprocessable Content",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        },
+        "x-policy": "irule:delete"
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "format": "uuid",
+          "description": "The UUID of the iRule.",
+          "name": "irule_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/quotas": {
       "get": {
         "tags": [
@@ -4307,16 +5371,61 @@ func init() {
           "type": "string",
           "x-nullable": true,
           "x-omitempty": false,
-          "example": "AZ-A"
+          "example": "AZ-A"
+        },
+        "bridge_mappings": {
+          "description": "Local interface carrying each physical network of the agent.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": {
+            "physnet1": "portchannel1",
+            "physnet2": "portchannel2"
+          }
+        },
+        "capabilities": {
+          "description": "Optional features supported by the agent. Services requiring a\ncapability (` + "`" + `wildcard_port` + "`" + ` for ` + "`" + `ports: [0]` + "`" + `, ` + "`" + `snat_pool` + "`" + ` for\n` + "`" + `snat_pool_size` + "`" + ` \u003e 1) are only scheduled to agents reporting it.\n",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": [
+            "snat_pool",
+            "wildcard_port"
+          ]
         },
         "created_at": {
           "$ref": "#/definitions/Timestamp"
         },
+        "device_type": {
+          "description": "Type of the device driven by the agent, if any.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "bigip"
+        },
         "enabled": {
           "description": "Whether the agent is enabled.",
           "type": "boolean",
           "default": true
         },
+        "failover_state": {
+          "description": "Failover state of the active device driven by the agent, if any.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "active"
+        },
+        "health": {
+          "$ref": "#/definitions/AgentHealth"
+        },
         "heartbeat_at": {
           "$ref": "#/definitions/Timestamp"
         },
@@ -4327,11 +5436,24 @@ func init() {
           "example": "agent-host-01"
         },
         "physnet": {
-          "description": "Physical network the agent is connected to.",
+          "description": "Preferred physical network the agent is connected to.",
           "type": "string",
           "x-nullable": true,
           "x-omitempty": false
         },
+        "physnets": {
+          "description": "Physical networks the agent is connected to, in order of preference.\nEndpoint segments are resolved on the first physical network the\ntarget network has a segment on.\n",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": [
+            "physnet1",
+            "physnet2"
+          ]
+        },
         "provider": {
           "description": "Provider type of the agent.",
           "type": "string",
@@ -4350,12 +5472,96 @@ func init() {
         },
         "updated_at": {
           "$ref": "#/definitions/Timestamp"
+        },
+        "version": {
+          "description": "Software version reported by the agent.",
+          "type": "string",
+          "x-nullable": true,
+          "x-omitempty": false,
+          "readOnly": true,
+          "example": "2.7.0"
+        }
+      }
+    },
+    "AgentHealth": {
+      "description": "Health details reported by the agent with every heartbeat.",
+      "type": "object",
+      "properties": {
+        "active_device": {
+          "description": "Hostname of the device AS3 declarations are posted to, F5 agents only.",
+          "type": "string",
+          "example": "bigip-1.example.com"
+        },
+        "devices": {
+          "description": "Reachability of each device driven by the agent, by hostname.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "boolean"
+          },
+          "example": {
+            "bigip-1.example.com": true,
+            "bigip-2.example.com": false
+          }
+        },
+        "last_as3_post_at": {
+          "description": "Time of the last AS3 declaration post, F5 agents only.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "last_as3_post_error": {
+          "description": "Error of the last AS3 declaration post, empty if it succeeded.",
+          "type": "string"
+        },
+        "last_as3_post_tenant": {
+          "description": "Tenant of the last AS3 declaration post.",
+          "type": "string",
+          "example": "Common"
+        },
+        "last_failover_at": {
+          "description": "Time the agent last switched to another active device.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "last_process_services_at": {
+          "description": "Time of the last successful service sync.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "queue_depth": {
+          "description": "Number of services and endpoints of the agent pending processing.",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
         }
       }
     },
     "Endpoint": {
       "type": "object",
       "properties": {
+        "allowed_cidrs": {
+          "description": "Source networks (CIDR notation) of the consumer network allowed to connect to the endpoint, connections from other sources are rejected. Omit or leave empty to allow all sources.\n",
+          "type": "array",
+          "maxItems": 64,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "x-go-type": {
+              "type": "CIDR"
+            },
+            "example": "10.180.0.0/16"
+          },
+          "x-omitempty": false
+        },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of this endpoint, overrides the ` + "`" + `connection_limit` + "`" + ` of the service. Omit to inherit the limit of the service, set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "connection_mirroring": {
           "description": "**Note: This option currently only affects endpoints for services with provider type ` + "`" + `tenant` + "`" + `.**\n\nEnable BIG-IP connection mirroring for high availability failover.\nWhen enabled, connection and persistence information is mirrored to\nthe standby device in a DSC configuration.\nEnabling mirroring can increase latency of the endpoint.\n",
           "type": "boolean",
@@ -4390,9 +5596,39 @@ func init() {
           "x-omitempty": false,
           "example": "Example endpoint."
         },
+        "port_mappings": {
+          "description": "Listen ports of the endpoint differing from the service port, e.g. to expose service port 443 on 8443 or to avoid a port already used in the consumer network. Exposed service ports without mapping are listened on as is.\n",
+          "type": "array",
+          "maxItems": 8,
+          "items": {
+            "$ref": "#/definitions/PortMapping"
+          },
+          "x-omitempty": false
+        },
+        "ports": {
+          "description": "Subset of the service ports exposed by the endpoint, all ports of the service are exposed if omitted or empty. Ports selected or mapped by an endpoint cannot be removed from the service.\n",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "integer",
+            "format": "int32",
+            "maximum": 65535,
+            "x-nullable": false,
+            "example": 443
+          },
+          "x-omitempty": false
+        },
         "project_id": {
           "$ref": "#/definitions/Project"
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of this endpoint, overrides the ` + "`" + `rate_limit` + "`" + ` of the service. Omit to inherit the limit of the service, set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "service_id": {
           "description": "The ID of the service.",
           "type": "string",
@@ -4536,6 +5772,65 @@ func init() {
         }
       }
     },
+    "IRule": {
+      "type": "object",
+      "required": [
+        "name",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "TCL source of the iRule.",
+          "type": "string",
+          "example": "when HTTP_REQUEST { HTTP::header insert X-Forwarded-For [IP::remote_addr] }"
+        },
+        "created_at": {
+          "$ref": "#/definitions/Timestamp"
+        },
+        "description": {
+          "description": "Description of the iRule.",
+          "type": "string",
+          "maxLength": 255,
+          "example": "Inserts the X-Forwarded-For header."
+        },
+        "id": {
+          "description": "The ID of the resource.",
+          "type": "string",
+          "format": "uuid",
+          "readOnly": true
+        },
+        "name": {
+          "description": "Unique name of the iRule, referenced by services.",
+          "type": "string",
+          "maxLength": 64,
+          "pattern": "^[A-Za-z0-9_.-]+$",
+          "example": "x-forwarded-for"
+        },
+        "updated_at": {
+          "$ref": "#/definitions/Timestamp"
+        }
+      },
+      "x-go-name": "irule"
+    },
+    "IRuleUpdatable": {
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "TCL source of the iRule.",
+          "type": "string",
+          "x-nullable": true,
+          "example": "when HTTP_REQUEST { HTTP::header insert X-Forwarded-For [IP::remote_addr] }"
+        },
+        "description": {
+          "description": "Description of the iRule.",
+          "type": "string",
+          "maxLength": 255,
+          "x-nullable": true,
+          "example": "Inserts the X-Forwarded-For header."
+        }
+      },
+      "x-go-name": "irule_updatable"
+    },
     "Link": {
       "type": "object",
       "properties": {
@@ -4549,6 +5844,32 @@ func init() {
         }
       }
     },
+    "PortMapping": {
+      "description": "Maps a port the endpoint listens on to a port of the service.",
+      "type": "object",
+      "required": [
+        "listen_port",
+        "service_port"
+      ],
+      "properties": {
+        "listen_port": {
+          "description": "Port the endpoint listens on.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65535,
+          "minimum": 1,
+          "example": 8443
+        },
+        "service_port": {
+          "description": "Port of the service the connections are forwarded to.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65535,
+          "minimum": 1,
+          "example": 443
+        }
+      }
+    },
     "Project": {
       "description": "The ID of the project owning this resource.",
       "type": "string",
@@ -4692,6 +6013,13 @@ func init() {
         "ip_addresses"
       ],
       "properties": {
+        "anti_affinity_group": {
+          "description": "Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "db-replicas"
+        },
         "availability_zone": {
           "description": "Availability zone of this service. If set to null, the service will be configured as a cross-AZ (cross-availability-zone) service, providing redundancy across all availability zones. Cross-AZ services are only supported in specific regions and providers that have cross-AZ agents deployed. If no cross-AZ agent is available for the requested region/provider, service creation will fail with a \"No available host agent found\" error.",
           "type": "string",
@@ -4700,6 +6028,13 @@ func init() {
           "x-omitempty": false,
           "example": "AZ-A"
         },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit or set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "created_at": {
           "$ref": "#/definitions/Timestamp"
         },
@@ -4760,6 +6095,25 @@ func init() {
             "example": "1.2.3.4"
           }
         },
+        "irules": {
+          "description": "Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions.",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "maxLength": 64
+          }
+        },
+        "members": {
+          "description": "Load balancing options of the IP addresses of the service. Addresses without options are members of weight 1 in priority group 0.",
+          "type": "array",
+          "maxItems": 16,
+          "items": {
+            "$ref": "#/definitions/ServiceMember"
+          },
+          "x-omitempty": false
+        },
         "name": {
           "description": "Name of the service.",
           "type": "string",
@@ -4773,6 +6127,13 @@ func init() {
           "format": "uuid",
           "x-nullable": true
         },
+        "pinned_host": {
+          "description": "Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "lb-agent-01"
+        },
         "ports": {
           "description": "Ports exposed by the service. Port 0 is a wildcard meaning \"all TCP ports\". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.",
           "type": "array",
@@ -4783,7 +6144,6 @@ func init() {
             "type": "integer",
             "format": "int32",
             "maximum": 65535,
-            "minimum": 0,
             "x-nullable": false,
             "example": 80
           },
@@ -4817,6 +6177,13 @@ func init() {
           "type": "boolean",
           "default": true
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit or set to 0 for no limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "require_approval": {
           "description": "Require explicit project approval for the service owner.",
           "type": "boolean",
@@ -4843,6 +6210,9 @@ func init() {
           },
           "x-nullable": true
         },
+        "traffic": {
+          "$ref": "#/definitions/ServiceTraffic"
+        },
         "updated_at": {
           "$ref": "#/definitions/Timestamp"
         },
@@ -4857,6 +6227,42 @@ func init() {
         }
       }
     },
+    "ServiceMember": {
+      "description": "Load balancing options of an IP address of the service.",
+      "type": "object",
+      "required": [
+        "ip_address"
+      ],
+      "properties": {
+        "backup": {
+          "description": "Member only receives connections while no other member is available.",
+          "type": "boolean"
+        },
+        "ip_address": {
+          "description": "IP Address of the service the options apply to.",
+          "type": "string",
+          "x-go-type": {
+            "type": "InetAddress"
+          },
+          "example": "1.2.3.4"
+        },
+        "priority_group": {
+          "description": "Members of the highest priority group receive all connections, members of lower groups only while no member of a higher group is available.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 65534,
+          "example": 1
+        },
+        "weight": {
+          "description": "Relative share of the connections the member receives, defaults to 1.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 256,
+          "minimum": 1,
+          "example": 2
+        }
+      }
+    },
     "ServiceStatus": {
       "description": "Status of the service.\n\n### Status can be one of\n| Status           | Description                            |\n| ---------------- | -------------------------------------- |\n| AVAILABLE        | Service is ready for consumption.      |\n| PENDING_CREATE   | Service is being set up                |\n| PENDING_UPDATE   | Service is being updated               |\n| PENDING_DELETE   | Service is being deleted               |\n| UNAVAILABLE      | Service is unavailable (e.g. disabled) |\n| ERROR_QUOTA      | Service has not enough port quota      |\n",
       "type": "string",
@@ -4870,9 +6276,72 @@ func init() {
       ],
       "readOnly": true
     },
+    "ServiceTraffic": {
+      "description": "Traffic settings of the connections of every endpoint to the service, unset settings use the defaults of the provider. On update, given settings replace the current ones and 0 resets a setting to its default.",
+      "type": "object",
+      "properties": {
+        "connect_timeout": {
+          "description": "Seconds to wait for a connection to the service to be established. Only supported by provider=cp, defaults to 30.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 3600,
+          "x-nullable": true,
+          "example": 5
+        },
+        "http_keep_alive": {
+          "description": "HTTP keep-alive of services with protocol HTTP: ` + "`" + `client` + "`" + ` keeps client connections open between requests and closes the connection to the service after every response, ` + "`" + `both` + "`" + ` also reuses the connections to the service, ` + "`" + `none` + "`" + ` closes both after every response. Only supported by provider=cp, defaults to ` + "`" + `client` + "`" + `. Set it to ` + "`" + `client` + "`" + ` to reset it, e.g. before changing the protocol of the service.",
+          "type": "string",
+          "enum": [
+            "both",
+            "client",
+            "none"
+          ],
+          "x-nullable": true,
+          "example": "both"
+        },
+        "idle_timeout": {
+          "description": "Seconds a connection may be inactive while waiting for the client or the service to send data. Defaults to 32 for provider=cp and to the idle timeout of the agent's TCP profile for provider=tenant.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 604800,
+          "x-nullable": true,
+          "example": 300
+        },
+        "max_connections": {
+          "description": "Maximum number of concurrent connections to each IP address of the service, per endpoint for provider=cp and of all endpoints together for provider=tenant. Connections exceeding the limit of all addresses are queued (provider=cp) or rejected (provider=tenant).",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 1000000,
+          "x-nullable": true,
+          "example": 100
+        },
+        "tunnel_timeout": {
+          "description": "Seconds an established TCP connection, or an HTTP connection upgraded e.g. to WebSocket, may be inactive, superseding idle_timeout once both sides are connected. Only supported by provider=cp, defaults to 3600.",
+          "type": "integer",
+          "format": "int32",
+          "maximum": 604800,
+          "x-nullable": true,
+          "example": 86400
+        }
+      }
+    },
     "ServiceUpdatable": {
       "type": "object",
       "properties": {
+        "anti_affinity_group": {
+          "description": "Scheduler hint: services of the same project sharing an anti-affinity group are never placed on the same agent. Set to an empty string to leave the group.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "db-replicas"
+        },
+        "connection_limit": {
+          "description": "Maximum number of concurrent connections of each endpoint to the service, protecting its backends from a single consumer flooding them. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "description": {
           "description": "Description of the service.",
           "type": "string",
@@ -4898,6 +6367,24 @@ func init() {
             "example": "1.2.3.4"
           }
         },
+        "irules": {
+          "description": "Names of iRules from the catalog attached to every endpoint of the service, after the proxy protocol iRule. Only supported by provider=tenant. Setting this requires cloud admin permissions. Omit to leave unchanged, set to an empty list to detach all iRules.",
+          "type": "array",
+          "maxItems": 8,
+          "uniqueItems": true,
+          "items": {
+            "type": "string",
+            "maxLength": 64
+          }
+        },
+        "members": {
+          "description": "Load balancing options of the IP addresses of the service, replaces the current options. Set to an empty list to balance all addresses round robin.",
+          "type": "array",
+          "maxItems": 16,
+          "items": {
+            "$ref": "#/definitions/ServiceMember"
+          }
+        },
         "name": {
           "description": "Name of the service.",
           "type": "string",
@@ -4905,17 +6392,22 @@ func init() {
           "x-nullable": true,
           "example": "ExampleService"
         },
+        "pinned_host": {
+          "description": "Scheduler hint: pin the service to the named agent host. A pinned service is only placed on that host and is never rebalanced or rescheduled to another agent. Setting this requires cloud admin permissions. Set to an empty string to remove the pin.",
+          "type": "string",
+          "maxLength": 64,
+          "x-nullable": true,
+          "example": "lb-agent-01"
+        },
         "ports": {
           "description": "Ports exposed by the service. Port 0 is a wildcard meaning \"all TCP ports\". When used it must be the sole element of the array. Maximum 8 ports per service; use port 0 for all-ports instead of enumerating.",
           "type": "array",
           "maxItems": 8,
-          "minItems": 0,
           "uniqueItems": true,
           "items": {
             "type": "integer",
             "format": "int32",
             "maximum": 65535,
-            "minimum": 0,
             "x-nullable": false,
             "example": 80
           }
@@ -4934,6 +6426,13 @@ func init() {
           "type": "boolean",
           "x-nullable": true
         },
+        "rate_limit": {
+          "description": "Maximum number of new connections per second of each endpoint to the service. Endpoints can override it. Omit to leave the current value unchanged, set to 0 to remove the limit.\n",
+          "type": "integer",
+          "format": "int32",
+          "x-nullable": true,
+          "x-omitempty": true
+        },
         "require_approval": {
           "description": "Require explicit project approval for the service owner.",
           "type": "boolean",
@@ -4956,6 +6455,9 @@ func init() {
             "maxLength": 128
           }
         },
+        "traffic": {
+          "$ref": "#/definitions/ServiceTraffic"
+        },
         "visibility": {
           "description": "Set global visibility of the service. For ` + "`" + `private` + "`" + ` visibility, RBAC policies can extend the visibility to specific projects.",
           "type": "string",
@@ -5269,6 +6771,11 @@ func init() {
       "name": "RBAC",
       "x-go-name": "rbac"
     },
+    {
+      "description": "### iRule Catalog\nAdministrative API for managing the catalog of vetted iRules that can be attached to services.\n",
+      "name": "IRule",
+      "x-go-name": "irule"
+    },
     {
       "description": "### Quota Operations\nAdministrative API for listing and setting quotas for services and endpoints.\n",
       "name": "Quota"
@@ -37,6 +37,7 @@ import (
 
 	"github.com/sapcc/archer/v2/restapi/operations/agent"
 	"github.com/sapcc/archer/v2/restapi/operations/endpoint"
+	"github.com/sapcc/archer/v2/restapi/operations/irule"
 	"github.com/sapcc/archer/v2/restapi/operations/quota"
 	"github.com/sapcc/archer/v2/restapi/operations/rbac"
 	"github.com/sapcc/archer/v2/restapi/operations/service"
@@ -72,6 +73,13 @@ func NewArcherAPI(spec *loads.Document) *ArcherAPI {
 			return middleware.NotImplemented("operation endpoint.DeleteEndpointEndpointID has not yet been implemented")
 		}),
 
+		IruleDeleteIrulesIruleIDHandler: irule.DeleteIrulesIruleIDHandlerFunc(func(params irule.DeleteIrulesIruleIDParams, principal any) middleware.Responder {
+			_ = params
+			_ = principal
+
+			return middleware.NotImplemented("operation irule.DeleteIrulesIruleID has not yet been implemented")
+		}),
+
 		QuotaDeleteQuotasProjectIDHandler: quota.DeleteQuotasProjectIDHandlerFunc(func(params quota.DeleteQuotasProjectIDParams, principal any) middleware.Responder {
 			_ = params
 			_ = principal
@@ -127,6 +135,20 @@ func NewArcherAPI(spec *loads.Document) *ArcherAPI {
 			return middleware.NotImplemented("operation endpoint.GetEndpointEndpointID has not yet been implemented")
 		}),
 
+		IruleGetIrulesHandler: irule.GetIrulesHandlerFunc(func(params irule.GetIrulesParams, principal any) middleware.Responder {
+			_ = params
+			_ = principal
+
+			return middleware.NotImplemented("operation irule.GetIrules has not yet been implemented")
+		}),
+
+		IruleGetIrulesIruleIDHandler: irule.GetIrulesIruleIDHandlerFunc(func(params irule.GetIrulesIruleIDParams, principal any) middleware.Responder {
+			_ = params
+			_ = principal
+
+			return middleware.NotImplemented("operation irule.GetIrulesIruleID has not yet been implemented")
+		}),
+
 		QuotaGetQuotasHandler: quota.GetQuotasHandlerFunc(func(params quota.GetQuotasParams, principal any) middleware.Responder {
 			_ = params
 			_ = principal
@@ -190,6 +212,13 @@ func NewArcherAPI(spec *loads.Document) *ArcherAPI {
 			return middleware.NotImplemented("operation endpoint.PostEndpoint has not yet been implemented")
 		}),
 
+		IrulePostIrulesHandler: irule.PostIrulesHandlerFunc(func(params irule.PostIrulesParams, principal any) middleware.Responder {
+			_ = params
+			_ = principal
+
+			return middleware.NotImplemented("operation irule.PostIrules has not yet been implemented")
+		}),
+
 		RbacPostRbacPoliciesHandler: rbac.PostRbacPoliciesHandlerFunc(func(params rbac.PostRbacPoliciesParams, principal any) middleware.Responder {
 			_ = params
 			_ = principal
@@ -218,6 +247,13 @@ func NewArcherAPI(spec *loads.Document) *ArcherAPI {
 			return middleware.NotImplemented("operation endpoint.PutEndpointEndpointID has not yet been implemented")
 		}),
 
+		IrulePutIrulesIruleIDHandler: irule.PutIrulesIruleIDHandlerFunc(func(params irule.PutIrulesIruleIDParams, principal any) middleware.Responder {
+			_ = params
+			_ = principal
+
+			return middleware.NotImplemented("operation irule.PutIrulesIruleID has not yet been implemented")
+		}),
+
 		QuotaPutQuotasProjectIDHandler: quota.PutQuotasProjectIDHandlerFunc(func(params quota.PutQuotasProjectIDParams, principal any) middleware.Responder {
 			_ = params
 			_ = principal
@@ -407,6 +443,8 @@ type ArcherAPI struct {
 
 	// EndpointDeleteEndpointEndpointIDHandler sets the operation handler for the delete endpoint endpoint ID operation
 	EndpointDeleteEndpointEndpointIDHandler endpoint.DeleteEndpointEndpointIDHandler
+	// IruleDeleteIrulesIruleIDHandler sets the operation handler for the delete irules irule ID operation
+	IruleDeleteIrulesIruleIDHandler irule.DeleteIrulesIruleIDHandler
 	// QuotaDeleteQuotasProjectIDHandler sets the operation handler for the delete quotas project ID operation
 	QuotaDeleteQuotasProjectIDHandler quota.DeleteQuotasProjectIDHandler
 	// RbacDeleteRbacPoliciesRbacPolicyIDHandler sets the operation handler for the delete rbac policies rbac policy ID operation
@@ -423,6 +461,10 @@ type ArcherAPI struct {
 	EndpointGetEndpointHandler endpoint.GetEndpointHandler
 	// EndpointGetEndpointEndpointIDHandler sets the operation handler for the get endpoint endpoint ID operation
 	EndpointGetEndpointEndpointIDHandler endpoint.GetEndpointEndpointIDHandler
+	// IruleGetIrulesHandler sets the operation handler for the get irules operation
+	IruleGetIrulesHandler irule.GetIrulesHandler
+	// IruleGetIrulesIruleIDHandler sets the operation handler for the get irules irule ID operation
+	IruleGetIrulesIruleIDHandler irule.GetIrulesIruleIDHandler
 	// QuotaGetQuotasHandler sets the operation handler for the get quotas operation
 	QuotaGetQuotasHandler quota.GetQuotasHandler
 	// QuotaGetQuotasDefaultsHandler sets the operation handler for the get quotas defaults operation
@@ -441,6 +483,8 @@ type ArcherAPI struct {
 	ServiceGetServiceServiceIDEndpointsHandler service.GetServiceServiceIDEndpointsHandler
 	// EndpointPostEndpointHandler sets the operation handler for the post endpoint operation
 	EndpointPostEndpointHandler endpoint.PostEndpointHandler
+	// IrulePostIrulesHandler sets the operation handler for the post irules operation
+	IrulePostIrulesHandler irule.PostIrulesHandler
 	// RbacPostRbacPoliciesHandler sets the operation handler for the post rbac policies operation
 	RbacPostRbacPoliciesHandler rbac.PostRbacPoliciesHandler
 	// ServicePostServiceHandler sets the operation handler for the post service operation
@@ -449,6 +493,8 @@ type ArcherAPI struct {
 	ServicePostServiceServiceIDMigrateHandler service.PostServiceServiceIDMigrateHandler
 	// EndpointPutEndpointEndpointIDHandler sets the operation handler for the put endpoint endpoint ID operation
 	EndpointPutEndpointEndpointIDHandler endpoint.PutEndpointEndpointIDHandler
+	// IrulePutIrulesIruleIDHandler sets the operation handler for the put irules irule ID operation
+	IrulePutIrulesIruleIDHandler irule.PutIrulesIruleIDHandler
 	// QuotaPutQuotasProjectIDHandler sets the operation handler for the put quotas project ID operation
 	QuotaPutQuotasProjectIDHandler quota.PutQuotasProjectIDHandler
 	// RbacPutRbacPoliciesRbacPolicyIDHandler sets the operation handler for the put rbac policies rbac policy ID operation
@@ -543,6 +589,9 @@ func (o *ArcherAPI) Validate() error {
 	if o.EndpointDeleteEndpointEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.DeleteEndpointEndpointIDHandler")
 	}
+	if o.IruleDeleteIrulesIruleIDHandler == nil {
+		unregistered = append(unregistered, "irule.DeleteIrulesIruleIDHandler")
+	}
 	if o.QuotaDeleteQuotasProjectIDHandler == nil {
 		unregistered = append(unregistered, "quota.DeleteQuotasProjectIDHandler")
 	}
@@ -567,6 +616,12 @@ func (o *ArcherAPI) Validate() error {
 	if o.EndpointGetEndpointEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.GetEndpointEndpointIDHandler")
 	}
+	if o.IruleGetIrulesHandler == nil {
+		unregistered = append(unregistered, "irule.GetIrulesHandler")
+	}
+	if o.IruleGetIrulesIruleIDHandler == nil {
+		unregistered = append(unregistered, "irule.GetIrulesIruleIDHandler")
+	}
 	if o.QuotaGetQuotasHandler == nil {
 		unregistered = append(unregistered, "quota.GetQuotasHandler")
 	}
@@ -594,6 +649,9 @@ func (o *ArcherAPI) Validate() error {
 	if o.EndpointPostEndpointHandler == nil {
 		unregistered = append(unregistered, "endpoint.PostEndpointHandler")
 	}
+	if o.IrulePostIrulesHandler == nil {
+		unregistered = append(unregistered, "irule.PostIrulesHandler")
+	}
 	if o.RbacPostRbacPoliciesHandler == nil {
 		unregistered = append(unregistered, "rbac.PostRbacPoliciesHandler")
 	}
@@ -606,6 +664,9 @@ func (o *ArcherAPI) Validate() error {
 	if o.EndpointPutEndpointEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.PutEndpointEndpointIDHandler")
 	}
+	if o.IrulePutIrulesIruleIDHandler == nil {
+		unregistered = append(unregistered, "irule.PutIrulesIruleIDHandler")
+	}
 	if o.QuotaPutQuotasProjectIDHandler == nil {
 		unregistered = append(unregistered, "quota.PutQuotasProjectIDHandler")
 	}
@@ -726,6 +787,10 @@ func (o *ArcherAPI) initHandlerCache() {
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
+	o.handlers["DELETE"]["/irules/{irule_id}"] = irule.NewDeleteIrulesIruleID(o.context, o.IruleDeleteIrulesIruleIDHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
 	o.handlers["DELETE"]["/quotas/{project_id}"] = quota.NewDeleteQuotasProjectID(o.context, o.QuotaDeleteQuotasProjectIDHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
@@ -758,6 +823,14 @@ func (o *ArcherAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/irules"] = irule.NewGetIrules(o.context, o.IruleGetIrulesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/irules/{irule_id}"] = irule.NewGetIrulesIruleID(o.context, o.IruleGetIrulesIruleIDHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/quotas"] = quota.NewGetQuotas(o.context, o.QuotaGetQuotasHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -794,6 +867,10 @@ func (o *ArcherAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/irules"] = irule.NewPostIrules(o.context, o.IrulePostIrulesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/rbac-policies"] = rbac.NewPostRbacPolicies(o.context, o.RbacPostRbacPoliciesHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -810,6 +887,10 @@ func (o *ArcherAPI) initHandlerCache() {
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
+	o.handlers["PUT"]["/irules/{irule_id}"] = irule.NewPutIrulesIruleID(o.context, o.IrulePutIrulesIruleIDHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
 	o.handlers["PUT"]["/quotas/{project_id}"] = quota.NewPutQuotasProjectID(o.context, o.QuotaPutQuotasProjectIDHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)